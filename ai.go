@@ -0,0 +1,107 @@
+package pong
+
+import (
+	"math"
+	"math/rand"
+)
+
+var aiAimError = float32(120) // Maximum distance from the ball center the AI aims at
+
+// PaddleAI moves a paddle following the ball, aiming with a random error so it can miss
+type PaddleAI struct {
+	paddle      *GameObject
+	left        bool    // The paddle defends the left side of the court
+	aimError    float32 // Maximum distance from the ball center it aims at
+	aimOffset   float32 // Distance from the ball center it aims at for the current shot
+	approaching bool    // The ball is moving towards the paddle
+	distance    float32 // Horizontal distance of the ball from the paddle
+	random      *rand.Rand
+}
+
+func newPaddleAI(paddle *GameObject, left bool, random *rand.Rand) *PaddleAI {
+	return &PaddleAI{
+		paddle:   paddle,
+		left:     left,
+		aimError: aiAimError,
+		random:   random,
+	}
+}
+
+// Update moves the paddle towards the ball at the paddle velocity, keeping it inside the court
+func (ai *PaddleAI) Update(deltaTime float64, ball *BallObject, height int) {
+	// Pick a new aim every time the ball starts moving towards the paddle or is served again after a goal
+	approaching := (ball.velocity.X() < 0) == ai.left
+	distance := float32(math.Abs(float64(ball.position.X() + ball.radius - ai.paddle.position.X() - ai.paddle.size.X()/2)))
+	if approaching && (!ai.approaching || distance > ai.distance) {
+		ai.aimOffset = (ai.random.Float32()*2 - 1) * ai.aimError
+	}
+	ai.approaching = approaching
+	ai.distance = distance
+
+	target := ball.position.Y() + ball.radius + ai.aimOffset
+	center := ai.paddle.position.Y() + ai.paddle.size.Y()/2
+	maxMove := paddleVelocity * float32(deltaTime)
+	move := target - center
+	if move > maxMove {
+		move = maxMove
+	} else if move < -maxMove {
+		move = -maxMove
+	}
+	y := ai.paddle.position.Y() + move
+	if y < 0 {
+		y = 0
+	} else if y > float32(height)-ai.paddle.size.Y() {
+		y = float32(height) - ai.paddle.size.Y()
+	}
+	ai.paddle.velocity[1] = (y - ai.paddle.position.Y()) / float32(deltaTime)
+	ai.paddle.position[1] = y
+}
+
+// Difficulty is how well the computer of the single player mode plays
+type Difficulty int
+
+// Difficulties of the computer of the single player mode
+const (
+	DifficultyEasy   Difficulty = iota // Reacts to the shots twice as late
+	DifficultyMedium                   // Reacts to the shots with the reaction delay
+	DifficultyHard                     // Moves at once where the ball will reach the paddle, bouncing off the walls
+)
+
+var difficultyNames = []string{"Easy", "Medium", "Hard"}
+
+// String returns the name of the difficulty
+func (d Difficulty) String() string {
+	if d < 0 || int(d) >= len(difficultyNames) {
+		return "unknown"
+	}
+	return difficultyNames[d]
+}
+
+// Next returns the following difficulty, back to the easiest after the hardest
+func (d Difficulty) Next() Difficulty {
+	return (d + 1) % Difficulty(len(difficultyNames))
+}
+
+// Personality returns how the computer plays at the difficulty, reacting to the shots the seconds late
+func (d Difficulty) Personality(reaction float64) Personality {
+	personality := Personality{Name: d.String(), Reaction: reaction, Speed: 1}
+	switch d {
+	case DifficultyEasy:
+		personality.Reaction *= 2
+	case DifficultyHard:
+		personality.Reaction = 0
+		personality.Predict = true
+		personality.Recenter = true
+	}
+	return personality
+}
+
+// SetDifficulty changes how well the computer of the single player mode plays
+func (g *Game) SetDifficulty(difficulty Difficulty) {
+	g.difficulty = difficulty
+}
+
+// Difficulty returns how well the computer of the single player mode plays
+func (g *Game) Difficulty() Difficulty {
+	return g.difficulty
+}
@@ -0,0 +1,104 @@
+package pong
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lucatironi/go-pong/pkg/bot"
+)
+
+// AIPluginsDir is where the AI controllers are loaded from, one Go plugin <name>.so each
+const AIPluginsDir = "./ai"
+
+// FindAIPlugins returns the names of the plugins found in the directory sorted, none when it's missing
+func FindAIPlugins(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".so" {
+			names = append(names, strings.TrimSuffix(file.Name(), ".so"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadPluginOpponent returns an opponent playing the paddle of the player with the controller of
+// the plugin of the directory
+func LoadPluginOpponent(dir, name string, player int) (*Opponent, error) {
+	controller, err := bot.LoadPlugin(filepath.Join(dir, name+".so"))
+	if err != nil {
+		return nil, err
+	}
+	return NewPluginOpponent(name, controller, player), nil
+}
+
+// NewPluginOpponent returns an opponent playing the paddle of the player with the controller
+func NewPluginOpponent(name string, controller bot.AIController, player int) *Opponent {
+	return &Opponent{personality: Personality{Name: name}, player: player, controller: controller}
+}
+
+// Plugin tells if the opponent is played by the controller of a plugin rather than a personality
+func (o *Opponent) Plugin() bool {
+	return o.controller != nil
+}
+
+// controlPlugin sets the input of the paddle of the opponent to the move of its controller, a
+// controller that panics is reported once and stays put from then on
+func (o *Opponent) controlPlugin(state State, input *Input) {
+	o.tick++
+	if o.failed {
+		return
+	}
+	move := bot.MoveStay
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Println("ERROR::AI: controller", o.Name(), "failed:", r)
+				o.failed = true
+				move = bot.MoveStay
+			}
+		}()
+		move = o.controller.Move(state.Bot(o.tick, o.player))
+	}()
+	input.SetBotMove(move, o.player)
+}
+
+// Bot returns the state as seen by the bot playing the paddle of the player
+func (s State) Bot(tick uint64, player int) bot.State {
+	paddle, opponent := s.Paddle1, s.Paddle2
+	score := [2]int{s.Score1, s.Score2}
+	if player == 2 {
+		paddle, opponent = opponent, paddle
+		score = [2]int{s.Score2, s.Score1}
+	}
+	return bot.State{
+		Tick:         tick,
+		Phase:        s.Phase.String(),
+		Player:       player,
+		Ball:         s.Ball,
+		BallVelocity: s.BallVelocity,
+		Paddle:       paddle,
+		Opponent:     opponent,
+		Score:        score,
+		Court:        [2]float32{VirtualWidth, VirtualHeight},
+	}
+}
+
+// SetBotMove sets the input of the paddle of the player to the move asked by a bot
+func (i *Input) SetBotMove(move string, player int) {
+	up, down := &i.Paddle1Up, &i.Paddle1Down
+	if player == 2 {
+		up, down = &i.Paddle2Up, &i.Paddle2Down
+	}
+	*up, *down = move == bot.MoveUp, move == bot.MoveDown
+}
@@ -0,0 +1,94 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	attractWait     = 15.0 // Seconds in the menu without input before the demo starts
+	attractReaction = 0.15 // Seconds the computers of the demo see the ball late by
+)
+
+// attract plays a match between two computers behind the menu when nobody plays
+type attract struct {
+	enabled     bool
+	idle        float64             // Seconds in the menu without input
+	controllers [2]PaddleController // Playing the paddles of the demo, none while it isn't running
+}
+
+// SetAttract lets the demo start after a while in the menu without input, off by default: the
+// players' input doesn't drive it, so it's left out of the recorded sessions
+func (g *Game) SetAttract(enabled bool) {
+	g.attract.enabled = enabled
+	g.Wake()
+}
+
+// Attracting tells if the demo is playing behind the menu
+func (g *Game) Attracting() bool {
+	return g.attract.controllers[0] != nil
+}
+
+// Wake tells the game the players are around: it stops the demo, back to the menu, and waits again
+// before starting it
+func (g *Game) Wake() {
+	g.attract.idle = 0
+	if !g.Attracting() {
+		return
+	}
+	g.attract.controllers = [2]PaddleController{}
+	g.Reset()
+	g.animateMenu()
+}
+
+// startAttract resets the game and hands the paddles to the computers
+func (g *Game) startAttract() {
+	g.Reset()
+	g.attract.controllers = [2]PaddleController{
+		NewDifficultyOpponent(DifficultyMedium, 1, attractReaction, g.presentation),
+		NewDifficultyOpponent(DifficultyMedium, 2, attractReaction, g.presentation),
+	}
+}
+
+// processAttract counts the time in the menu without input, starting the demo after a while, and
+// moves its paddles while it plays. It tells if the input was used, stopping the demo
+func (g *Game) processAttract(input Input, deltaTime float64) bool {
+	if !g.attract.enabled {
+		return false
+	}
+	if input != (Input{}) {
+		playing := g.Attracting()
+		g.Wake()
+		return playing
+	}
+	if !g.Attracting() {
+		g.attract.idle += deltaTime
+		if g.attract.idle >= attractWait {
+			g.startAttract()
+		}
+		return false
+	}
+	// The computers only play in matches
+	state := g.State()
+	state.Phase = GameActive
+	var demo Input
+	for _, controller := range g.attract.controllers {
+		controller.Control(state, &demo, deltaTime)
+	}
+	g.movePaddles(demo, deltaTime)
+	return true
+}
+
+// simulateAttract plays the demo match, starting another one once it's won
+func (g *Game) simulateAttract(deltaTime float64) simulationEvents {
+	events := g.simulate(deltaTime)
+	if g.state != GameMenu {
+		g.Reset()
+		g.state = GameMenu
+	}
+	return events
+}
+
+// drawAttract tells the match behind the menu is a demo
+func (g *Game) drawAttract() {
+	if g.state == GameMenu && g.Attracting() && !g.editing {
+		g.drawCentered(200, 0.35, mgl.Vec3{0.6, 0.6, 0.6}, "DEMO")
+	}
+}
@@ -0,0 +1,28 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var autopilotColor = mgl.Vec3{1.0, 0.8, 0.1}
+
+// SetAutopilot shows that the computer plays the paddle of the player (1 or 2) in their place
+func (g *Game) SetAutopilot(player int, on bool) {
+	g.autopilot[player-1] = on
+}
+
+// drawAutopilot renders a notice under the score on the side of the players the computer plays for
+func (g *Game) drawAutopilot() {
+	if g.state != GameActive && g.state != GamePaused {
+		return
+	}
+	for side, player := range g.screenPlayers() {
+		if !g.autopilot[player-1] {
+			continue
+		}
+		x := float32(40)
+		if side == 1 {
+			width, _ := g.text.MeasureText(0.3, "CPU playing - move to take over")
+			x = float32(g.width) - 40 - width
+		}
+		g.text.RenderText(x, 160, 0.3, autopilotColor, "CPU playing - move to take over")
+	}
+}
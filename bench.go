@@ -0,0 +1,53 @@
+package pong
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// RunBench runs the given seconds of AI vs AI simulation as fast as possible without a window,
+// then reports the simulation speed, the allocations and the collisions
+func RunBench(seconds, updateRate float64) {
+	game := New(Options{})
+	game.state = GameActive
+	ai1 := newPaddleAI(game.paddle1, true, game.presentation)
+	ai2 := newPaddleAI(game.paddle2, false, game.presentation)
+
+	step := 1.0 / updateRate
+	ticks := int(seconds * updateRate)
+	var collisions, goals, matches int
+
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < ticks; i++ {
+		ai1.Update(step, game.ball, game.height)
+		ai2.Update(step, game.ball, game.height)
+		events := game.simulate(step)
+		if events.paddleHit {
+			collisions++
+		}
+		if events.scored != 0 {
+			goals++
+		}
+		if events.won {
+			matches++
+			game.resetObjects()
+			game.match.reset()
+			game.state = GameActive
+		}
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	allocs := after.Mallocs - before.Mallocs
+	bytes := after.TotalAlloc - before.TotalAlloc
+	fmt.Printf("Simulated %.0fs (%v ticks at %v Hz) in %v\n", seconds, ticks, updateRate, elapsed)
+	fmt.Printf("%12.0f ticks/sec\n", float64(ticks)/elapsed.Seconds())
+	fmt.Printf("%12v allocations (%.2f per tick, %v bytes)\n", allocs, float64(allocs)/float64(ticks), bytes)
+	fmt.Printf("%12v collisions\n", collisions)
+	fmt.Printf("%12v goals\n", goals)
+	fmt.Printf("%12v matches\n", matches)
+}
@@ -0,0 +1,149 @@
+package pong
+
+import (
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// Binding is an action bound to a key, the keys are named by the owner of the bindings screen
+type Binding struct {
+	Action string
+	Key    string
+}
+
+// BindingsControls are the commands of a bindings screen for one frame
+type BindingsControls struct {
+	Navigation         // Activating an action waits for the next key pressed to bind it
+	Reset      bool    // Restore the default bindings
+	Close      bool    // Close the screen, or cancel the rebinding
+	Key        string  // Name of the key pressed in the frame, if any
+	Pointer    Pointer // Hovering focuses an action, clicking it rebinds it
+}
+
+// BindingsScreen lists the actions with their keys and rebinds them to the next key pressed
+type BindingsScreen struct {
+	game      *Game
+	bindings  []Binding
+	defaults  []Binding
+	focus     focus
+	capturing bool // Waiting for the key to bind to the focused action
+	open      bool
+}
+
+// NewBindingsScreen returns a closed screen listing the bindings, reset to the defaults on request
+func NewBindingsScreen(game *Game, bindings, defaults []Binding) *BindingsScreen {
+	s := &BindingsScreen{
+		game:     game,
+		bindings: append([]Binding{}, bindings...),
+		defaults: append([]Binding{}, defaults...),
+	}
+	if game.initialized {
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { s.draw() }))
+	}
+	return s
+}
+
+// Open shows the screen with the first action focused
+func (s *BindingsScreen) Open() {
+	s.open = true
+	s.focus.reset(len(s.bindings))
+	s.capturing = false
+}
+
+// IsOpen tells if the screen is shown
+func (s *BindingsScreen) IsOpen() bool {
+	return s.open
+}
+
+// SetBindings replaces the bindings listed, when the keys are changed elsewhere
+func (s *BindingsScreen) SetBindings(bindings []Binding) {
+	s.bindings = append([]Binding{}, bindings...)
+}
+
+// Bindings returns the current bindings
+func (s *BindingsScreen) Bindings() []Binding {
+	return append([]Binding{}, s.bindings...)
+}
+
+// Update applies the controls and reports whether the bindings changed
+func (s *BindingsScreen) Update(controls BindingsControls) bool {
+	if !s.open || len(s.bindings) == 0 {
+		return false
+	}
+	if s.capturing {
+		switch {
+		case controls.Close:
+			s.capturing = false
+		case controls.Key != "":
+			s.capturing = false
+			changed := s.bindings[s.focus.index].Key != controls.Key
+			s.bindings[s.focus.index].Key = controls.Key
+			return changed
+		}
+		return false
+	}
+	switch {
+	case controls.Close:
+		s.open = false
+	case s.focus.update(controls.Navigation, controls.Pointer, s.row):
+		s.capturing = true
+	case controls.Reset:
+		s.bindings = append([]Binding{}, s.defaults...)
+		return true
+	}
+	return false
+}
+
+// Conflicts returns the other actions bound to the same key as the action at the index
+func (s *BindingsScreen) Conflicts(index int) []string {
+	var actions []string
+	for i, binding := range s.bindings {
+		if i != index && binding.Key == s.bindings[index].Key {
+			actions = append(actions, binding.Action)
+		}
+	}
+	return actions
+}
+
+// row returns the area of the action at the index, the rows get closer to fit the actions on the screen
+func (s *BindingsScreen) row(i int) (mgl.Vec2, mgl.Vec2) {
+	spacing := float32(60)
+	if fit := float32(s.game.height-190-120) / float32(len(s.bindings)); fit < spacing {
+		spacing = fit
+	}
+	return mgl.Vec2{float32(s.game.width/2) - 480, 190 + float32(i)*spacing}, mgl.Vec2{1200, spacing - 8}
+}
+
+// draw renders the bindings over the whole screen, the conflicting ones in red
+func (s *BindingsScreen) draw() {
+	if !s.open {
+		return
+	}
+	g := s.game
+	g.renderer.Draw(mgl.Vec2{0, 0}, mgl.Vec2{float32(g.width), float32(g.height)}, 0, mgl.Vec3{0.1, 0.1, 0.1})
+	x := float32(g.width/2) - 460
+	g.text.RenderText(x, 100, 0.6, mgl.Vec3{1.0, 1.0, 1.0}, "CONTROLS")
+	for i, binding := range s.bindings {
+		position, size := s.row(i)
+		y := position.Y() + 10
+		color := mgl.Vec3{0.6, 0.6, 0.6}
+		if i == s.focus.index {
+			g.drawFocusRing(position, size)
+			color = mgl.Vec3{1.0, 0.8, 0.1}
+		}
+		g.text.RenderText(x, y, 0.35, color, "%v", binding.Action)
+		if i == s.focus.index && s.capturing {
+			g.text.RenderText(x+420, y, 0.35, color, "Press a key...")
+			continue
+		}
+		keyColor := color
+		if conflicts := s.Conflicts(i); len(conflicts) > 0 {
+			keyColor = mgl.Vec3{1.0, 0.3, 0.3}
+			g.text.RenderText(x+640, y+6, 0.25, keyColor, "also %v", strings.Join(conflicts, ", "))
+		}
+		g.text.RenderText(x+420, y, 0.35, keyColor, "%v", binding.Key)
+	}
+	g.text.RenderText(x, float32(g.height)-80, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "UP/DOWN/TAB select - ENTER rebind - DELETE reset all - ESC back")
+}
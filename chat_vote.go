@@ -0,0 +1,92 @@
+package pong
+
+import (
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// chatVoteWindow is how long the votes are collected before being tallied, in seconds:
+// every user counts once per window, their last vote wins
+var chatVoteWindow = 0.5
+
+// ChatVotes tallies the "up" and "down" votes of a chat to move a paddle
+type ChatVotes struct {
+	votes     map[string]int // Direction voted by each user in the current window
+	timer     float64        // Time left in the current window
+	up, down  int            // Tally of the last window
+	direction int            // Direction decided by the last window: -1 up, 1 down, 0 stay
+}
+
+// NewChatVotes returns an empty tally
+func NewChatVotes() *ChatVotes {
+	return &ChatVotes{
+		votes: make(map[string]int),
+		timer: chatVoteWindow,
+	}
+}
+
+// Vote counts a chat message when it starts with "up" or "down"
+func (c *ChatVotes) Vote(user, text string) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return
+	}
+	switch fields[0] {
+	case "up":
+		c.votes[user] = -1
+	case "down":
+		c.votes[user] = 1
+	}
+}
+
+// Update tallies the votes at the end of every window
+func (c *ChatVotes) Update(deltaTime float64) {
+	c.timer -= deltaTime
+	if c.timer > 0 {
+		return
+	}
+	c.timer += chatVoteWindow
+	c.up, c.down = 0, 0
+	for user, vote := range c.votes {
+		if vote < 0 {
+			c.up++
+		} else {
+			c.down++
+		}
+		delete(c.votes, user)
+	}
+	switch {
+	case c.up > c.down:
+		c.direction = -1
+	case c.down > c.up:
+		c.direction = 1
+	default:
+		c.direction = 0
+	}
+}
+
+// Apply moves the paddle of the player as decided by the chat, overriding the input
+func (c *ChatVotes) Apply(input *Input, player int) {
+	up, down := c.direction < 0, c.direction > 0
+	if player == 1 {
+		input.Paddle1Up, input.Paddle1Down = up, down
+	} else {
+		input.Paddle2Up, input.Paddle2Down = up, down
+	}
+}
+
+// ShowChatVotes draws the tally of the votes above the paddle of the player
+func (g *Game) ShowChatVotes(votes *ChatVotes, player int) {
+	if !g.initialized {
+		return
+	}
+	x := float32(60)
+	if player == 2 {
+		x = float32(g.width) - 460
+	}
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) {
+		g.text.RenderText(x, 60, 0.3, mgl.Vec3{0.6, 0.4, 1.0}, "CHAT  UP %v : DOWN %v", votes.up, votes.down)
+	}))
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+)
+
+// Actions that can be bound to a key
+const (
+	actionPaddle1Up   = "Player 1 up"
+	actionPaddle1Down = "Player 1 down"
+	actionSkin1Next   = "Player 1 skin"
+	actionWell1       = "Player 1 well"
+	actionPaddle2Up   = "Player 2 up"
+	actionPaddle2Down = "Player 2 down"
+	actionSkin2Next   = "Player 2 skin"
+	actionWell2       = "Player 2 well"
+	actionPaddle3Up   = "Player 3 up"
+	actionPaddle3Down = "Player 3 down"
+	actionPaddle4Up   = "Player 4 up"
+	actionPaddle4Down = "Player 4 down"
+	actionStart       = "Start"
+	actionPause       = "Pause"
+	actionTutorial    = "Tutorial"
+	actionWarmUp      = "Warm-up"
+)
+
+// playerActions are the actions of the left and the right player, their keys are saved in the profile
+// the player picked under the profileActions names, so they follow the profile on either side
+var (
+	playerActions = [2][]string{
+		{actionPaddle1Up, actionPaddle1Down, actionSkin1Next, actionWell1},
+		{actionPaddle2Up, actionPaddle2Down, actionSkin2Next, actionWell2},
+	}
+	profileActions = []string{"up", "down", "skin", "well"}
+)
+
+// defaultBindings are the keys of the actions, in the order listed by the bindings screen
+var defaultBindings = []pong.Binding{
+	{Action: actionPaddle1Up, Key: "W"},
+	{Action: actionPaddle1Down, Key: "S"},
+	{Action: actionSkin1Next, Key: "D"},
+	{Action: actionWell1, Key: "A"},
+	{Action: actionPaddle2Up, Key: "UP"},
+	{Action: actionPaddle2Down, Key: "DOWN"},
+	{Action: actionSkin2Next, Key: "RIGHT"},
+	{Action: actionWell2, Key: "LEFT"},
+	{Action: actionPaddle3Up, Key: "R"},
+	{Action: actionPaddle3Down, Key: "F"},
+	{Action: actionPaddle4Up, Key: "U"},
+	{Action: actionPaddle4Down, Key: "J"},
+	{Action: actionStart, Key: "ENTER"},
+	{Action: actionPause, Key: "P"},
+	{Action: actionTutorial, Key: "T"},
+	{Action: actionWarmUp, Key: "SPACE"},
+}
+
+// keyNames are the names of the keys in the config file, letters and digits are added by init
+var keyNames = map[glfw.Key]string{
+	glfw.KeySpace:        "SPACE",
+	glfw.KeyEnter:        "ENTER",
+	glfw.KeyTab:          "TAB",
+	glfw.KeyBackspace:    "BACKSPACE",
+	glfw.KeyUp:           "UP",
+	glfw.KeyDown:         "DOWN",
+	glfw.KeyLeft:         "LEFT",
+	glfw.KeyRight:        "RIGHT",
+	glfw.KeyLeftShift:    "LSHIFT",
+	glfw.KeyRightShift:   "RSHIFT",
+	glfw.KeyLeftControl:  "LCTRL",
+	glfw.KeyRightControl: "RCTRL",
+	glfw.KeyLeftAlt:      "LALT",
+	glfw.KeyRightAlt:     "RALT",
+	glfw.KeyComma:        "COMMA",
+	glfw.KeyPeriod:       "PERIOD",
+	glfw.KeySlash:        "SLASH",
+	glfw.KeySemicolon:    "SEMICOLON",
+}
+
+func init() {
+	for key := glfw.KeyA; key <= glfw.KeyZ; key++ {
+		keyNames[key] = string(rune('A' + key - glfw.KeyA))
+	}
+	for key := glfw.Key0; key <= glfw.Key9; key++ {
+		keyNames[key] = string(rune('0' + key - glfw.Key0))
+	}
+}
+
+// keyName returns the name of the key, the ones without a name are written by code
+func keyName(key glfw.Key) string {
+	if name, ok := keyNames[key]; ok {
+		return name
+	}
+	return fmt.Sprintf("KEY%d", key)
+}
+
+// parseKey returns the key with the given name
+func parseKey(name string) (glfw.Key, error) {
+	name = strings.ToUpper(name)
+	for key, keyName := range keyNames {
+		if keyName == name {
+			return key, nil
+		}
+	}
+	var code int
+	if _, err := fmt.Sscanf(name, "KEY%d", &code); err == nil {
+		return glfw.Key(code), nil
+	}
+	return 0, fmt.Errorf("unknown key %q", name)
+}
+
+// keyBindings maps the actions to the keys, they start as the defaults overridden by the config
+type keyBindings map[string]glfw.Key
+
+// newKeyBindings returns the bindings of the config, the ones missing or invalid are the defaults
+func newKeyBindings(config Config) (keyBindings, error) {
+	bindings := make(keyBindings)
+	for _, binding := range defaultBindings {
+		bindings[binding.Action], _ = parseKey(binding.Key)
+	}
+	var err error
+	for action, name := range config.Bindings {
+		key, keyErr := parseKey(name)
+		if _, ok := bindings[action]; !ok {
+			err = fmt.Errorf("unknown action %q in the bindings", action)
+		} else if keyErr != nil {
+			err = keyErr
+		} else {
+			bindings[action] = key
+		}
+	}
+	return bindings, err
+}
+
+// list returns the bindings in the order of the defaults, named for the bindings screen
+func (b keyBindings) list() []pong.Binding {
+	var list []pong.Binding
+	for _, binding := range defaultBindings {
+		list = append(list, pong.Binding{Action: binding.Action, Key: keyName(b[binding.Action])})
+	}
+	return list
+}
+
+// set replaces the bindings with the ones edited in the bindings screen
+func (b keyBindings) set(list []pong.Binding) {
+	for _, binding := range list {
+		if key, err := parseKey(binding.Key); err == nil {
+			b[binding.Action] = key
+		}
+	}
+}
+
+// config returns the bindings for the config file, only the ones changed from the defaults and
+// not saved in the profiles
+func (b keyBindings) config() map[string]string {
+	changed := make(map[string]string)
+	for _, binding := range defaultBindings {
+		if isPlayerAction(binding.Action) {
+			continue
+		}
+		if name := keyName(b[binding.Action]); name != binding.Key {
+			changed[binding.Action] = name
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return changed
+}
+
+// profile returns the keys of the player actions for the profile, only the ones changed from the defaults
+func (b keyBindings) profile(player int) map[string]string {
+	changed := make(map[string]string)
+	for i, action := range playerActions[player-1] {
+		if name := keyName(b[action]); name != defaultKey(action) {
+			changed[profileActions[i]] = name
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return changed
+}
+
+// applyProfile binds the player actions to the keys of the profile, the defaults where it has none
+func (b keyBindings) applyProfile(player int, keys map[string]string) error {
+	var err error
+	for i, action := range playerActions[player-1] {
+		b[action], _ = parseKey(defaultKey(action))
+		if name, ok := keys[profileActions[i]]; ok {
+			key, keyErr := parseKey(name)
+			if keyErr != nil {
+				err = keyErr
+				continue
+			}
+			b[action] = key
+		}
+	}
+	return err
+}
+
+// isPlayerAction tells if the action belongs to one of the players
+func isPlayerAction(action string) bool {
+	for _, actions := range playerActions {
+		for _, playerAction := range actions {
+			if action == playerAction {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultKey returns the name of the default key of the action
+func defaultKey(action string) string {
+	for _, binding := range defaultBindings {
+		if binding.Action == action {
+			return binding.Key
+		}
+	}
+	return ""
+}
+
+// readBindingsControls maps the keyboard and mouse state to the bindings screen commands, any key
+// is reported to be bound but ESC, which cancels
+func readBindingsControls(window *glfw.Window) pong.BindingsControls {
+	controls := pong.BindingsControls{
+		Navigation: readNavigation(),
+		Reset:      keyboard.Pressed(glfw.KeyDelete),
+		Close:      keyboard.Pressed(glfw.KeyEscape),
+		Pointer:    readPointer(window),
+	}
+	if key, ok := keyboard.LastPressed(); ok && key != glfw.KeyEscape {
+		controls.Key = keyName(key)
+	}
+	return controls
+}
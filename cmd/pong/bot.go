@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/lucatironi/go-pong/pkg/bot"
+)
+
+// listenBots opens the bot socket asked by the flags, the unix socket taking precedence, nil when
+// none was asked
+func listenBots(port int, socket string) (*bot.Server, error) {
+	switch {
+	case socket != "":
+		return bot.Listen("unix", socket)
+	case port != 0:
+		return bot.Listen("tcp", fmt.Sprintf("localhost:%v", port))
+	}
+	return nil, nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	pong "github.com/lucatironi/go-pong"
+)
+
+// Config holds the settings read from the config file, the command line flags override them
+type Config struct {
+	UpdateRate  float64 `json:"update_rate"`  // Fixed simulation updates per second, one of the tickRates
+	RenderRate  float64 `json:"render_rate"`  // Frames rendered per second, zero follows the monitor refresh
+	Quality     string  `json:"quality"`      // Graphics quality preset
+	VSync       string  `json:"vsync"`        // Swap mode, one of the vsyncModes
+	Resolution  string  `json:"resolution"`   // Size of the window as WIDTHxHEIGHT, or of the screen when fullscreen
+	DisplayMode string  `json:"display_mode"` // One of the displayModes
+	MSAA        int     `json:"msaa"`         // Multisampling samples, -1 follows the quality preset
+	Theme       string  `json:"theme"`        // Colors of the court
+	Trail       string  `json:"trail"`        // Particles left by the ball, the ones of the skins or a preset of the mods
+	// Keys of the actions changed from the defaults, by action name, the ones of the players are in their profiles
+	Bindings map[string]string `json:"bindings,omitempty"`
+	// Profiles picked by the left and the right player
+	Players [2]string `json:"players"`
+	// Pause the match when the window loses the focus
+	PauseOnFocusLoss bool `json:"pause_on_focus_loss"`
+	// Sides of the players, one of the controlPresets
+	ControlPreset string `json:"control_preset"`
+	// Mutators of the matches, by name
+	Mutators []string `json:"mutators,omitempty"`
+	// Combinations of mutators saved from the mutators screen, by preset name
+	MutatorPresets map[string][]string `json:"mutator_presets,omitempty"`
+	// Level of the matches, the name of a file in the levels directory
+	Level string `json:"level,omitempty"`
+	// Seed of the random numbers, zero picks a new one every run
+	Seed int64 `json:"seed,omitempty"`
+	// Pulse the edges of the screen on the hits and the goals, for the players who can't hear them
+	CueIndicators bool `json:"cue_indicators,omitempty"`
+	// Leave scorch marks where the ball hits the walls and the paddles
+	Decals bool `json:"decals,omitempty"`
+	// Color the trail of the ball by the player who last hit it
+	PlayerTrails bool `json:"player_trails,omitempty"`
+}
+
+// tickRates are the supported fixed update rates, the physics constants are all per second
+// so the simulation plays the same at any of them
+var tickRates = []float64{60, 120, 240}
+
+func validTickRate(rate float64) bool {
+	for _, tickRate := range tickRates {
+		if rate == tickRate {
+			return true
+		}
+	}
+	return false
+}
+
+// validTrail tells if the trail is the one of the skins or a particle preset of the mods
+func validTrail(trail string) bool {
+	for _, name := range modContent.TrailNames() {
+		if trail == name {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultConfig() Config {
+	return Config{
+		UpdateRate:       120,
+		RenderRate:       0,
+		Quality:          pong.QualityHigh.String(),
+		VSync:            "on",
+		Resolution:       "800x600",
+		DisplayMode:      "windowed",
+		MSAA:             -1,
+		Theme:            modContent.ThemeNames()[0],
+		Trail:            pong.SkinTrail,
+		PauseOnFocusLoss: true,
+		ControlPreset:    controlPresets[0].name,
+	}
+}
+
+// loadConfig reads the config file over the default settings, a missing file is not an error
+func loadConfig(file string) (Config, error) {
+	config := defaultConfig()
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return config, nil
+	} else if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return defaultConfig(), fmt.Errorf("failed to parse %v: %v", file, err)
+	}
+	return config, config.validate()
+}
+
+// saveConfig writes the settings to the config file
+func saveConfig(file string, config Config) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(data, '\n'), 0644)
+}
+
+// applyFlags overrides the settings with the command line flags explicitly set
+func (c *Config) applyFlags() error {
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "update-rate":
+			c.UpdateRate = *updateRate
+		case "render-rate":
+			c.RenderRate = *renderRate
+		case "quality":
+			c.Quality = *quality
+		case "vsync":
+			c.VSync = *vsync
+		case "controls":
+			c.ControlPreset = *preset
+		case "seed":
+			c.Seed = *seed
+		}
+	})
+	return c.validate()
+}
+
+// validate restores the default of the invalid settings and reports them
+func (c *Config) validate() error {
+	defaults := defaultConfig()
+	if !validTickRate(c.UpdateRate) {
+		rate := c.UpdateRate
+		c.UpdateRate = defaults.UpdateRate
+		return fmt.Errorf("unsupported update rate %v, expected one of %v", rate, tickRates)
+	}
+	if c.RenderRate < 0 {
+		c.RenderRate = defaults.RenderRate
+		return fmt.Errorf("the render rate can't be negative")
+	}
+	if _, err := pong.ParseQuality(c.Quality); err != nil {
+		c.Quality = defaults.Quality
+		return err
+	}
+	if !validVSyncMode(c.VSync) {
+		mode := c.VSync
+		c.VSync = defaults.VSync
+		return fmt.Errorf("unknown vsync mode %q, expected one of %v", mode, vsyncModes)
+	}
+	if _, _, err := parseResolution(c.Resolution); err != nil {
+		c.Resolution = defaults.Resolution
+		return err
+	}
+	if !validDisplayMode(c.DisplayMode) {
+		mode := c.DisplayMode
+		c.DisplayMode = defaults.DisplayMode
+		return fmt.Errorf("unknown display mode %q, expected one of %v", mode, displayModes)
+	}
+	if c.MSAA < -1 || c.MSAA > maxMSAA {
+		c.MSAA = defaults.MSAA
+		return fmt.Errorf("unsupported MSAA samples, expected -1 to follow the quality or 0 to %v", maxMSAA)
+	}
+	if _, err := modContent.ParseTheme(c.Theme); err != nil {
+		c.Theme = defaults.Theme
+		return err
+	}
+	if !validTrail(c.Trail) {
+		trail := c.Trail
+		c.Trail = defaults.Trail
+		return fmt.Errorf("unknown trail %q, expected one of %v", trail, modContent.TrailNames())
+	}
+	if findControlPreset(c.ControlPreset) == -1 {
+		preset := c.ControlPreset
+		c.ControlPreset = defaults.ControlPreset
+		return fmt.Errorf("unknown control preset %q, expected one of %v", preset, controlPresetNames())
+	}
+	if _, err := pong.ParseMutators(c.Mutators); err != nil {
+		c.Mutators = defaults.Mutators
+		return err
+	}
+	for name, mutators := range c.MutatorPresets {
+		if _, err := pong.ParseMutators(mutators); err != nil {
+			delete(c.MutatorPresets, name)
+			return fmt.Errorf("mutator preset %q: %v", name, err)
+		}
+		if findMutatorPreset(name) != -1 || name == customPreset {
+			delete(c.MutatorPresets, name)
+			return fmt.Errorf("mutator preset %q is built in", name)
+		}
+	}
+	return nil
+}
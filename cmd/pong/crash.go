@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.2/glfw"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// crashDir is where the crash bundles are written, one directory per crash
+const crashDir = "crashes"
+
+// crashLogLines is how many of the last log lines are kept for the crash bundle
+const crashLogLines = 200
+
+// logRing keeps the last lines written to the log
+type logRing struct {
+	mutex sync.Mutex
+	lines []string
+	next  int
+}
+
+func (r *logRing) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if len(r.lines) < crashLogLines {
+			r.lines = append(r.lines, line)
+			continue
+		}
+		r.lines[r.next] = line
+		r.next = (r.next + 1) % crashLogLines
+	}
+	return len(p), nil
+}
+
+// Lines returns the kept lines, oldest first
+func (r *logRing) Lines() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append(append([]string{}, r.lines[r.next:]...), r.lines[:r.next]...)
+}
+
+// crashReporter writes a crash bundle and tells the user when the game panics
+type crashReporter struct {
+	log        *logRing
+	config     *Config
+	glVersion  string
+	glRenderer string
+	window     *glfw.Window // Set once the window is created, to save its last frame
+	dir        string       // Bundle directory, created by the first capture
+	stack      []byte       // Stack of the panic, taken where it was first caught
+}
+
+// newCrashReporter starts keeping the log lines
+func newCrashReporter() *crashReporter {
+	c := &crashReporter{log: &logRing{}}
+	log.SetOutput(io.MultiWriter(os.Stderr, c.log))
+	return c
+}
+
+// capture saves the stack and the last displayed frame of a panic, then lets it go on:
+// defer it where the window still exists
+func (c *crashReporter) capture() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	c.stack = debug.Stack()
+	if c.window != nil && c.createDir() == nil {
+		width, height := c.window.GetFramebufferSize()
+		// The back buffer holds a half drawn frame, save the one on screen
+		gl.ReadBuffer(gl.FRONT)
+		if err := render.SaveScreenshot(filepath.Join(c.dir, "screenshot.png"), width, height); err != nil {
+			log.Println("ERROR::CRASH: failed to save the screenshot:", err)
+		}
+		gl.ReadBuffer(gl.BACK)
+	}
+	panic(r)
+}
+
+// report writes the crash bundle of a panic, shows it to the user and exits: defer it first in main
+func (c *crashReporter) report() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if c.stack == nil {
+		c.stack = debug.Stack()
+	}
+	fmt.Fprintf(os.Stderr, "panic: %v\n\n%s\n", r, c.stack)
+	message := fmt.Sprintf("Pong crashed: %v", r)
+	if err := c.writeBundle(r); err != nil {
+		fmt.Println("ERROR::CRASH: failed to write the crash bundle:", err)
+	} else {
+		message += fmt.Sprintf("\n\nA crash report was saved in %v, please attach it when reporting the problem.", c.dir)
+	}
+	showErrorDialog("Pong crashed", message)
+	os.Exit(2)
+}
+
+func (c *crashReporter) createDir() error {
+	if c.dir != "" {
+		return nil
+	}
+	dir := filepath.Join(crashDir, time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	c.dir = dir
+	return nil
+}
+
+func (c *crashReporter) writeBundle(r interface{}) error {
+	if err := c.createDir(); err != nil {
+		return err
+	}
+	stack := fmt.Sprintf("panic: %v\n\n%s", r, c.stack)
+	system := fmt.Sprintf("os: %v/%v\ngo: %v\nopengl version: %v\nopengl renderer: %v\nargs: %q\n",
+		runtime.GOOS, runtime.GOARCH, runtime.Version(), c.glVersion, c.glRenderer, os.Args)
+	config, _ := json.MarshalIndent(c.config, "", "  ")
+	files := map[string][]byte{
+		"stack.txt":   []byte(stack),
+		"system.txt":  []byte(system),
+		"config.json": config,
+		"log.txt":     []byte(strings.Join(c.log.Lines(), "\n") + "\n"),
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(c.dir, name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// showErrorDialog shows the message in a dialog with the tools of the desktop, if any
+func showErrorDialog(title, message string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display dialog %q with title %q buttons {\"OK\"} with icon stop", message, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("msg", "*", message)
+	default:
+		if path, err := exec.LookPath("zenity"); err == nil {
+			cmd = exec.Command(path, "--error", "--title", title, "--text", message)
+		} else if path, err := exec.LookPath("kdialog"); err == nil {
+			cmd = exec.Command(path, "--title", title, "--error", message)
+		}
+	}
+	if cmd == nil || cmd.Run() != nil {
+		fmt.Fprintln(os.Stderr, message)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"image/png"
+	"log"
+	"os"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+)
+
+// cursorFile is the sprite of the mouse cursor, its hot spot is the top left corner
+const cursorFile = "./assets/cursor.png"
+
+// setCursor replaces the system cursor with the sprite of the game, keeping the system one when it can't be loaded
+func setCursor(window *glfw.Window) *glfw.Cursor {
+	file, err := os.Open(cursorFile)
+	if err != nil {
+		log.Println("ERROR::CURSOR:", err)
+		return nil
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		log.Println("ERROR::CURSOR: failed to decode", cursorFile, err)
+		return nil
+	}
+	cursor := glfw.CreateCursor(img, 0, 0)
+	window.SetCursor(cursor)
+	return cursor
+}
+
+// readPointer returns the mouse state over the game, in virtual resolution coordinates
+func readPointer(window *glfw.Window) pong.Pointer {
+	x, y := mouse.Position()
+	// The cursor is in window coordinates, the framebuffer is bigger on high DPI screens
+	windowWidth, windowHeight := window.GetSize()
+	framebufferWidth, framebufferHeight := window.GetFramebufferSize()
+	if windowWidth > 0 && windowHeight > 0 {
+		x *= float64(framebufferWidth) / float64(windowWidth)
+		y *= float64(framebufferHeight) / float64(windowHeight)
+	}
+	return pong.Pointer{
+		Position: game.ToVirtual(x, y),
+		Moved:    mouse.Moved(),
+		Click:    mouse.Pressed(glfw.MouseButtonLeft),
+		Down:     mouse.Down(glfw.MouseButtonLeft),
+		Erase:    mouse.Pressed(glfw.MouseButtonRight),
+	}
+}
+
+// CursorPosCallback defines the callback to handle the mouse moving
+func CursorPosCallback(window *glfw.Window, x, y float64) {
+	mouse.HandleCursor(x, y)
+}
+
+// MouseButtonCallback defines the callback to handle the mouse buttons
+func MouseButtonCallback(window *glfw.Window, button glfw.MouseButton, action glfw.Action, modifierKey glfw.ModifierKey) {
+	mouse.HandleButton(button, action)
+	if action == glfw.Press {
+		game.Wake()
+	}
+}
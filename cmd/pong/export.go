@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// Size and frame rate of the exported videos
+var (
+	exportWidth, exportHeight = 1280, 720
+	exportFrameRate           = 60
+)
+
+// exportReplay plays the replay back headless, rendering its frames offscreen at the export frame
+// rate and piping them to ffmpeg to encode the video file, the format follows its extension
+func exportReplay(replay *pong.Replay, out string) error {
+	ffmpeg, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return fmt.Errorf("exporting needs ffmpeg in the PATH: %v", err)
+	}
+	*hidden = true
+	window := initGlfw(defaultConfig())
+	defer glfw.Terminate()
+	defer window.Destroy()
+	initOpenGL()
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+
+	options := replay.Header.Options()
+	options.Quality = pong.QualityHigh.Settings()
+	// The callbacks of the window expect the global game
+	game = pong.New(options)
+	game.Init()
+	defer game.Close()
+	target := render.NewRenderTarget(int32(exportWidth), int32(exportHeight), 0)
+	defer target.Delete()
+
+	// The frames are read bottom up, ffmpeg flips them back
+	encoder := exec.Command(ffmpeg, "-loglevel", "error", "-y",
+		"-f", "rawvideo", "-pixel_format", "rgba", "-video_size", fmt.Sprintf("%vx%v", exportWidth, exportHeight),
+		"-framerate", fmt.Sprint(exportFrameRate), "-i", "-",
+		"-vf", "vflip", "-pix_fmt", "yuv420p", out)
+	encoder.Stdout, encoder.Stderr = os.Stdout, os.Stderr
+	frames, err := encoder.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := encoder.Start(); err != nil {
+		return err
+	}
+
+	step := 1.0 / replay.Header.TickRate
+	frameTime := 1.0 / float64(exportFrameRate)
+	pixels := make([]byte, exportWidth*exportHeight*4)
+	var tick uint64
+	var simulated float64
+	for frame := 0; tick < replay.Ticks; frame++ {
+		for ; tick < replay.Ticks && simulated < float64(frame)*frameTime; tick++ {
+			game.Step(replay.Input(tick), step)
+			simulated += step
+		}
+		game.Render(target)
+		target.ReadPixels(pixels)
+		if _, err = frames.Write(pixels); err != nil {
+			break
+		}
+	}
+	frames.Close()
+	if waitErr := encoder.Wait(); err == nil {
+		err = waitErr
+	}
+	return err
+}
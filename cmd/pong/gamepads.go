@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+)
+
+// gamepadDeadZone is how far the stick has to be pushed to move the paddle
+const gamepadDeadZone = 0.5
+
+// gamepadPlayers is how many controllers are read, the first one plays the left paddle, the second
+// the right one and the others the front paddles of doubles
+const gamepadPlayers = 4
+
+// readGamepads moves the paddles with the vertical axis of the left stick of the controllers
+// connected, on top of the keys
+func readGamepads(input *pong.Input) {
+	paddles := [gamepadPlayers][2]*bool{
+		{&input.Paddle1Up, &input.Paddle1Down},
+		{&input.Paddle2Up, &input.Paddle2Down},
+		{&input.Paddle3Up, &input.Paddle3Down},
+		{&input.Paddle4Up, &input.Paddle4Down},
+	}
+	for i, paddle := range paddles {
+		joystick := glfw.Joystick1 + glfw.Joystick(i)
+		if !glfw.JoystickPresent(joystick) {
+			continue
+		}
+		axes := glfw.GetJoystickAxes(joystick)
+		if len(axes) < 2 {
+			continue
+		}
+		// The vertical axis points down
+		*paddle[0] = *paddle[0] || axes[1] < -gamepadDeadZone
+		*paddle[1] = *paddle[1] || axes[1] > gamepadDeadZone
+	}
+}
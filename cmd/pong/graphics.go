@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+)
+
+// displayModes are the supported ways to show the window: a window, the screen switched to the
+// resolution, a window covering the screen at its current resolution, or a small window in a corner
+// of the screen with a simplified HUD
+var displayModes = []string{"windowed", "fullscreen", "borderless", "mini"}
+
+// Size of the mini window, whatever the resolution, and its distance from the corner of the screen
+var (
+	miniWidth, miniHeight = 480, 270
+	miniMargin            = 40
+)
+
+// resolutions are offered by the graphics settings, any other can be set in the config file
+var resolutions = []string{"800x600", "1280x720", "1600x900", "1920x1080", "2560x1440"}
+
+// msaaSamples are offered by the graphics settings, -1 follows the quality preset
+var msaaSamples = []int{-1, 0, 2, 4, 8}
+
+const maxMSAA = 16
+
+func validDisplayMode(mode string) bool {
+	for _, displayMode := range displayModes {
+		if mode == displayMode {
+			return true
+		}
+	}
+	return false
+}
+
+// parseResolution reads a WIDTHxHEIGHT resolution
+func parseResolution(resolution string) (int, int, error) {
+	var width, height int
+	if _, err := fmt.Sscanf(resolution, "%dx%d", &width, &height); err != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid resolution %q, expected WIDTHxHEIGHT", resolution)
+	}
+	return width, height, nil
+}
+
+// applyDisplay resizes the window and switches it between windowed and fullscreen as configured
+func applyDisplay(window *glfw.Window, config Config) {
+	width, height, _ := parseResolution(config.Resolution)
+	monitor := glfw.GetPrimaryMonitor()
+	if monitor == nil {
+		window.SetSize(width, height)
+		return
+	}
+	mode := monitor.GetVideoMode()
+	switch config.DisplayMode {
+	case "fullscreen":
+		window.SetMonitor(monitor, 0, 0, width, height, glfw.DontCare)
+	case "borderless":
+		window.SetMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+	case "mini":
+		// Top right corner, out of the way
+		window.SetMonitor(nil, mode.Width-miniWidth-miniMargin, miniMargin, miniWidth, miniHeight, 0)
+	default:
+		// Center the window on the monitor
+		window.SetMonitor(nil, (mode.Width-width)/2, (mode.Height-height)/2, width, height, 0)
+	}
+}
+
+// qualitySettings returns the effects settings of the quality preset with the MSAA override
+func qualitySettings(config Config) pong.QualitySettings {
+	preset, _ := pong.ParseQuality(config.Quality)
+	settings := preset.Settings()
+	if config.MSAA >= 0 {
+		settings = settings.WithSamples(int32(config.MSAA))
+	}
+	return settings
+}
+
+// graphicsSettings returns the settings of the graphics screen with the values of the config selected
+func graphicsSettings(config Config) []*pong.Setting {
+	var msaa []string
+	for _, samples := range msaaSamples {
+		msaa = append(msaa, msaaName(samples))
+	}
+	var qualities []string
+	for quality := pong.QualityLow; quality <= pong.QualityUltra; quality++ {
+		qualities = append(qualities, quality.String())
+	}
+	return []*pong.Setting{
+		newSetting("Resolution", resolutions, config.Resolution),
+		newSetting("Display mode", displayModes, config.DisplayMode),
+		newSetting("VSync", vsyncModes, config.VSync),
+		newSetting("MSAA", msaa, msaaName(config.MSAA)),
+		newSetting("Effects quality", qualities, config.Quality),
+		newSetting("Theme", modContent.ThemeNames(), config.Theme),
+		newSetting("Ball trail", modContent.TrailNames(), config.Trail),
+		newSetting("Player trails", []string{"off", "on"}, onOffName(config.PlayerTrails)),
+		newSetting("Impact marks", []string{"off", "on"}, onOffName(config.Decals)),
+		newSetting("Visual sound cues", []string{"off", "on"}, onOffName(config.CueIndicators)),
+	}
+}
+
+// newSetting returns a setting with the value selected, added to the values when missing
+func newSetting(name string, values []string, value string) *pong.Setting {
+	setting := &pong.Setting{Name: name, Values: values}
+	for i, v := range values {
+		if v == value {
+			setting.Current = i
+			return setting
+		}
+	}
+	setting.Values = append(append([]string{}, values...), value)
+	setting.Current = len(values)
+	return setting
+}
+
+// onOffName returns the value of a setting turning something on or off
+func onOffName(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+func msaaName(samples int) string {
+	switch samples {
+	case -1:
+		return "quality"
+	case 0:
+		return "off"
+	}
+	return strconv.Itoa(samples) + "x"
+}
+
+// msaaValue is the inverse of msaaName
+func msaaValue(name string) int {
+	switch name {
+	case "quality":
+		return -1
+	case "off":
+		return 0
+	}
+	samples, _ := strconv.Atoi(strings.TrimSuffix(name, "x"))
+	return samples
+}
+
+// setGraphicsSetting stores the value of a setting of the graphics screen in the config
+func setGraphicsSetting(config *Config, setting *pong.Setting) {
+	switch setting.Name {
+	case "Resolution":
+		config.Resolution = setting.Value()
+	case "Display mode":
+		config.DisplayMode = setting.Value()
+	case "VSync":
+		config.VSync = setting.Value()
+	case "MSAA":
+		config.MSAA = msaaValue(setting.Value())
+	case "Effects quality":
+		config.Quality = setting.Value()
+	case "Theme":
+		config.Theme = setting.Value()
+	case "Ball trail":
+		config.Trail = setting.Value()
+	case "Player trails":
+		config.PlayerTrails = setting.Value() == "on"
+	case "Impact marks":
+		config.Decals = setting.Value() == "on"
+	case "Visual sound cues":
+		config.CueIndicators = setting.Value() == "on"
+	}
+}
+
+// applyGraphicsSetting applies a changed setting of the graphics screen live and returns the rate
+// to cap the frames to
+func applyGraphicsSetting(window *glfw.Window, config Config, setting *pong.Setting, frameRate float64) float64 {
+	switch setting.Name {
+	case "Resolution", "Display mode":
+		applyDisplay(window, config)
+		game.SetCompact(config.DisplayMode == "mini")
+	case "VSync":
+		return applyVSync(config.VSync, config.RenderRate)
+	case "MSAA", "Effects quality":
+		game.SetQuality(qualitySettings(config))
+	case "Theme":
+		theme, _ := modContent.ParseTheme(config.Theme)
+		game.SetTheme(theme)
+	case "Ball trail":
+		game.SetTrail(config.Trail)
+	case "Player trails":
+		game.SetPlayerTrails(config.PlayerTrails)
+	case "Impact marks":
+		game.SetDecals(config.Decals)
+	case "Visual sound cues":
+		game.SetCueIndicators(config.CueIndicators)
+	}
+	return frameRate
+}
+
+// readSettingsControls maps the keyboard and mouse state to the settings screen commands
+func readSettingsControls(window *glfw.Window) pong.SettingsControls {
+	return pong.SettingsControls{
+		Navigation: readNavigation(),
+		Left:       keyboard.Pressed(glfw.KeyLeft),
+		Right:      keyboard.Pressed(glfw.KeyRight),
+		Close:      keyboard.Pressed(glfw.KeyEscape) || keyboard.Pressed(glfw.KeyO),
+		Pointer:    readPointer(window),
+	}
+}
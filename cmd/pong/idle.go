@@ -0,0 +1,60 @@
+package main
+
+import pong "github.com/lucatironi/go-pong"
+
+// autopilotPersonality plays the paddles taken over when no personality was loaded
+var autopilotPersonality = pong.Personality{Name: "autopilot", AimError: 20, Reaction: 0.1, Speed: 1, Recenter: true}
+
+// idleTakeover hands the paddle of a player who stopped playing mid-match to the computer, until
+// they move it again
+type idleTakeover struct {
+	after       float64 // Seconds without input before the computer takes over, zero never does
+	personality pong.Personality
+	idle        [2]float64        // Seconds since the players last moved their paddle
+	opponents   [2]*pong.Opponent // Playing the paddles taken over
+}
+
+// newIdleTakeover returns a takeover after the seconds with the first of the personalities
+func newIdleTakeover(after float64, personalities []pong.Personality) *idleTakeover {
+	t := &idleTakeover{after: after, personality: autopilotPersonality}
+	if len(personalities) > 0 {
+		t.personality = personalities[0]
+	}
+	return t
+}
+
+// control plays the paddles of the players idle for too long, the humans telling which players
+// are at the keyboard rather than played by the computer or a bot
+func (t *idleTakeover) control(state pong.State, input *pong.Input, deltaTime float64, humans [2]bool) {
+	if t.after <= 0 {
+		return
+	}
+	moves := [2]bool{input.Paddle1Up || input.Paddle1Down, input.Paddle2Up || input.Paddle2Down}
+	for i := range t.opponents {
+		player := i + 1
+		switch {
+		case state.Phase == pong.GamePaused || state.Phase == pong.GameSetBreak:
+			continue
+		case state.Phase != pong.GameActive || moves[i] || !humans[i]:
+			t.idle[i] = 0
+			t.release(player)
+			continue
+		}
+		t.idle[i] += deltaTime
+		if t.opponents[i] == nil && t.idle[i] >= t.after {
+			t.opponents[i] = pong.NewOpponent(t.personality, player, game.Random())
+			game.SetAutopilot(player, true)
+		}
+		if t.opponents[i] != nil {
+			t.opponents[i].Control(state, input, deltaTime)
+		}
+	}
+}
+
+// release gives the paddle back to the player
+func (t *idleTakeover) release(player int) {
+	if t.opponents[player-1] != nil {
+		t.opponents[player-1] = nil
+		game.SetAutopilot(player, false)
+	}
+}
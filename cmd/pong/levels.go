@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	pong "github.com/lucatironi/go-pong"
+	"github.com/lucatironi/go-pong/pkg/level"
+)
+
+// levelsScreen is the settings screen picking the level of the matches, the menu behind shows it
+type levelsScreen struct {
+	*pong.SettingsScreen
+	levels  []*level.Level
+	setting *pong.Setting
+}
+
+// newLevelsScreen returns the screen listing the classic court and the levels, with the named one selected
+func newLevelsScreen(levels []*level.Level, name string) (*levelsScreen, error) {
+	s := &levelsScreen{levels: levels, setting: &pong.Setting{Name: "Level", Values: []string{pong.ClassicLevel}}}
+	for _, l := range levels {
+		s.setting.Values = append(s.setting.Values, l.Name)
+	}
+	s.SettingsScreen = pong.NewSettingsScreen(game, "LEVELS", []*pong.Setting{s.setting})
+	s.SettingsScreen.SetHint("LEFT/RIGHT change - E edit - ESC back")
+	for i, value := range s.setting.Values {
+		if value == name {
+			s.setting.Current = i
+			return s, nil
+		}
+	}
+	return s, fmt.Errorf("unknown level %q, playing on the classic court", name)
+}
+
+// level returns the level selected, nil for the classic court
+func (s *levelsScreen) level() *level.Level {
+	if s.setting.Current == 0 {
+		return nil
+	}
+	return s.levels[s.setting.Current-1]
+}
+
+// set adds the level to the list, or replaces the one with its name, keeping the selection
+func (s *levelsScreen) set(l *level.Level) {
+	for i, old := range s.levels {
+		if old.Name == l.Name {
+			s.levels[i] = l
+			return
+		}
+	}
+	s.levels = append(s.levels, l)
+	s.setting.Values = append(s.setting.Values, l.Name)
+}
+
+// newName returns a name for a new level no other level has
+func (s *levelsScreen) newName() string {
+	for n := 1; ; n++ {
+		name := fmt.Sprintf("level %v", n)
+		taken := false
+		for _, value := range s.setting.Values {
+			taken = taken || value == name
+		}
+		if !taken {
+			return name
+		}
+	}
+}
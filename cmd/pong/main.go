@@ -0,0 +1,873 @@
+// Command pong opens a window and runs the pong game in it
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+	"github.com/lucatironi/go-pong/pkg/chat"
+	"github.com/lucatironi/go-pong/pkg/input"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+const (
+	// Most fixed updates run before rendering a frame, when the simulation is further behind
+	// the render is skipped to catch up, fixed updates are never skipped
+	maxUpdatesPerFrame = 8
+	// Seconds between the checks for events while the window is minimized
+	minimizedPollInterval = 0.1
+	// Frames rendered per second out of a match, in the menus, pause and win screens
+	idleFrameRate = 30
+)
+
+var (
+	game       *pong.Game
+	keyboard   = input.NewKeyboard()
+	mouse      = input.NewMouse()
+	focusLost  bool // The window lost the focus, pause the match on the next update
+	minimized  bool // The window can't be seen, don't simulate nor render
+	graphics   *pong.SettingsScreen
+	mutators   *mutatorsScreen
+	levels     *levelsScreen
+	editor     *pong.LevelEditor
+	testPlay   bool // A level is test-played from the editor, which comes back after the match
+	controls   *pong.BindingsScreen
+	profiles   *pong.ProfileScreen
+	inspector  *pong.Inspector
+	dialog     *pong.Dialog
+	requested  pong.Input            // Input asked by the dialogs for the next update, on top of the keys
+	opponent   pong.PaddleController // Computer playing the right paddle, nil when it's a player
+	bindings   keyBindings
+	devMode    = flag.Bool("dev", false, "enable development mode (hot reload of textures, resource leak checks)")
+	glDebug    = flag.Bool("gl-debug", false, "create a debug OpenGL context and log its debug messages")
+	configFile = flag.String("config", "config.json", "path of the config file")
+	updateRate = flag.Float64("update-rate", 120, "fixed simulation updates per second: 60, 120 or 240")
+	renderRate = flag.Float64("render-rate", 0, "frames rendered per second, 0 follows the monitor refresh")
+	quality    = flag.String("quality", "high", "graphics quality preset: low, medium, high or ultra")
+	vsync      = flag.String("vsync", "on", "sync the frames with the monitor refresh: on, off or adaptive")
+	bench      = flag.Float64("bench", 0, "run the given seconds of AI vs AI simulation headless and report its performance")
+	selfPlay   = flag.Int("self-play", 0, "play the given matches per pairing of the AI personalities and their mutations headless, then report their win rates")
+	mutations  = flag.Int("mutations", 8, "mutations of each AI personality played by -self-play")
+	adaptiveAI = flag.Bool("adaptive-ai", false, "let the computer opponent adjust its skill to keep the matches close")
+	softwareGL = flag.Bool("software-gl", false, "render with the Mesa software implementation (llvmpipe), for machines without a GPU")
+	hidden     = flag.Bool("hidden", false, "don't show the window, for rendering without a display server")
+	screenshot = flag.String("screenshot", "", "save the first rendered frame to the given PNG file and quit")
+	record     = flag.String("record", "", "record the inputs of the session to the given replay file")
+	playback   = flag.String("replay", "", "play back the given replay file")
+	twitch     = flag.String("twitch", "", "let the chat of the given Twitch channel move the right paddle voting up or down")
+	twitchNick = flag.String("twitch-nick", chat.AnonymousNick, "nick to join the Twitch chat with")
+	twitchAuth = flag.String("twitch-token", "", "OAuth token (oauth:...) of the Twitch nick, not needed when anonymous")
+	tutorial   = flag.Bool("tutorial", false, "start with the tutorial, it's shown anyway the first time the game runs")
+	sets       = flag.Int("sets", 1, "play the matches as best of the given sets")
+	botPort    = flag.Int("bot-port", 0, "let an external program play a paddle over a TCP socket on the given local port")
+	botSocket  = flag.String("bot-socket", "", "let an external program play a paddle over the given unix socket")
+	botPlayer  = flag.Int("bot-player", 2, "paddle played by the external program: 1 left, 2 right")
+	aiReaction = flag.Float64("ai-reaction", 0.15, "seconds the computer opponent of the single player mode sees the ball late by")
+	idleAfter  = flag.Float64("idle-takeover", 0, "let the computer play the paddle of a player idle for the given seconds mid-match, until they move again")
+	doubles    = flag.Bool("doubles", false, "play two against two, the players 3 and 4 at the front: they can play with the keys or the controllers 3 and 4")
+	flip       = flag.Bool("flip", false, "chaos modifier: mirror the court now and then, horizontally or vertically, the controls stay the same")
+	preset     = flag.String("controls", "standard", "sides of the players: standard, left-handed (keys swapped) or swapped-sides (keys swapped and court mirrored)")
+	seed       = flag.Int64("seed", 0, "seed of the random numbers, to reproduce a run; zero picks a new one")
+)
+
+func init() {
+	// This is needed to arrange that main() runs on main thread.
+	// See documentation for functions that are only allowed to be called from the main thread.
+	runtime.LockOSThread()
+}
+
+func main() {
+	crash := newCrashReporter()
+	defer crash.report()
+	flag.Parse()
+	if flag.Arg(0) == "replay" {
+		os.Exit(runReplayCommand(flag.Args()[1:]))
+	}
+	// The themes of the mods are validated along with the config
+	mods, modsFailed := loadMods()
+	config, err := loadConfig(*configFile)
+	if err != nil {
+		fmt.Println("ERROR::CONFIG:", err)
+	}
+	// The settings changed in game are saved without the flags overrides
+	fileConfig := config
+	if err := config.applyFlags(); err != nil {
+		fmt.Println("ERROR::CONFIG:", err)
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	crash.config = &config
+	if bindings, err = newKeyBindings(config); err != nil {
+		fmt.Println("ERROR::CONFIG:", err)
+	}
+	if *bench > 0 {
+		pong.RunBench(*bench, config.UpdateRate)
+		return
+	}
+	personalities, err := pong.LoadPersonalities(pong.PersonalitiesDir)
+	if err != nil {
+		fmt.Println("ERROR::PERSONALITIES:", err)
+	}
+	if *selfPlay > 0 {
+		pong.RunSelfPlay(personalities, *mutations, *selfPlay, config.UpdateRate, config.Seed)
+		return
+	}
+	players, err := loadProfiles(config.Players)
+	if err != nil {
+		fmt.Println("ERROR::PROFILES:", err)
+	}
+	// The keys of the players in the config file move to the profiles created
+	if err := players.saveCreated(bindings); err != nil {
+		fmt.Println("ERROR::PROFILES:", err)
+	}
+
+	window := initGlfw(config)
+	defer glfw.Terminate()
+	if cursor := setCursor(window); cursor != nil {
+		defer cursor.Destroy()
+	}
+	if config.PauseOnFocusLoss {
+		window.SetFocusCallback(FocusCallback)
+	}
+
+	initOpenGL()
+	crash.window = window
+	crash.glVersion = gl.GoStr(gl.GetString(gl.VERSION))
+	crash.glRenderer = gl.GoStr(gl.GetString(gl.RENDERER))
+
+	frameRate := applyVSync(config.VSync, config.RenderRate)
+
+	// OpenGL configuration
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+
+	theme, _ := modContent.ParseTheme(config.Theme)
+	// Replays and screenshots start from the menu like any other session
+	tutorialPending := *playback == "" && *screenshot == "" && (*tutorial || firstRun())
+	options := pong.Options{
+		Quality:   qualitySettings(config),
+		Theme:     &theme,
+		DevMode:   *devMode,
+		Tutorial:  tutorialPending,
+		Sets:      *sets,
+		Handicaps: [2]int{players.picked(1).Handicap, players.picked(2).Handicap},
+		Mutators:  configMutators(config),
+		Doubles:   *doubles,
+		Mods:      mods,
+		Content:   modContent,
+		Seed:      config.Seed,
+	}
+	game = pong.New(options)
+	game.SetCompact(config.DisplayMode == "mini")
+	game.Init()
+	applyControlPreset(config.ControlPreset)
+	game.SetCourtFlip(*flip)
+	game.SetTrail(config.Trail)
+	game.SetCueIndicators(config.CueIndicators)
+	game.SetPlayerTrails(config.PlayerTrails)
+	game.SetDecals(config.Decals)
+	defer game.Close()
+	game.Resize(window.GetFramebufferSize())
+	graphics = pong.NewSettingsScreen(game, "GRAPHICS", graphicsSettings(config))
+	mutators = newMutatorsScreen(config)
+	courtLevels, err := game.LoadLevels()
+	if err != nil {
+		fmt.Println("ERROR::LEVELS:", err)
+		modsFailed = true
+	}
+	if modsFailed {
+		game.Notify("Some mods failed to load, see the log")
+	}
+	if config.Level == "" {
+		config.Level = pong.ClassicLevel
+	}
+	if levels, err = newLevelsScreen(courtLevels, config.Level); err != nil {
+		fmt.Println("ERROR::LEVELS:", err)
+	}
+	game.SetLevel(levels.level())
+	options.Level = levels.level()
+	editor = pong.NewLevelEditor(game)
+	controls = pong.NewBindingsScreen(game, bindings.list(), defaultBindings)
+	profiles = pong.NewProfileScreen(game)
+	inspector = pong.NewInspector(game)
+	dialog = pong.NewDialog(game)
+	updateControls := func(c pong.BindingsControls) {
+		if controls.Update(c) {
+			bindings.set(controls.Bindings())
+			config.Bindings = bindings.config()
+			fileConfig.Bindings = config.Bindings
+			for player := 1; player <= 2; player++ {
+				players.picked(player).Bindings = bindings.profile(player)
+			}
+		}
+	}
+
+	var recorder *replayRecorder
+	if *record != "" {
+		if recorder, err = createReplay(*record, config.UpdateRate, options); err != nil {
+			fmt.Println("ERROR::REPLAY:", err)
+		} else {
+			defer recorder.Close()
+			game.Notify("Recording to " + *record)
+		}
+	}
+	applyProfiles(players, recorder != nil)
+	// The keys of the players are kept in the profiles from now on
+	config.Bindings = bindings.config()
+	fileConfig.Bindings = config.Bindings
+	updateProfiles := func(c pong.ProfileControls) {
+		if profiles.Update(c) {
+			if err := players.update(profiles.Profiles(), profiles.Players()); err != nil {
+				fmt.Println("ERROR::PROFILES:", err)
+				game.Notify("Failed to save the profiles")
+			}
+			applyProfiles(players, recorder != nil)
+			config.Players = players.names()
+			fileConfig.Players = config.Players
+		}
+	}
+
+	var viewer *pong.ReplayViewer
+	if *playback != "" {
+		replay, err := readReplayFile(*playback)
+		if err == nil {
+			err = replay.Validate()
+		}
+		if err != nil {
+			fmt.Println("ERROR::REPLAY:", err)
+			return
+		}
+		viewer = pong.NewReplayViewer(game, replay)
+	}
+	// The demo would play differently from the replay of the session
+	game.SetAttract(recorder == nil && viewer == nil)
+
+	var chatClient *chat.Client
+	var votes *pong.ChatVotes
+	if *twitch != "" {
+		if chatClient, err = chat.Dial(chat.TwitchAddress, *twitchNick, *twitchAuth, *twitch); err != nil {
+			fmt.Println("ERROR::CHAT:", err)
+		} else {
+			defer chatClient.Close()
+			votes = pong.NewChatVotes()
+			game.ShowChatVotes(votes, 2)
+			game.Notify("Joined the chat of " + *twitch)
+		}
+	}
+
+	if *botPlayer != 1 && *botPlayer != 2 {
+		fmt.Println("ERROR::BOT: the bot plays paddle 1 or 2, not", *botPlayer)
+		*botPlayer = 2
+	}
+	bots, err := listenBots(*botPort, *botSocket)
+	if err != nil {
+		fmt.Println("ERROR::BOT:", err)
+	} else if bots != nil {
+		defer bots.Close()
+		game.Notify(fmt.Sprintf("Waiting for a bot on %v", bots.Addr()))
+	}
+	idle := newIdleTakeover(*idleAfter, personalities)
+	var tick uint64
+
+	// Save the last frame of a crash while the window is still there
+	defer crash.capture()
+
+	fixedTimeStep := 1.0 / config.UpdateRate
+	var accumulator, lastRender float64
+	resultRecorded := false // The result of the match on the win screen is in the statistics
+	lastFrame := glfw.GetTime()
+
+	for !window.ShouldClose() {
+		if minimized {
+			glfw.WaitEventsTimeout(minimizedPollInterval)
+			// Resume from where it was left, without catching up the time spent minimized
+			lastFrame = glfw.GetTime()
+			continue
+		}
+		currentFrame := glfw.GetTime()
+		glfw.PollEvents()
+		if viewer != nil {
+			// The replay viewer runs the simulation at the replay tick rate and speed
+			viewer.Update(readReplayControls(), currentFrame-lastFrame)
+		} else {
+			accumulator += currentFrame - lastFrame
+		}
+		lastFrame = currentFrame
+		if chatClient != nil && !readVotes(chatClient, votes) {
+			// Give the paddle back to the keyboard
+			game.Notify("Chat disconnected")
+			chatClient, votes = nil, nil
+		}
+		phase := game.State().Phase
+		inMenu := viewer == nil && (phase == pong.GameMenu || phase == pong.GamePaused)
+		inMatch := viewer == nil && (phase == pong.GameActive || phase == pong.GamePaused || phase == pong.GameTutorial || phase == pong.GameSetBreak || phase == pong.GameWarmUp || phase == pong.GameIntro)
+		// A screen over the game keeps the demo away
+		if overlayOpen() {
+			game.Wake()
+		}
+		if dialog.IsOpen() {
+			dialog.Update(readDialogControls(window))
+		} else if graphics.IsOpen() {
+			if setting := graphics.Update(readSettingsControls(window)); setting != nil {
+				setGraphicsSetting(&config, setting)
+				setGraphicsSetting(&fileConfig, setting)
+				frameRate = applyGraphicsSetting(window, config, setting, frameRate)
+			}
+			if !graphics.IsOpen() {
+				saveSettings(*configFile, fileConfig)
+			}
+		} else if mutators.IsOpen() {
+			if keyboard.Pressed(glfw.KeyS) {
+				if name, err := mutators.save(&config); err != nil {
+					game.Notify("Mutators " + err.Error())
+				} else {
+					fileConfig.MutatorPresets = config.MutatorPresets
+					game.Notify("Saved the preset " + name)
+				}
+			}
+			if setting := mutators.Update(readSettingsControls(window)); setting != nil {
+				mutators.changed(setting)
+				game.SetMutators(mutators.mutators())
+				config.Mutators = mutators.mutators().Names()
+				fileConfig.Mutators = config.Mutators
+			}
+			if !mutators.IsOpen() {
+				saveSettings(*configFile, fileConfig)
+			}
+		} else if editor.IsOpen() {
+			control := keyboard.Down(glfw.KeyLeftControl) || keyboard.Down(glfw.KeyRightControl)
+			if control && keyboard.Pressed(glfw.KeyS) {
+				if saved, err := game.SaveLevel(pong.LevelsDir, editor.Level()); err != nil {
+					fmt.Println("ERROR::LEVELS:", err)
+					game.Notify("Failed to save the level")
+				} else {
+					editor.Saved()
+					levels.set(saved)
+					game.Notify("Saved the level " + saved.Name)
+				}
+			} else if keyboard.Pressed(glfw.KeyP) {
+				testPlay = true
+				editor.Close()
+				requested.Start = true
+			} else if keyboard.Pressed(glfw.KeyEscape) {
+				closeEditor := func() {
+					editor.Close()
+					game.SetLevel(levels.level())
+				}
+				if editor.Changed() {
+					dialog.Ask("Discard the changes to the level?", closeEditor, nil)
+				} else {
+					closeEditor()
+				}
+			} else {
+				editor.Update(readLevelEditorControls(window, control))
+			}
+		} else if testPlay && phase == pong.GameMenu && !requested.Start {
+			testPlay = false
+			editor.Resume()
+		} else if levels.IsOpen() {
+			if keyboard.Pressed(glfw.KeyE) {
+				levels.Update(pong.SettingsControls{Close: true})
+				editor.Open(levels.level(), levels.newName())
+			} else if setting := levels.Update(readSettingsControls(window)); setting != nil {
+				game.SetLevel(levels.level())
+				config.Level = setting.Value()
+				fileConfig.Level = config.Level
+			}
+			if !levels.IsOpen() {
+				saveSettings(*configFile, fileConfig)
+			}
+		} else if controls.IsOpen() {
+			c := readBindingsControls(window)
+			if c.Reset {
+				c.Reset = false
+				dialog.Ask("Reset all the controls?", func() { updateControls(pong.BindingsControls{Reset: true}) }, nil)
+			}
+			updateControls(c)
+			if !controls.IsOpen() {
+				saveSettings(*configFile, fileConfig)
+				if err := players.savePicked(); err != nil {
+					fmt.Println("ERROR::PROFILES:", err)
+				}
+			}
+		} else if profiles.IsOpen() {
+			c := readProfileControls(window)
+			if c.Delete && profiles.CanDelete() {
+				c.Delete = false
+				dialog.Ask("Delete the profile "+profiles.Focused().Name+"?", func() { updateProfiles(pong.ProfileControls{Delete: true}) }, nil)
+			}
+			updateProfiles(c)
+			if !profiles.IsOpen() {
+				saveSettings(*configFile, fileConfig)
+			}
+		} else if inspector.IsOpen() {
+			inspector.Update(readInspectorControls(window))
+		} else if *devMode && recorder == nil && viewer == nil && keyboard.Pressed(glfw.KeyF5) {
+			// A replay wouldn't follow the entities changed
+			inspector.Open()
+		} else if inMenu && keyboard.Pressed(glfw.KeyC) {
+			keyboard.Typed() // Drop the text typed before
+			profiles.Open(players.screen(), players.players)
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.KeyV) {
+			opponent = nextOpponent(opponent, personalities, *adaptiveAI)
+			applyProfiles(players, recorder != nil)
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.Key1) {
+			opponent = singlePlayer(opponent, *aiReaction)
+			applyProfiles(players, recorder != nil)
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.KeyX) {
+			opponent = nextDifficulty(opponent, *aiReaction)
+			applyProfiles(players, recorder != nil)
+		} else if inMenu && keyboard.Pressed(glfw.KeyO) {
+			graphics.Open()
+		} else if inMenu && keyboard.Pressed(glfw.KeyK) {
+			controls.Open()
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.Key2) {
+			if recorder != nil {
+				game.Notify("Doubles can't change while recording")
+			} else {
+				game.SetDoubles(!game.Doubles())
+				mode := "Singles: one player per side"
+				if game.Doubles() {
+					mode = "Doubles: two players per side"
+				}
+				game.Notify(mode)
+			}
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.KeyM) {
+			if recorder != nil {
+				// The replay keeps the mutators it started with
+				game.Notify("The mutators can't change while recording")
+			} else {
+				mutators.Open()
+			}
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.KeyE) {
+			if recorder != nil {
+				game.Notify("The level can't change while recording")
+			} else {
+				levels.Open()
+			}
+		} else if phase == pong.GameMenu && viewer == nil && keyboard.Pressed(glfw.KeyL) {
+			config.ControlPreset = nextControlPreset(config.ControlPreset)
+			fileConfig.ControlPreset = config.ControlPreset
+			applyControlPreset(config.ControlPreset)
+			if err := saveConfig(*configFile, fileConfig); err != nil {
+				fmt.Println("ERROR::CONFIG:", err)
+			}
+			game.Notify("Controls: " + config.ControlPreset)
+		} else if phase == pong.GamePaused && *devMode && recorder == nil && (keyboard.Pressed(glfw.KeyLeft) || keyboard.Pressed(glfw.KeyRight)) {
+			// A replay wouldn't follow the rewound match
+			updates := 1
+			if keyboard.Down(glfw.KeyLeftShift) {
+				updates = int(config.UpdateRate / 10)
+			}
+			if keyboard.Pressed(glfw.KeyRight) {
+				updates = -updates
+			}
+			game.Rewind(updates)
+		} else if phase == pong.GameTutorial && keyboard.Pressed(glfw.KeyG) {
+			game.ToggleTrajectory()
+		} else if phase == pong.GameWin && keyboard.Pressed(glfw.KeyH) {
+			game.ToggleHeatMap()
+		} else if keyboard.Pressed(glfw.KeyF2) {
+			// Switch between the mini window and the display mode of the settings, or a window
+			display := config
+			if display.DisplayMode == "mini" {
+				display.DisplayMode = "windowed"
+			}
+			if !game.Compact() {
+				display.DisplayMode = "mini"
+			}
+			applyDisplay(window, display)
+			game.SetCompact(display.DisplayMode == "mini")
+		} else if keyboard.Pressed(glfw.KeyF3) {
+			game.ToggleDebug()
+		} else if keyboard.Pressed(glfw.KeyF4) {
+			game.ToggleCollisionShapes()
+		} else if keyboard.Pressed(glfw.KeyEscape) {
+			if inMatch {
+				askQuit(phase)
+			} else {
+				window.SetShouldClose(true)
+			}
+		}
+
+		// Manage user input and update Game state with a fixed timestep
+		for steps := 0; accumulator >= fixedTimeStep && steps < maxUpdatesPerFrame; steps++ {
+			input := readInput()
+			if votes != nil {
+				votes.Update(fixedTimeStep)
+				votes.Apply(&input, 2)
+			}
+			if opponent != nil {
+				opponent.Control(game.State(), &input, fixedTimeStep)
+			}
+			humans := [2]bool{true, opponent == nil && votes == nil}
+			if bots != nil {
+				humans[*botPlayer-1] = false
+			}
+			idle.control(game.State(), &input, fixedTimeStep, humans)
+			// The front players of doubles play with the keys or the controllers 3 and 4
+			readyComputers(game.State(), &input, [4]bool{humans[0], humans[1], true, true})
+			if bots != nil {
+				bots.Send(game.State().Bot(tick, *botPlayer))
+				input.SetBotMove(bots.Move(), *botPlayer)
+			}
+			tick++
+			if recorder != nil {
+				recorder.Record(input)
+			}
+			game.Step(input, fixedTimeStep)
+			accumulator -= fixedTimeStep
+		}
+		if game.DebugShown() {
+			adjustment := ""
+			if o, ok := opponent.(*pong.Opponent); ok && *adaptiveAI {
+				adjustment = o.Adjustment()
+			}
+			game.SetDebugValue("adaptive AI", adjustment)
+		}
+		if tutorialPending && game.TutorialCompleted() {
+			markTutorialDone()
+			tutorialPending = false
+		}
+		if result, won := game.Result(); !won {
+			resultRecorded = false
+		} else if !resultRecorded && viewer == nil && opponent == nil && !game.Doubles() {
+			for player := 1; player <= 2; player++ {
+				players.picked(player).Stats.Add(result, player)
+			}
+			player1, player2 := players.picked(1), players.picked(2)
+			player1.Rating, player2.Rating = pong.UpdateRatings(player1.Rating, player2.Rating, result)
+			game.SetRatings(player1.Rating, player2.Rating)
+			if err := players.savePicked(); err != nil {
+				fmt.Println("ERROR::PROFILES:", err)
+			}
+			resultRecorded = true
+		}
+		// Remember the skins picked in the menu
+		if phase := game.State().Phase; phase == pong.GameMenu && viewer == nil {
+			for player := 1; player <= 2; player++ {
+				if p := players.picked(player); p.Skin != game.SkinName(player) {
+					p.Skin = game.SkinName(player)
+					if err := saveProfile(*p); err != nil {
+						fmt.Println("ERROR::PROFILES:", err)
+					}
+				}
+			}
+		}
+		// Skip the render while the simulation is behind
+		if accumulator >= fixedTimeStep {
+			continue
+		}
+		// Wait for the next update or render when the render rate is capped
+		if frameRate > 0 {
+			now := glfw.GetTime()
+			nextRender := lastRender + 1.0/frameRate
+			if now < nextRender {
+				nextUpdate := now + fixedTimeStep - accumulator
+				time.Sleep(time.Duration((math.Min(nextRender, nextUpdate) - now) * float64(time.Second)))
+				continue
+			}
+			lastRender = now
+		}
+
+		// Render
+		alpha := float32(accumulator / fixedTimeStep)
+		if viewer != nil {
+			alpha = viewer.Alpha()
+		}
+		game.Draw(alpha)
+
+		if *screenshot != "" {
+			width, height := window.GetFramebufferSize()
+			if err := render.SaveScreenshot(*screenshot, width, height); err != nil {
+				fmt.Println("ERROR::SCREENSHOT:", err)
+			}
+			window.SetShouldClose(true)
+		}
+
+		window.SwapBuffers()
+
+		// Nothing moves at full rate out of a match: sleep until the next idle frame, waking up on input
+		if phase := game.State().Phase; viewer == nil && phase != pong.GameActive && phase != pong.GameTutorial && phase != pong.GameWarmUp && phase != pong.GameIntro && !game.Attracting() {
+			if wait := 1.0/idleFrameRate - (glfw.GetTime() - currentFrame); wait > 0 {
+				glfw.WaitEventsTimeout(wait)
+			}
+		}
+	}
+}
+
+// KeyCallback defines the callback to handle keyboard events
+func KeyCallback(window *glfw.Window, key glfw.Key, scanCode int, action glfw.Action, modifierKey glfw.ModifierKey) {
+	// ESC is handled by the main loop: it quits from the menu, asks to confirm in a match
+	// and goes back from the other screens
+	keyboard.HandleKey(key, action)
+	// Any key brings the menu back from the demo, and does nothing else
+	if action == glfw.Press {
+		if game.Attracting() {
+			keyboard.LastPressed()
+		}
+		game.Wake()
+	}
+}
+
+// CharCallback defines the callback to handle the text typed
+func CharCallback(window *glfw.Window, char rune) {
+	keyboard.HandleChar(char)
+}
+
+// overlayOpen tells if one of the settings screens, the level editor or a dialog is shown over the game
+func overlayOpen() bool {
+	return graphics.IsOpen() || mutators.IsOpen() || levels.IsOpen() || editor.IsOpen() || controls.IsOpen() ||
+		profiles.IsOpen() || inspector.IsOpen() || dialog.IsOpen()
+}
+
+// askQuit pauses the match and asks to quit it, resuming it on a no
+func askQuit(phase pong.GameState) {
+	question := "Quit the match?"
+	if phase == pong.GameTutorial {
+		question = "Quit the tutorial?"
+	} else if phase == pong.GameWarmUp {
+		question = "Quit the warm-up?"
+	}
+	resume := phase == pong.GameActive
+	requested.Pause = resume
+	dialog.Ask(question, func() {
+		requested.Quit = true
+	}, func() {
+		requested.Pause = resume
+	})
+}
+
+// readNavigation maps the keys moving the focus, the same on every screen: the arrows go
+// through the items, TAB forward and SHIFT+TAB back, ENTER uses the focused one
+func readNavigation() pong.Navigation {
+	shift := keyboard.Down(glfw.KeyLeftShift) || keyboard.Down(glfw.KeyRightShift)
+	tab := keyboard.Pressed(glfw.KeyTab)
+	return pong.Navigation{
+		Previous: keyboard.Pressed(glfw.KeyUp) || (tab && shift),
+		Next:     keyboard.Pressed(glfw.KeyDown) || (tab && !shift),
+		Activate: keyboard.Pressed(glfw.KeyEnter),
+	}
+}
+
+// readInspectorControls maps the keyboard and mouse state to the entity inspector commands
+func readInspectorControls(window *glfw.Window) pong.InspectorControls {
+	return pong.InspectorControls{
+		Navigation: readNavigation(),
+		Left:       keyboard.Pressed(glfw.KeyLeft),
+		Right:      keyboard.Pressed(glfw.KeyRight),
+		Fine:       keyboard.Down(glfw.KeyLeftShift) || keyboard.Down(glfw.KeyRightShift),
+		Close:      keyboard.Pressed(glfw.KeyEscape) || keyboard.Pressed(glfw.KeyF5),
+		Pointer:    readPointer(window),
+	}
+}
+
+// readLevelEditorControls maps the keyboard and mouse state to the level editor commands
+func readLevelEditorControls(window *glfw.Window, control bool) pong.LevelEditorControls {
+	return pong.LevelEditorControls{
+		Pointer:  readPointer(window),
+		NextTool: keyboard.Pressed(glfw.KeyTab),
+		Snap:     keyboard.Pressed(glfw.KeyG),
+		Undo:     control && keyboard.Pressed(glfw.KeyZ),
+		Redo:     control && keyboard.Pressed(glfw.KeyY),
+	}
+}
+
+// readDialogControls maps the keyboard and mouse state to the dialog answers
+func readDialogControls(window *glfw.Window) pong.DialogControls {
+	navigation := readNavigation()
+	// The buttons are side by side
+	navigation.Previous = navigation.Previous || keyboard.Pressed(glfw.KeyLeft)
+	navigation.Next = navigation.Next || keyboard.Pressed(glfw.KeyRight)
+	return pong.DialogControls{
+		Navigation: navigation,
+		Yes:        keyboard.Pressed(glfw.KeyY),
+		No:         keyboard.Pressed(glfw.KeyN) || keyboard.Pressed(glfw.KeyEscape),
+		Pointer:    readPointer(window),
+	}
+}
+
+// readInput maps the keyboard state to the game controls through the key bindings, adding the
+// input asked by the dialogs: it goes through the input so it's recorded in the replays
+func readInput() pong.Input {
+	input := requested
+	requested = pong.Input{}
+	input.Pause = input.Pause || pauseOnFocusLoss()
+	if overlayOpen() {
+		// The keys drive the screen shown
+		return input
+	}
+	input.Paddle1Up = keyboard.Down(bindings[actionPaddle1Up])
+	input.Paddle1Down = keyboard.Down(bindings[actionPaddle1Down])
+	input.Paddle2Up = keyboard.Down(bindings[actionPaddle2Up])
+	input.Paddle2Down = keyboard.Down(bindings[actionPaddle2Down])
+	input.Start = keyboard.Pressed(bindings[actionStart])
+	input.Skin1Next = keyboard.Pressed(bindings[actionSkin1Next])
+	input.Skin2Next = keyboard.Pressed(bindings[actionSkin2Next])
+	input.Well1 = keyboard.Pressed(bindings[actionWell1])
+	input.Well2 = keyboard.Pressed(bindings[actionWell2])
+	input.Paddle3Up = keyboard.Down(bindings[actionPaddle3Up])
+	input.Paddle3Down = keyboard.Down(bindings[actionPaddle3Down])
+	input.Paddle4Up = keyboard.Down(bindings[actionPaddle4Up])
+	input.Paddle4Down = keyboard.Down(bindings[actionPaddle4Down])
+	readGamepads(&input)
+	input.Pause = input.Pause || keyboard.Pressed(bindings[actionPause])
+	input.Tutorial = keyboard.Pressed(bindings[actionTutorial])
+	input.WarmUp = keyboard.Pressed(bindings[actionWarmUp])
+	if keysSwapped {
+		swapPlayerInput(&input)
+	}
+	return input
+}
+
+// pauseOnFocusLoss reports whether the match has to pause because the window lost the focus,
+// it goes through the input so the pause is recorded in the replays like the others
+func pauseOnFocusLoss() bool {
+	lost := focusLost
+	focusLost = false
+	return lost && game.State().Phase == pong.GameActive
+}
+
+// FocusCallback defines the callback to handle the window gaining or losing the focus
+func FocusCallback(window *glfw.Window, focused bool) {
+	if !focused {
+		focusLost = true
+	}
+}
+
+// readVotes counts the chat messages received since the last frame, it returns false once the chat is closed
+func readVotes(client *chat.Client, votes *pong.ChatVotes) bool {
+	for {
+		select {
+		case message, ok := <-client.Messages():
+			if !ok {
+				return false
+			}
+			votes.Vote(message.User, message.Text)
+		default:
+			return true
+		}
+	}
+}
+
+// JoystickCallback defines the callback to handle controllers being connected and disconnected
+func JoystickCallback(joy, event int) {
+	switch glfw.MonitorEvent(event) {
+	case glfw.Connected:
+		game.Notify("Controller connected: " + glfw.GetJoystickName(glfw.Joystick(joy)))
+	case glfw.Disconnected:
+		game.Notify("Controller disconnected")
+	}
+}
+
+// saveSettings writes the config file when leaving a settings screen
+func saveSettings(file string, config Config) {
+	if err := saveConfig(file, config); err != nil {
+		fmt.Println("ERROR::CONFIG:", err)
+		game.Notify("Failed to save the settings")
+		return
+	}
+	game.Notify("Settings saved")
+}
+
+// readReplayControls maps the keyboard state to the replay viewer commands
+func readReplayControls() pong.ReplayControls {
+	return pong.ReplayControls{
+		TogglePause: keyboard.Pressed(glfw.KeySpace),
+		Forward:     keyboard.Pressed(glfw.KeyRight),
+		Back:        keyboard.Pressed(glfw.KeyLeft),
+		Faster:      keyboard.Pressed(glfw.KeyUp),
+		Slower:      keyboard.Pressed(glfw.KeyDown),
+		NextGoal:    keyboard.Pressed(glfw.KeyPageDown),
+		PrevGoal:    keyboard.Pressed(glfw.KeyPageUp),
+	}
+}
+
+// FramebufferSizeCallback defines the callback to handle resize of the window
+func FramebufferSizeCallback(window *glfw.Window, width, height int) {
+	// Some platforms shrink the framebuffer to nothing when minimizing
+	minimized = width == 0 || height == 0
+	if minimized {
+		return
+	}
+	game.Resize(width, height)
+}
+
+// IconifyCallback defines the callback to handle the window being minimized and restored
+func IconifyCallback(window *glfw.Window, iconified bool) {
+	minimized = iconified
+}
+
+// initGlfw initializes glfw and returns a glfw.Window to use.
+func initGlfw(config Config) *glfw.Window {
+	if *softwareGL {
+		// Ask Mesa to pick its software rasterizer over any hardware driver
+		os.Setenv("LIBGL_ALWAYS_SOFTWARE", "1")
+		os.Setenv("GALLIUM_DRIVER", "llvmpipe")
+	}
+	if err := glfw.Init(); err != nil {
+		panic(err)
+	}
+	glfw.WindowHint(glfw.Resizable, glfw.False)
+	glfw.WindowHint(glfw.ContextVersionMajor, 4)
+	glfw.WindowHint(glfw.ContextVersionMinor, 1)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	if *glDebug {
+		glfw.WindowHint(glfw.OpenGLDebugContext, glfw.True)
+	}
+	if *hidden {
+		glfw.WindowHint(glfw.Visible, glfw.False)
+	}
+	if config.DisplayMode == "mini" {
+		// GLFW 3.2 only sets these creating the window, switching to mini later keeps the decorations
+		glfw.WindowHint(glfw.Floating, glfw.True)
+		glfw.WindowHint(glfw.Decorated, glfw.False)
+	}
+
+	width, height, _ := parseResolution(config.Resolution)
+	window, err := glfw.CreateWindow(width, height, "Pong", nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	if config.DisplayMode != "windowed" {
+		applyDisplay(window, config)
+	}
+	window.MakeContextCurrent()
+
+	window.SetKeyCallback(KeyCallback)
+	window.SetCharCallback(CharCallback)
+	window.SetCursorPosCallback(CursorPosCallback)
+	window.SetMouseButtonCallback(MouseButtonCallback)
+	glfw.SetJoystickCallback(JoystickCallback)
+	window.SetFramebufferSizeCallback(FramebufferSizeCallback)
+	window.SetIconifyCallback(IconifyCallback)
+
+	return window
+}
+
+// initOpenGL initializes OpenGL.
+func initOpenGL() {
+	// Initialize Glow
+	if err := gl.Init(); err != nil {
+		panic(err)
+	}
+
+	version := gl.GoStr(gl.GetString(gl.VERSION))
+	fmt.Println("OpenGL version", version)
+	if *softwareGL {
+		fmt.Println("OpenGL renderer", gl.GoStr(gl.GetString(gl.RENDERER)))
+	}
+
+	if *glDebug {
+		render.EnableDebugOutput()
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+
+	pong "github.com/lucatironi/go-pong"
+)
+
+// modContent are the themes and particle presets of the mods, offered by the config and the graphics settings
+var modContent pong.ModContent
+
+// loadMods finds the mods and loads their themes and particle presets, the game loads their skins
+// and levels. It returns the mods and tells if some of their content failed to load, it's skipped
+func loadMods() ([]pong.Mod, bool) {
+	mods, err := pong.FindMods(pong.ModsDir)
+	if err != nil {
+		fmt.Println("ERROR::MODS:", err)
+		return nil, true
+	}
+	content, err := pong.LoadModContent(mods)
+	if err != nil {
+		fmt.Println("ERROR::MODS:", err)
+	}
+	modContent = content
+	return mods, err != nil
+}
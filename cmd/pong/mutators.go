@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	pong "github.com/lucatironi/go-pong"
+)
+
+// mutatorPreset is a named combination of mutators
+type mutatorPreset struct {
+	name     string
+	mutators pong.Mutators
+}
+
+// mutatorPresets are always offered by the mutators screen, the ones saved in the config follow them
+var mutatorPresets = []mutatorPreset{
+	{name: "classic"},
+	{name: "arcade", mutators: pong.MutatorBigBall | pong.MutatorDoubleSpeed},
+	{name: "precision", mutators: pong.MutatorTinyPaddles},
+	{name: "blind", mutators: pong.MutatorFogOfWar},
+	{name: "chaos", mutators: pong.MutatorBigBall | pong.MutatorTinyPaddles | pong.MutatorDoubleSpeed | pong.MutatorNoWalls | pong.MutatorFogOfWar | pong.MutatorGravityWells | pong.MutatorPowerUps},
+}
+
+// configMutators returns the mutators of the config, the config is validated already
+func configMutators(config Config) pong.Mutators {
+	mutators, _ := pong.ParseMutators(config.Mutators)
+	return mutators
+}
+
+// findMutatorPreset returns the index of the named built in preset, -1 if there's none
+func findMutatorPreset(name string) int {
+	for i, preset := range mutatorPresets {
+		if preset.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// customPreset is shown when the mutators on are not any of the presets
+const customPreset = "custom"
+
+// mutatorsScreen is the settings screen of the mutators: a preset, then each mutator on or off
+type mutatorsScreen struct {
+	*pong.SettingsScreen
+	presets []mutatorPreset
+	preset  *pong.Setting
+	toggles []*pong.Setting
+}
+
+// newMutatorsScreen returns the screen with the mutators of the config selected
+func newMutatorsScreen(config Config) *mutatorsScreen {
+	s := &mutatorsScreen{preset: &pong.Setting{Name: "Preset"}}
+	settings := []*pong.Setting{s.preset}
+	for _, name := range pong.MutatorNames() {
+		toggle := newSetting(name, []string{"off", "on"}, "off")
+		s.toggles = append(s.toggles, toggle)
+		settings = append(settings, toggle)
+	}
+	s.SettingsScreen = pong.NewSettingsScreen(game, "MUTATORS", settings)
+	s.SettingsScreen.SetHint("UP/DOWN/TAB select - LEFT/RIGHT change - S save preset - ESC back")
+	s.setPresets(config.MutatorPresets)
+	s.set(configMutators(config))
+	return s
+}
+
+// setPresets lists the built in presets and the saved ones, by name
+func (s *mutatorsScreen) setPresets(saved map[string][]string) {
+	s.presets = append([]mutatorPreset(nil), mutatorPresets...)
+	var names []string
+	for name := range saved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		mutators, _ := pong.ParseMutators(saved[name])
+		s.presets = append(s.presets, mutatorPreset{name: name, mutators: mutators})
+	}
+}
+
+// mutators returns the mutators turned on
+func (s *mutatorsScreen) mutators() pong.Mutators {
+	var mutators pong.Mutators
+	for i, toggle := range s.toggles {
+		if toggle.Current == 1 {
+			mutators |= 1 << uint(i)
+		}
+	}
+	return mutators
+}
+
+// set turns the mutators on and selects their preset, custom is only listed when they are none
+func (s *mutatorsScreen) set(mutators pong.Mutators) {
+	for i, toggle := range s.toggles {
+		toggle.Current = int(mutators>>uint(i)) & 1
+	}
+	s.preset.Values = nil
+	s.preset.Current = -1
+	for i, preset := range s.presets {
+		s.preset.Values = append(s.preset.Values, preset.name)
+		if preset.mutators == mutators && s.preset.Current == -1 {
+			s.preset.Current = i
+		}
+	}
+	if s.preset.Current == -1 {
+		s.preset.Values = append(s.preset.Values, customPreset)
+		s.preset.Current = len(s.presets)
+	}
+}
+
+// changed keeps the preset and the mutators in sync after the setting changed
+func (s *mutatorsScreen) changed(setting *pong.Setting) {
+	if index := s.preset.Current; setting == s.preset && index < len(s.presets) {
+		s.set(s.presets[index].mutators)
+		// Presets saved with the same mutators stay selected
+		s.preset.Current = index
+		return
+	}
+	s.set(s.mutators())
+}
+
+// save adds the mutators on as a new preset to the config, it returns its name or an error when
+// they already are one
+func (s *mutatorsScreen) save(config *Config) (string, error) {
+	if s.preset.Value() != customPreset {
+		return "", fmt.Errorf("already the preset %v", s.preset.Value())
+	}
+	name := ""
+	for n := 1; name == "" || config.MutatorPresets[name] != nil; n++ {
+		name = fmt.Sprintf("%v %v", customPreset, n)
+	}
+	if config.MutatorPresets == nil {
+		config.MutatorPresets = map[string][]string{}
+	}
+	config.MutatorPresets[name] = s.mutators().Names()
+	s.setPresets(config.MutatorPresets)
+	s.set(s.mutators())
+	return name, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+
+	pong "github.com/lucatironi/go-pong"
+)
+
+// nextOpponent returns the opponent following the current one: the right player, then the computer
+// with each of the personalities, adapting its skill to the match if asked to, then the controllers
+// of the AI plugins. The plugins are looked for every time, so they can be added while playing
+func nextOpponent(controller pong.PaddleController, personalities []pong.Personality, adaptive bool) pong.PaddleController {
+	plugins, err := pong.FindAIPlugins(pong.AIPluginsDir)
+	if err != nil {
+		fmt.Println("ERROR::AI: failed to find the plugins:", err)
+	}
+	next := 0
+	if current, ok := controller.(*pong.Opponent); ok && !singlePlayerOpponent(current) {
+		names := personalityNames(personalities)
+		if current.Plugin() {
+			next = len(personalities)
+			names = plugins
+		}
+		for i, name := range names {
+			if name == current.Name() {
+				next += i + 1
+			}
+		}
+	}
+	if next < len(personalities) {
+		opponent := pong.NewOpponent(personalities[next], 2, game.Random())
+		opponent.SetAdaptive(adaptive)
+		return opponent
+	}
+	// A plugin failing to load is skipped for the next one
+	for _, name := range plugins[next-len(personalities):] {
+		opponent, err := pong.LoadPluginOpponent(pong.AIPluginsDir, name, 2)
+		if err == nil {
+			return opponent
+		}
+		fmt.Println("ERROR::AI: failed to load plugin", name+":", err)
+	}
+	return nil
+}
+
+// personalityNames returns the names of the personalities
+func personalityNames(personalities []pong.Personality) []string {
+	names := make([]string, len(personalities))
+	for i, personality := range personalities {
+		names[i] = personality.Name
+	}
+	return names
+}
+
+// singlePlayer returns the computer to play alone against at the difficulty of the game, reacting
+// to the shots the seconds late, the right player again when the current opponent is that computer
+func singlePlayer(controller pong.PaddleController, reaction float64) pong.PaddleController {
+	if singlePlayerOpponent(controller) {
+		return nil
+	}
+	return pong.NewDifficultyOpponent(game.Difficulty(), 2, reaction, game.Random())
+}
+
+// nextDifficulty makes the single player mode harder, back to the easiest after the hardest, and
+// returns the opponent playing at the new difficulty
+func nextDifficulty(controller pong.PaddleController, reaction float64) pong.PaddleController {
+	game.SetDifficulty(game.Difficulty().Next())
+	game.Notify("Difficulty: " + game.Difficulty().String())
+	if singlePlayerOpponent(controller) {
+		return pong.NewDifficultyOpponent(game.Difficulty(), 2, reaction, game.Random())
+	}
+	return controller
+}
+
+// singlePlayerOpponent tells if the opponent is the computer of the single player mode
+func singlePlayerOpponent(controller pong.PaddleController) bool {
+	opponent, ok := controller.(*pong.Opponent)
+	if !ok {
+		return false
+	}
+	_, single := opponent.Difficulty()
+	return single
+}
+
+// opponentName returns how the opponent of the left player is shown
+func opponentName(controller pong.PaddleController) string {
+	opponent, ok := controller.(*pong.Opponent)
+	switch {
+	case !ok:
+		return "CPU"
+	case opponent.Plugin():
+		return "AI " + opponent.Name()
+	}
+	return "CPU " + opponent.Name()
+}
@@ -0,0 +1,62 @@
+package main
+
+import pong "github.com/lucatironi/go-pong"
+
+// controlPreset is a quick setup of the sides: the keys of the players swapped, so the left player
+// plays with the keys of the right one, and the court mirrored, so the left player is shown on the right
+type controlPreset struct {
+	name     string
+	swapKeys bool
+	mirror   bool
+}
+
+// controlPresets are the presets in the order L switches through them in the menu
+var controlPresets = []controlPreset{
+	{name: "standard"},
+	{name: "left-handed", swapKeys: true},
+	{name: "swapped-sides", swapKeys: true, mirror: true},
+}
+
+// keysSwapped tells if the keys of the players drive the paddle of the other one
+var keysSwapped bool
+
+// findControlPreset returns the index of the named preset, -1 if there's none
+func findControlPreset(name string) int {
+	for i, preset := range controlPresets {
+		if preset.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// controlPresetNames returns the names of the presets, for the errors
+func controlPresetNames() []string {
+	var names []string
+	for _, preset := range controlPresets {
+		names = append(names, preset.name)
+	}
+	return names
+}
+
+// nextControlPreset returns the name of the preset after the named one
+func nextControlPreset(name string) string {
+	return controlPresets[(findControlPreset(name)+1)%len(controlPresets)].name
+}
+
+// applyControlPreset swaps the keys of the players and mirrors the court as the named preset does
+func applyControlPreset(name string) {
+	preset := controlPresets[findControlPreset(name)]
+	keysSwapped = preset.swapKeys
+	game.SetMirrored(preset.mirror)
+}
+
+// swapPlayerInput gives the paddle and the skin input of each player to the other one
+func swapPlayerInput(input *pong.Input) {
+	input.Paddle1Up, input.Paddle2Up = input.Paddle2Up, input.Paddle1Up
+	input.Paddle1Down, input.Paddle2Down = input.Paddle2Down, input.Paddle1Down
+	input.Skin1Next, input.Skin2Next = input.Skin2Next, input.Skin1Next
+	input.Well1, input.Well2 = input.Well2, input.Well1
+	input.Paddle3Up, input.Paddle4Up = input.Paddle4Up, input.Paddle3Up
+	input.Paddle3Down, input.Paddle4Down = input.Paddle4Down, input.Paddle3Down
+}
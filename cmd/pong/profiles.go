@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+	pong "github.com/lucatironi/go-pong"
+)
+
+// profilesDir keeps a save file per player profile, named after the profile
+const profilesDir = "profiles"
+
+// defaultProfileNames are the profiles created when there aren't two to pick from
+var defaultProfileNames = []string{"Player 1", "Player 2"}
+
+// profile is the save file of a player profile
+type profile struct {
+	Name     string            `json:"name"`
+	Skin     string            `json:"skin,omitempty"`
+	Handicap int               `json:"handicap"`
+	Rating   float64           `json:"rating"`
+	Bindings map[string]string `json:"bindings,omitempty"` // Keys of the player actions changed from the defaults
+	Stats    pong.Stats        `json:"stats"`
+}
+
+// newProfile returns a profile without matches played
+func newProfile(name string) profile {
+	return profile{Name: name, Rating: pong.InitialRating}
+}
+
+// playerProfiles are the profiles saved and the ones picked by the left and the right player
+type playerProfiles struct {
+	list    []profile
+	players [2]int
+	created []int // Profiles created for lack of them, not saved yet
+}
+
+// loadProfiles reads the save files sorted by name, creating the default profiles when there are less
+// than two, and picks the named profiles for the players
+func loadProfiles(picked [2]string) (*playerProfiles, error) {
+	profiles := &playerProfiles{players: [2]int{-1, -1}}
+	files, err := ioutil.ReadDir(profilesDir)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		var p profile
+		data, readErr := ioutil.ReadFile(filepath.Join(profilesDir, file.Name()))
+		if readErr == nil {
+			readErr = json.Unmarshal(data, &p)
+		}
+		if readErr != nil || p.Name == "" {
+			err = fmt.Errorf("failed to load the profile %v: %v", file.Name(), readErr)
+			continue
+		}
+		if p.Rating == 0 {
+			// Saved before the ratings
+			p.Rating = pong.InitialRating
+		}
+		profiles.list = append(profiles.list, p)
+	}
+	sort.Slice(profiles.list, func(i, j int) bool {
+		return strings.ToLower(profiles.list[i].Name) < strings.ToLower(profiles.list[j].Name)
+	})
+	for _, name := range defaultProfileNames {
+		if len(profiles.list) < 2 && profiles.find(name) == -1 {
+			profiles.list = append(profiles.list, newProfile(name))
+			profiles.created = append(profiles.created, len(profiles.list)-1)
+		}
+	}
+	for i, name := range picked {
+		if index := profiles.find(name); index != -1 && index != profiles.players[1-i] {
+			profiles.players[i] = index
+		}
+	}
+	// The players without a profile take the first ones free
+	for i := range profiles.players {
+		for free := 0; profiles.players[i] == -1; free++ {
+			if free != profiles.players[1-i] {
+				profiles.players[i] = free
+			}
+		}
+	}
+	return profiles, err
+}
+
+// find returns the index of the named profile, -1 if there's none
+func (p *playerProfiles) find(name string) int {
+	for i, profile := range p.list {
+		if strings.EqualFold(profile.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// picked returns the profile of the player
+func (p *playerProfiles) picked(player int) *profile {
+	return &p.list[p.players[player-1]]
+}
+
+// names returns the names of the profiles picked by the players, for the config file
+func (p *playerProfiles) names() [2]string {
+	return [2]string{p.picked(1).Name, p.picked(2).Name}
+}
+
+// screen returns the profiles for the profile screen
+func (p *playerProfiles) screen() []pong.Profile {
+	var profiles []pong.Profile
+	for _, profile := range p.list {
+		profiles = append(profiles, pong.Profile{Name: profile.Name, Handicap: profile.Handicap, Rating: profile.Rating, Stats: profile.Stats})
+	}
+	return profiles
+}
+
+// update replaces the profiles with the ones edited in the profile screen, saving the new and the
+// changed ones and deleting the save files of the ones removed
+func (p *playerProfiles) update(edited []pong.Profile, players [2]int) error {
+	var list []profile
+	var err error
+	for _, e := range edited {
+		saved := newProfile(e.Name)
+		index := p.find(e.Name)
+		if index != -1 {
+			saved = p.list[index]
+		}
+		if index == -1 || saved.Handicap != e.Handicap {
+			saved.Handicap = e.Handicap
+			if saveErr := saveProfile(saved); saveErr != nil {
+				err = saveErr
+			}
+		}
+		list = append(list, saved)
+	}
+	for _, old := range p.list {
+		if !profileListed(edited, old.Name) {
+			if removeErr := os.Remove(profileFile(old.Name)); removeErr != nil && !os.IsNotExist(removeErr) {
+				err = removeErr
+			}
+		}
+	}
+	p.list = list
+	p.players = players
+	return err
+}
+
+// saveCreated writes the profiles created for lack of them, with the keys of the players
+func (p *playerProfiles) saveCreated(bindings keyBindings) error {
+	var err error
+	for _, index := range p.created {
+		for player, picked := range p.players {
+			if picked == index {
+				p.list[index].Bindings = bindings.profile(player + 1)
+			}
+		}
+		if saveErr := saveProfile(p.list[index]); saveErr != nil {
+			err = saveErr
+		}
+	}
+	p.created = nil
+	return err
+}
+
+// savePicked writes the profiles of the players
+func (p *playerProfiles) savePicked() error {
+	var err error
+	for player := 1; player <= 2; player++ {
+		if saveErr := saveProfile(*p.picked(player)); saveErr != nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// applyProfiles gives the players the names, keys, skins and handicaps of their profiles: the
+// handicaps are left alone while recording, the replay keeps the ones it started with
+func applyProfiles(profiles *playerProfiles, recording bool) {
+	names := profiles.names()
+	game.SetPlayerNames(names[0], names[1])
+	game.SetRatings(profiles.picked(1).Rating, profiles.picked(2).Rating)
+	if opponent != nil {
+		// The matches against the computer aren't rated
+		game.SetPlayerNames(names[0], opponentName(opponent))
+		game.SetRatings(profiles.picked(1).Rating, 0)
+	}
+	for player := 1; player <= 2; player++ {
+		p := profiles.picked(player)
+		if err := bindings.applyProfile(player, p.Bindings); err != nil {
+			fmt.Printf("ERROR::PROFILES: %v: %v\n", p.Name, err)
+		}
+		game.PickSkin(player, p.Skin)
+	}
+	controls.SetBindings(bindings.list())
+	if !recording {
+		game.SetHandicaps(profiles.picked(1).Handicap, profiles.picked(2).Handicap)
+	}
+}
+
+// readProfileControls maps the keyboard and mouse state to the profile screen commands
+func readProfileControls(window *glfw.Window) pong.ProfileControls {
+	return pong.ProfileControls{
+		Navigation: readNavigation(),
+		Player1:    keyboard.Pressed(glfw.Key1),
+		Player2:    keyboard.Pressed(glfw.Key2),
+		Left:       keyboard.Pressed(glfw.KeyLeft),
+		Right:      keyboard.Pressed(glfw.KeyRight),
+		Create:     keyboard.Pressed(glfw.KeyN),
+		Delete:     keyboard.Pressed(glfw.KeyDelete),
+		Text:       keyboard.Typed(),
+		Erase:      keyboard.Pressed(glfw.KeyBackspace),
+		Close:      keyboard.Pressed(glfw.KeyEscape),
+		Pointer:    readPointer(window),
+	}
+}
+
+// profileListed tells if a profile of the list has the name
+func profileListed(profiles []pong.Profile, name string) bool {
+	for _, profile := range profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// profileFile returns the save file of the named profile
+func profileFile(name string) string {
+	return filepath.Join(profilesDir, name+".json")
+}
+
+// saveProfile writes the save file of the profile
+func saveProfile(p profile) error {
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(profileFile(p.Name), append(data, '\n'), 0644)
+}
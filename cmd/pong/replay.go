@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	pong "github.com/lucatironi/go-pong"
+)
+
+// replayUsage describes the replay subcommands
+const replayUsage = `usage: pong replay inspect|validate FILE
+       pong replay export FILE VIDEO
+  inspect   print the header and the length of the replay
+  validate  check the replay can be read and played back by this build, then play it back
+  export    render the replay to a video file with ffmpeg, the format follows the extension (.mp4, .webm...)`
+
+// replayArgs are the arguments of each replay subcommand, its name included
+var replayArgs = map[string]int{"inspect": 2, "validate": 2, "export": 3}
+
+// runReplayCommand runs a replay subcommand and returns the exit code
+func runReplayCommand(args []string) int {
+	if len(args) == 0 || len(args) != replayArgs[args[0]] {
+		fmt.Fprintln(os.Stderr, replayUsage)
+		return 2
+	}
+	replay, err := readReplayFile(args[1])
+	if err != nil {
+		fmt.Println("ERROR::REPLAY:", err)
+		return 1
+	}
+	switch args[0] {
+	case "inspect":
+		header := replay.Header
+		fmt.Printf("version:     %v (readable from version %v)\n", header.Version, header.MinVersion)
+		fmt.Printf("config hash: %016x\n", header.ConfigHash)
+		fmt.Printf("seed:        %v\n", header.Seed)
+		fmt.Printf("tick rate:   %v Hz\n", header.TickRate)
+		fmt.Printf("sets:        best of %v\n", header.Sets)
+		fmt.Printf("handicaps:   %v : %v\n", header.Handicaps[0], header.Handicaps[1])
+		fmt.Printf("mutators:    %v\n", header.Mutators)
+		fmt.Printf("intro:       %vs, victory %vs\n", header.Intro, header.Victory)
+		if header.Level != nil {
+			fmt.Printf("level:       %v\n", header.Level.Name)
+		}
+		fmt.Printf("length:      %v ticks (%v)\n", replay.Ticks, replayDuration(replay))
+		fmt.Printf("records:     %v input changes\n", replay.Records)
+	case "validate":
+		if err := replay.Validate(); err != nil {
+			fmt.Println("ERROR::REPLAY:", err)
+			return 1
+		}
+		state := replay.Play().State()
+		fmt.Printf("OK: %v ticks played back, final score %v : %v\n", replay.Ticks, state.Score1, state.Score2)
+	case "export":
+		if err := exportReplay(replay, args[2]); err != nil {
+			fmt.Println("ERROR::EXPORT:", err)
+			return 1
+		}
+		fmt.Printf("OK: %v exported to %v\n", replayDuration(replay), args[2])
+	}
+	return 0
+}
+
+func readReplayFile(file string) (*pong.Replay, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return pong.ReadReplay(f)
+}
+
+func replayDuration(replay *pong.Replay) time.Duration {
+	if replay.Header.TickRate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(replay.Ticks) / replay.Header.TickRate * float64(time.Second))
+}
+
+// replayRecorder records the inputs of the session to a replay file
+type replayRecorder struct {
+	*pong.ReplayWriter
+	file *os.File
+}
+
+// createReplay creates the replay file of a game created with the options, the seed included
+func createReplay(path string, tickRate float64, options pong.Options) (*replayRecorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := pong.NewReplayWriter(file, tickRate, options)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &replayRecorder{ReplayWriter: writer, file: file}, nil
+}
+
+// Close ends the replay and closes its file
+func (r *replayRecorder) Close() error {
+	if err := r.ReplayWriter.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
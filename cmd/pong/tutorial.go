@@ -0,0 +1,23 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+// tutorialDoneFile marks that the tutorial was completed or skipped, without it the game starts with the tutorial
+const tutorialDoneFile = ".tutorial-done"
+
+// firstRun reports whether the tutorial was never completed
+func firstRun() bool {
+	_, err := os.Stat(tutorialDoneFile)
+	return os.IsNotExist(err)
+}
+
+// markTutorialDone writes the marker so the tutorial isn't shown again
+func markTutorialDone() {
+	if err := ioutil.WriteFile(tutorialDoneFile, nil, 0644); err != nil {
+		log.Println("ERROR::TUTORIAL: failed to save the tutorial progress:", err)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log"
+
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// vsyncModes are the supported swap modes: synced with the monitor refresh, not synced, or synced
+// unless a frame is late (it tears instead of waiting for the next refresh)
+var vsyncModes = []string{"on", "off", "adaptive"}
+
+func validVSyncMode(mode string) bool {
+	for _, vsyncMode := range vsyncModes {
+		if mode == vsyncMode {
+			return true
+		}
+	}
+	return false
+}
+
+// applyVSync sets the swap interval of the mode and returns the rate to cap the frames to: the
+// configured render rate, or the monitor refresh when vsync is off and no render rate is set
+func applyVSync(mode string, renderRate float64) float64 {
+	switch mode {
+	case "off":
+		glfw.SwapInterval(0)
+		if renderRate == 0 {
+			renderRate = monitorRefreshRate()
+			log.Printf("VSYNC: off, capping the frames to the %v Hz of the monitor", renderRate)
+		}
+	case "adaptive":
+		if glfw.ExtensionSupported("WGL_EXT_swap_control_tear") || glfw.ExtensionSupported("GLX_EXT_swap_control_tear") {
+			glfw.SwapInterval(-1)
+			break
+		}
+		log.Println("VSYNC: adaptive vsync is not supported by the driver, using vsync on")
+		fallthrough
+	default:
+		glfw.SwapInterval(1)
+	}
+	return renderRate
+}
+
+// monitorRefreshRate returns the refresh rate of the primary monitor, zero when unknown
+func monitorRefreshRate() float64 {
+	monitor := glfw.GetPrimaryMonitor()
+	if monitor == nil {
+		return 0
+	}
+	mode := monitor.GetVideoMode()
+	if mode == nil {
+		return 0
+	}
+	return float64(mode.RefreshRate)
+}
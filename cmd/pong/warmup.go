@@ -0,0 +1,23 @@
+package main
+
+import pong "github.com/lucatironi/go-pong"
+
+// readyComputers makes the players not at the keyboard ready in the warm-up, holding up and down
+// for them, so the match starts as soon as the humans are
+func readyComputers(state pong.State, input *pong.Input, humans [4]bool) {
+	if state.Phase != pong.GameWarmUp {
+		return
+	}
+	if !humans[0] {
+		input.Paddle1Up, input.Paddle1Down = true, true
+	}
+	if !humans[1] {
+		input.Paddle2Up, input.Paddle2Down = true, true
+	}
+	if !humans[2] {
+		input.Paddle3Up, input.Paddle3Down = true, true
+	}
+	if !humans[3] {
+		input.Paddle4Up, input.Paddle4Down = true, true
+	}
+}
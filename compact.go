@@ -0,0 +1,57 @@
+package pong
+
+import (
+	"fmt"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// SetCompact simplifies the HUD for a small window: the score and a word or two, large enough to
+// be read at a fraction of the virtual resolution
+func (g *Game) SetCompact(compact bool) {
+	g.compact = compact
+}
+
+// Compact tells if the HUD is simplified for a small window
+func (g *Game) Compact() bool {
+	return g.compact
+}
+
+// drawCompactUI renders the score and what to do next in large text
+func (g *Game) drawCompactUI() {
+	left, right := g.screenScores()
+	g.drawCentered(80, 2, mgl.Vec3{1.0, 1.0, 1.0}, fmt.Sprintf("%v : %v", left, right))
+	message := ""
+	switch g.state {
+	case GameMenu:
+		message = "ENTER"
+	case GamePaused:
+		message = "PAUSED"
+	case GameSetBreak:
+		message = "NEXT SET"
+	case GameWin:
+		message = "P1 WINS"
+		if g.paddle2Score > g.paddle1Score {
+			message = "P2 WINS"
+		}
+	case GameTutorial:
+		g.drawTutorial()
+	case GameIntro:
+		names := g.screenPlayers()
+		message = fmt.Sprintf("P%v VS P%v", names[0], names[1])
+	case GameWarmUp:
+		message = "WARM-UP"
+		if g.warmUp.countdown > 0 {
+			message = fmt.Sprint(int(g.warmUp.countdown) + 1)
+		}
+	}
+	if message != "" {
+		g.drawCentered(float32(g.height/2)-60, 1.5, mgl.Vec3{1.0, 1.0, 1.0}, message)
+	}
+}
+
+// drawCentered renders the text centered on the screen at the height
+func (g *Game) drawCentered(y, scale float32, color mgl.Vec3, text string) {
+	width, _ := g.text.MeasureText(scale, "%v", text)
+	g.text.RenderText(float32(g.width/2)-width/2, y, scale, color, "%v", text)
+}
@@ -0,0 +1,110 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/tween"
+)
+
+var (
+	cueWidth      = float32(12) // Thickness of the pulses along the edges of the screen
+	cueSmashWidth = float32(28) // Thickness of the pulse of a smash
+	cueTime       = 0.4         // Seconds a pulse takes to fade
+	cueGoalTime   = 1.0
+	cueHitColor   = mgl.Vec3{1.0, 1.0, 1.0}
+	cueSmashColor = mgl.Vec3{1.0, 0.5, 0.1}
+	cueGoalColor  = mgl.Vec3{1.0, 0.8, 0.1}
+)
+
+// Edges of the screen the cues pulse along
+const (
+	cueLeft = iota
+	cueRight
+	cueTop
+	cueBottom
+)
+
+// cueIndicators pulse the edges of the screen on the events that make a sound, the hits, the
+// smashes and the goals, for the players who can't hear them
+type cueIndicators struct {
+	enabled bool
+	pulses  [4]cuePulse // By edge of the screen
+}
+
+// cuePulse is the fading pulse of an edge of the screen
+type cuePulse struct {
+	alpha float32
+	width float32
+	color mgl.Vec3
+}
+
+// SetCueIndicators shows the hits and the goals as pulses along the edges of the screen
+func (g *Game) SetCueIndicators(enabled bool) {
+	g.cues.enabled = enabled
+}
+
+// CueIndicators tells if the hits and the goals pulse the edges of the screen
+func (g *Game) CueIndicators() bool {
+	return g.cues.enabled
+}
+
+// cue pulses the edge of the screen closest to where the events of the update happened
+func (g *Game) cue(events simulationEvents) {
+	if !g.cues.enabled {
+		return
+	}
+	center := g.ball.position.Add(mgl.Vec2{g.ball.radius, g.ball.radius})
+	side := cueLeft
+	if center.X() > float32(g.width/2) {
+		side = cueRight
+	}
+	switch {
+	case events.scored != 0:
+		g.pulseCue(side, cueSmashWidth, cueGoalColor, cueGoalTime)
+	case events.paddleHit && g.hitStop == hitStopCounter:
+		g.pulseCue(side, cueSmashWidth, cueSmashColor, cueTime)
+	case events.paddleHit:
+		g.pulseCue(side, cueWidth, cueHitColor, cueTime)
+	case events.wallHit:
+		edge := cueTop
+		if center.Y() > float32(g.height/2) {
+			edge = cueBottom
+		}
+		g.pulseCue(edge, cueWidth, cueHitColor, cueTime)
+	}
+}
+
+// pulseCue starts a pulse along the edge of the court, the one shown there once the court is
+// mirrored or flipped
+func (g *Game) pulseCue(edge int, width float32, color mgl.Vec3, duration float64) {
+	flipped := g.flip.flipped
+	if edge <= cueRight && g.mirrored != (flipped && g.flip.horizontal) {
+		edge = 1 - edge
+	} else if edge >= cueTop && flipped && !g.flip.horizontal {
+		edge = cueTop + cueBottom - edge
+	}
+	pulse := &g.cues.pulses[edge]
+	pulse.width, pulse.color = width, color
+	g.tweens.Add(tween.NewFloat(&pulse.alpha, 1, 0, duration, tween.InQuad))
+}
+
+// drawCues renders the pulses along the edges of the screen
+func (g *Game) drawCues() {
+	width, height := float32(g.width), float32(g.height)
+	for edge, pulse := range g.cues.pulses {
+		if pulse.alpha <= 0 {
+			continue
+		}
+		position, size := mgl.Vec2{0, 0}, mgl.Vec2{pulse.width, height}
+		switch edge {
+		case cueRight:
+			position = mgl.Vec2{width - pulse.width, 0}
+		case cueTop:
+			size = mgl.Vec2{width, pulse.width}
+		case cueBottom:
+			position, size = mgl.Vec2{0, height - pulse.width}, mgl.Vec2{width, pulse.width}
+		}
+		g.renderer.Alpha = pulse.alpha
+		g.renderer.Draw(position, size, 0, pulse.color)
+	}
+	g.renderer.Alpha = 1
+}
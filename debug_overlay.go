@@ -0,0 +1,130 @@
+package pong
+
+import (
+	"fmt"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+var (
+	debugShapeWidth    = float32(3)
+	debugContactTime   = 0.5          // Seconds the last contact stays drawn
+	debugNormalLength  = float32(80)  // Length of the contact normal drawn
+	debugVelocityScale = float32(0.1) // Seconds of movement the velocity vectors are drawn as
+)
+
+// debugValue is a named value listed by the debug overlay
+type debugValue struct {
+	name, value string
+}
+
+// debugContact is a contact of the ball with a paddle, for the collision shapes
+type debugContact struct {
+	point  mgl.Vec2 // Point of the paddle touched
+	normal mgl.Vec2
+	time   float64 // Game time of the contact, negative before the first one
+}
+
+// debugOverlay lists the values worth watching while playing, in the bottom left corner, and
+// draws the collision shapes over the court
+type debugOverlay struct {
+	shown   bool
+	shapes  bool
+	values  []debugValue // In the order they were first set
+	contact debugContact
+	gc      *telemetry
+}
+
+// ToggleDebug shows or hides the debug overlay
+func (g *Game) ToggleDebug() {
+	g.debug.shown = !g.debug.shown
+	g.debug.gc.reset()
+}
+
+// ToggleCollisionShapes shows or hides the boxes of the paddles, the circle of the ball, the last
+// contact and the velocities
+func (g *Game) ToggleCollisionShapes() {
+	g.debug.shapes = !g.debug.shapes
+}
+
+// recordContact keeps the contact of the ball with a paddle for the collision shapes
+func (g *Game) recordContact(circle physics.Circle, contact physics.Collision) {
+	g.debug.contact = debugContact{
+		point:  circle.Center.Sub(contact.Normal.Mul(circle.Radius - contact.Penetration)),
+		normal: contact.Normal,
+		time:   g.time,
+	}
+}
+
+// DebugShown tells if the debug overlay is shown
+func (g *Game) DebugShown() bool {
+	return g.debug.shown
+}
+
+// SetDebugValue sets a value listed by the debug overlay, an empty value removes it
+func (g *Game) SetDebugValue(name, value string) {
+	values := g.debug.values[:0]
+	found := false
+	for _, v := range g.debug.values {
+		if v.name == name {
+			found = true
+			if value == "" {
+				continue
+			}
+			v.value = value
+		}
+		values = append(values, v)
+	}
+	if !found && value != "" {
+		values = append(values, debugValue{name, value})
+	}
+	g.debug.values = values
+}
+
+// drawDebug renders the state of the simulation followed by the debug values
+func (g *Game) drawDebug() {
+	if !g.debug.shown {
+		return
+	}
+	state := g.State()
+	lines := []debugValue{
+		{"ball", fmt.Sprintf("%.0f, %.0f", state.Ball.X(), state.Ball.Y())},
+		{"velocity", fmt.Sprintf("%.0f, %.0f", state.BallVelocity.X(), state.BallVelocity.Y())},
+		{"paddles", fmt.Sprintf("%.0f - %.0f", state.Paddle1.Y(), state.Paddle2.Y())},
+	}
+	g.debug.gc.frame()
+	lines = append(lines, g.debug.gc.values...)
+	lines = append(lines, g.debug.values...)
+	top := float32(g.height) - float32(len(lines))*30 - 40
+	g.renderer.Draw(mgl.Vec2{20, top}, mgl.Vec2{760, float32(len(lines))*30 + 20}, 0, mgl.Vec3{0.0, 0.0, 0.0})
+	for i, line := range lines {
+		y := top + 10 + float32(i)*30
+		g.text.RenderText(40, y, 0.25, mgl.Vec3{0.6, 0.6, 0.6}, "%v", line.name)
+		g.text.RenderText(220, y, 0.25, mgl.Vec3{1.0, 1.0, 1.0}, "%v", line.value)
+	}
+}
+
+// drawCollisionShapes renders the shapes the collisions are checked with, the velocities of the
+// objects and the last contact while it's recent
+func (g *Game) drawCollisionShapes(alpha float32) {
+	if !g.debug.shapes {
+		return
+	}
+	green, yellow, red := mgl.Vec3{0.2, 1.0, 0.2}, mgl.Vec3{1.0, 0.8, 0.1}, mgl.Vec3{1.0, 0.2, 0.2}
+	paddles, _ := g.paddles()
+	for _, paddle := range paddles {
+		position := paddle.RenderPosition(alpha)
+		g.renderer.DrawRectOutline(position, paddle.size, debugShapeWidth, green)
+		center := position.Add(paddle.size.Mul(0.5))
+		g.renderer.DrawLine(center, center.Add(paddle.velocity.Mul(debugVelocityScale)), debugShapeWidth, yellow)
+	}
+	center := g.ball.RenderPosition(alpha).Add(mgl.Vec2{g.ball.radius, g.ball.radius})
+	g.renderer.DrawCircleOutline(center, g.ball.radius, debugShapeWidth, green)
+	g.renderer.DrawLine(center, center.Add(g.ball.velocity.Mul(debugVelocityScale)), debugShapeWidth, yellow)
+	if c := g.debug.contact; c.time >= 0 && g.time-c.time < debugContactTime {
+		size := mgl.Vec2{debugShapeWidth * 4, debugShapeWidth * 4}
+		g.renderer.Draw(c.point.Sub(size.Mul(0.5)), size, 0, red)
+		g.renderer.DrawLine(c.point, c.point.Add(c.normal.Mul(debugNormalLength)), debugShapeWidth, red)
+	}
+}
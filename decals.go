@@ -0,0 +1,109 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	decalLife         = 3.0         // Seconds an impact mark takes to fade away
+	decalSize         = float32(18) // Side of the marks, the paddle ones are half as big
+	decalLimit        = 32          // Marks kept at once, the oldest make room for the new ones
+	decalWallColor    = mgl.Vec3{0.45, 0.32, 0.22}
+	decalPaddleColor  = mgl.Vec3{0.25, 0.18, 0.12}
+	playerTrailColors = [2]mgl.Vec4{{0.3, 0.6, 1.0, 1.0}, {1.0, 0.4, 0.3, 1.0}}
+)
+
+// decals are the scorch marks left where the ball hits the walls and the paddles
+type decals struct {
+	enabled bool
+	marks   []decal
+}
+
+// decal is a fading impact mark, on a paddle it moves with it
+type decal struct {
+	paddle   *GameObject // Paddle hit, nil for the walls
+	offset   mgl.Vec2    // Center of the mark, from the top left corner of the paddle or of the court
+	rotation float32
+	life     float64 // Seconds left
+}
+
+// SetDecals leaves scorch marks where the ball hits the walls and the paddles
+func (g *Game) SetDecals(enabled bool) {
+	g.decals.enabled = enabled
+	if !enabled {
+		g.decals.marks = g.decals.marks[:0]
+	}
+}
+
+// Decals tells if the ball leaves scorch marks where it hits
+func (g *Game) Decals() bool {
+	return g.decals.enabled
+}
+
+// SetPlayerTrails colors the trail of the ball by the player who last hit it
+func (g *Game) SetPlayerTrails(enabled bool) {
+	g.playerTrails = enabled
+	g.applySkins()
+}
+
+// PlayerTrails tells if the trail of the ball is colored by the player who last hit it
+func (g *Game) PlayerTrails() bool {
+	return g.playerTrails
+}
+
+// updateDecals fades the marks and leaves new ones where the events of the update happened
+func (g *Game) updateDecals(events simulationEvents, deltaTime float64) {
+	marks := g.decals.marks[:0]
+	for _, mark := range g.decals.marks {
+		mark.life -= deltaTime
+		if mark.life > 0 {
+			marks = append(marks, mark)
+		}
+	}
+	g.decals.marks = marks
+	if !g.decals.enabled {
+		return
+	}
+	center := g.ball.position.Add(mgl.Vec2{g.ball.radius, g.ball.radius})
+	switch {
+	case events.paddleHit && events.hitPaddle != nil:
+		offset := center.Sub(events.hitPaddle.position)
+		// On the face the ball bounced off
+		offset[0] = mgl.Clamp(offset[0], 0, events.hitPaddle.size.X())
+		offset[1] = mgl.Clamp(offset[1], 0, events.hitPaddle.size.Y())
+		g.addDecal(events.hitPaddle, offset)
+	case events.wallHit:
+		if center.Y() > float32(g.height/2) {
+			center[1] = float32(g.height)
+		} else {
+			center[1] = 0
+		}
+		g.addDecal(nil, center)
+	}
+}
+
+// addDecal leaves a mark, taking the place of the oldest one when there are too many
+func (g *Game) addDecal(paddle *GameObject, offset mgl.Vec2) {
+	if len(g.decals.marks) >= decalLimit {
+		g.decals.marks = append(g.decals.marks[:0], g.decals.marks[1:]...)
+	}
+	// Turn each mark a little differently, without touching the random numbers of the simulation
+	rotation := float32(g.time) * 7
+	g.decals.marks = append(g.decals.marks, decal{paddle: paddle, offset: offset, rotation: rotation, life: decalLife})
+}
+
+// drawDecals renders the marks as two crossed squares, fading with their life
+func (g *Game) drawDecals(alpha float32) {
+	for _, mark := range g.decals.marks {
+		size, color, center := decalSize, decalWallColor, mark.offset
+		if mark.paddle != nil {
+			size, color = decalSize/2, decalPaddleColor
+			center = mark.paddle.RenderPosition(alpha).Add(mark.offset)
+		}
+		g.renderer.Alpha = float32(mark.life / decalLife)
+		for _, rotation := range []float32{mark.rotation, mark.rotation + mgl.DegToRad(45)} {
+			// The sprites turn around their top left corner
+			corner := mgl.Rotate2D(rotation).Mul2x1(mgl.Vec2{size / 2, size / 2})
+			g.renderer.Draw(center.Sub(corner), mgl.Vec2{size, size}, rotation, color)
+		}
+	}
+	g.renderer.Alpha = 1
+}
@@ -0,0 +1,110 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// DialogControls are the answers to a dialog for one frame
+type DialogControls struct {
+	Navigation         // Activating a button answers
+	Yes, No    bool    // Answer without going through the buttons
+	Pointer    Pointer // Hovering focuses a button, clicking it answers
+}
+
+// dialogSize is the size of the dialog box, drawn in the middle of the screen
+var dialogSize = mgl.Vec2{900, 260}
+
+// Dialog asks a yes or no question over the game, dimming the scene behind it: while it's open
+// its owner sends the input to it only
+type Dialog struct {
+	game    *Game
+	message string
+	onYes   func()
+	onNo    func()
+	open    bool
+	focus   focus // Button focused: 0 yes, 1 no
+}
+
+// NewDialog returns a closed dialog
+func NewDialog(game *Game) *Dialog {
+	d := &Dialog{game: game}
+	if game.initialized {
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { d.draw() }))
+	}
+	return d
+}
+
+// Ask opens the dialog with the question, the callbacks are run on the answer and can be nil
+func (d *Dialog) Ask(message string, onYes, onNo func()) {
+	d.message = message
+	d.onYes = onYes
+	d.onNo = onNo
+	d.open = true
+	d.focus.reset(2)
+	d.dim(true)
+}
+
+// IsOpen tells if the dialog waits for an answer
+func (d *Dialog) IsOpen() bool {
+	return d.open
+}
+
+// Update closes the dialog on an answer, running its callback
+func (d *Dialog) Update(controls DialogControls) {
+	if !d.open {
+		return
+	}
+	if d.focus.update(controls.Navigation, controls.Pointer, d.button) {
+		controls.Yes = controls.Yes || d.focus.index == 0
+		controls.No = controls.No || d.focus.index == 1
+	}
+	if !controls.Yes && !controls.No {
+		return
+	}
+	d.open = false
+	d.dim(false)
+	answer := d.onNo
+	if controls.Yes {
+		answer = d.onYes
+	}
+	if answer != nil {
+		answer()
+	}
+}
+
+func (d *Dialog) dim(dim bool) {
+	if d.game.initialized {
+		d.game.effects.Dim = dim
+	}
+}
+
+// position returns the top left corner of the dialog box
+func (d *Dialog) position() mgl.Vec2 {
+	return mgl.Vec2{float32(d.game.width)/2 - dialogSize.X()/2, float32(d.game.height)/2 - dialogSize.Y()/2}
+}
+
+// button returns the area of the yes (0) or no (1) button
+func (d *Dialog) button(button int) (mgl.Vec2, mgl.Vec2) {
+	return d.position().Add(mgl.Vec2{60 + float32(button)*280, 140}), mgl.Vec2{240, 80}
+}
+
+// draw renders the question in a box in the middle of the screen
+func (d *Dialog) draw() {
+	if !d.open {
+		return
+	}
+	g := d.game
+	position := d.position()
+	g.renderer.Draw(position.Sub(mgl.Vec2{4, 4}), dialogSize.Add(mgl.Vec2{8, 8}), 0, mgl.Vec3{1.0, 0.8, 0.1})
+	g.renderer.Draw(position, dialogSize, 0, mgl.Vec3{0.1, 0.1, 0.1})
+	g.text.RenderText(position.X()+60, position.Y()+50, 0.45, mgl.Vec3{1.0, 1.0, 1.0}, "%v", d.message)
+	for button, label := range []string{"YES (Y)", "NO (N)"} {
+		buttonPosition, size := d.button(button)
+		g.renderer.Draw(buttonPosition, size, 0, mgl.Vec3{0.25, 0.25, 0.25})
+		if d.focus.index == button {
+			g.drawFocusRing(buttonPosition, size)
+		}
+		g.text.RenderText(buttonPosition.X()+30, buttonPosition.Y()+24, 0.35, mgl.Vec3{1.0, 0.8, 0.1}, "%v", label)
+	}
+}
@@ -0,0 +1,66 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var doublesFront = float32(480) // Distance of the front paddles from the back ones, in doubles
+
+// SetDoubles switches between one player and two players per side from the next match, the menu
+// shows them at once. In doubles the players 3 and 4 play the front paddles of the left and the
+// right team
+func (g *Game) SetDoubles(doubles bool) {
+	g.doubles = doubles
+	if g.state == GameMenu {
+		g.applyRules(g.matchRules())
+		g.resetObjects()
+	}
+}
+
+// Doubles tells if the matches are played two against two
+func (g *Game) Doubles() bool {
+	return g.doubles
+}
+
+// matchRules returns the rules of the matches, the tutorial plays without the mutators, doubles nor level
+func (g *Game) matchRules() rules {
+	r := g.mutators.rules()
+	r.doubles = g.doubles
+	r.level = g.level
+	return r
+}
+
+// paddles returns the paddles in play with the team of each: the back ones, then the front ones in doubles
+func (g *Game) paddles() ([]*GameObject, []int) {
+	if !g.rules.doubles {
+		return []*GameObject{g.paddle1, g.paddle2}, []int{1, 2}
+	}
+	return []*GameObject{g.paddle1, g.paddle2, g.paddle3, g.paddle4}, []int{1, 2, 1, 2}
+}
+
+// passesThrough tells if the ball goes through the paddles of the team: in doubles the ball
+// leaving a team goes through its paddles, so the front one doesn't stop the shots of the back one
+func (g *Game) passesThrough(ball *BallObject, team int) bool {
+	return g.rules.doubles && (ball.velocity.X() > 0) == (team == 1)
+}
+
+// frontPositions returns where the front paddles of the teams start
+func (g *Game) frontPositions() (mgl.Vec2, mgl.Vec2) {
+	width := g.rules.paddleSize.X()
+	back := g.paddleSpawns()
+	return back[0].Add(mgl.Vec2{width + doublesFront, 0}), back[1].Sub(mgl.Vec2{width + doublesFront, 0})
+}
+
+// drawFrontPaddles renders the front paddles, in doubles
+func (g *Game) drawFrontPaddles(alpha float32) {
+	if g.rules.doubles {
+		g.paddle3.Draw(g.renderer, alpha)
+		g.paddle4.Draw(g.renderer, alpha)
+	}
+}
+
+// teamName returns how the winner of a set or a match is called, the player or the team
+func (g *Game) teamName(team int) string {
+	if !g.rules.doubles {
+		return g.playerNames[team-1]
+	}
+	return [2]string{"Left team", "Right team"}[team-1]
+}
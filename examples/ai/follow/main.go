@@ -0,0 +1,31 @@
+// Command follow is an AI plugin moving its paddle towards the ball, build it into the AI plugins
+// directory of the game with:
+//
+//	go build -buildmode=plugin -o ai/follow.so ./examples/ai/follow
+package main
+
+import "github.com/lucatironi/go-pong/pkg/bot"
+
+var deadZone = float32(10) // Distance from the ball the paddle stops moving at
+
+// follower moves the paddle to the height of the ball
+type follower struct{}
+
+// NewAIController returns the controller of the plugin, looked up by the game
+func NewAIController() bot.AIController {
+	return follower{}
+}
+
+// Move goes up or down towards the ball
+func (follower) Move(state bot.State) string {
+	switch {
+	case state.Ball[1] < state.Paddle[1]-deadZone:
+		return bot.MoveUp
+	case state.Ball[1] > state.Paddle[1]+deadZone:
+		return bot.MoveDown
+	}
+	return bot.MoveStay
+}
+
+// main is never run, plugins only need the package to be main
+func main() {}
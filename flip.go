@@ -0,0 +1,76 @@
+package pong
+
+import (
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	flipInterval = 12.0 // Seconds of play with the court the right way before it flips
+	flipDuration = 6.0  // Seconds of play the court stays flipped
+	flipWarning  = 1.5  // Seconds the flip is announced for before it happens
+	flipBlink    = 4.0  // Blinks per second of the warning
+	flipPunch    = float32(0.06)
+)
+
+// courtFlip is the chaos modifier mirroring the court now and then, horizontally and vertically in
+// turns: a harder take on the confuse effect, which turns the court upside down for good
+type courtFlip struct {
+	enabled    bool
+	timer      float64 // Seconds of play left before the court flips or goes back
+	flipped    bool
+	horizontal bool // Axis of the current or the next flip
+}
+
+// SetCourtFlip turns the modifier flipping the court on or off, the input isn't flipped with it
+func (g *Game) SetCourtFlip(enabled bool) {
+	g.flip = courtFlip{enabled: enabled, timer: flipInterval, horizontal: true}
+	g.applyMirror()
+}
+
+// CourtFlip tells if the modifier flipping the court is on
+func (g *Game) CourtFlip() bool {
+	return g.flip.enabled
+}
+
+// updateFlip counts the seconds of play to the next flip, punching the camera as it happens
+func (g *Game) updateFlip(deltaTime float64) {
+	f := &g.flip
+	if !f.enabled {
+		return
+	}
+	f.timer -= deltaTime
+	if f.timer > 0 {
+		return
+	}
+	if f.flipped {
+		f.timer = flipInterval
+		f.horizontal = !f.horizontal
+	} else {
+		f.timer = flipDuration
+	}
+	f.flipped = !f.flipped
+	g.applyMirror()
+	g.camera.ZoomPunch(flipPunch, 0.3)
+}
+
+// warning tells if the flip, or the way back, is about to happen
+func (f courtFlip) warning() bool {
+	return f.enabled && f.timer < flipWarning
+}
+
+// drawFlipWarning blinks the axis of the coming flip in the middle of the screen
+func (g *Game) drawFlipWarning() {
+	if g.state != GameActive || !g.flip.warning() || math.Mod(g.flip.timer*flipBlink, 1) < 0.5 {
+		return
+	}
+	message := "FLIP <->"
+	if !g.flip.horizontal {
+		message = "FLIP UP-DOWN"
+	}
+	if g.flip.flipped {
+		message = "BACK"
+	}
+	g.drawCentered(float32(g.height/2)-180, 0.6, mgl.Vec3{1.0, 0.3, 0.2}, message)
+}
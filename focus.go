@@ -0,0 +1,60 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+// focusRingWidth is the thickness of the ring drawn around the focused item
+var focusRingWidth = float32(4)
+
+// Navigation moves the focus through the items of a screen, the same keys drive every screen
+type Navigation struct {
+	Previous, Next bool
+	Activate       bool // Use the focused item
+}
+
+// focus tracks the focused item of a screen: the items are traversed in the order they're drawn,
+// wrapping around, and the pointer focuses the item under it
+type focus struct {
+	index int
+	count int
+}
+
+// reset focuses the first of the items
+func (f *focus) reset(count int) {
+	f.index = 0
+	f.count = count
+}
+
+// update moves the focus and reports whether the focused item was activated, by the
+// navigation or by a click
+func (f *focus) update(navigation Navigation, pointer Pointer, area func(int) (mgl.Vec2, mgl.Vec2)) bool {
+	if f.count == 0 {
+		return false
+	}
+	switch {
+	case navigation.Previous:
+		f.index = (f.index + f.count - 1) % f.count
+	case navigation.Next:
+		f.index = (f.index + 1) % f.count
+	}
+	for i := 0; i < f.count; i++ {
+		if position, size := area(i); pointer.Over(position, size) {
+			if pointer.Moved || pointer.Click {
+				f.index = i
+			}
+			if pointer.Click {
+				return true
+			}
+		}
+	}
+	return navigation.Activate
+}
+
+// drawFocusRing draws the ring around the area of the focused item
+func (g *Game) drawFocusRing(position, size mgl.Vec2) {
+	color := mgl.Vec3{1.0, 0.8, 0.1}
+	w := focusRingWidth
+	g.renderer.Draw(position.Sub(mgl.Vec2{w, w}), mgl.Vec2{size.X() + 2*w, w}, 0, color)
+	g.renderer.Draw(mgl.Vec2{position.X() - w, position.Y() + size.Y()}, mgl.Vec2{size.X() + 2*w, w}, 0, color)
+	g.renderer.Draw(mgl.Vec2{position.X() - w, position.Y()}, mgl.Vec2{w, size.Y()}, 0, color)
+	g.renderer.Draw(mgl.Vec2{position.X() + size.X(), position.Y()}, mgl.Vec2{w, size.Y()}, 0, color)
+}
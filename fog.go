@@ -0,0 +1,58 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	fogDensity = float32(0.85) // Share of the light taken away on the fogged half
+	fogShift   = float32(4)    // Speed of the fog moving to the other half, in sides per second
+	fogFade    = float32(2)    // Speed of the fog coming and going, in densities per second
+)
+
+// fogOfWar hides the half of the court the ball is going away from: each player sees the ball
+// clearly only in their own half, so the shots of the opponent have to be anticipated
+type fogOfWar struct {
+	side    float32 // -1 with the fog on the right half of the court, 1 on the left
+	density float32
+}
+
+// updateFog moves the fog to the half the ball is leaving, it's only up while playing
+func (g *Game) updateFog(deltaTime float64) {
+	f := &g.fog
+	density := float32(0)
+	if g.rules.fog && (g.state == GameActive || g.state == GamePaused) {
+		density = fogDensity
+	}
+	side := float32(1)
+	if g.ball.velocity.X() < 0 {
+		side = -1
+	}
+	f.side = approach(f.side, side, fogShift*float32(deltaTime))
+	f.density = approach(f.density, density, fogFade*float32(deltaTime))
+}
+
+// applyFog sets the fog of the postprocessor, splitting the court along its middle through the camera
+func (g *Game) applyFog() {
+	g.effects.Fog.Enabled = g.fog.density > 0
+	if !g.effects.Fog.Enabled {
+		return
+	}
+	view := g.camera.View()
+	left := view.Mul4x1(mgl.Vec4{0, 0, 0, 1}).X()
+	middle := view.Mul4x1(mgl.Vec4{float32(g.width / 2), 0, 0, 1}).X()
+	right := view.Mul4x1(mgl.Vec4{float32(g.width), 0, 0, 1}).X()
+	g.effects.Fog.Split = middle / float32(g.width)
+	g.effects.Fog.Side = g.fog.side
+	if left > right {
+		// The court is mirrored, so is the fog
+		g.effects.Fog.Side = -g.fog.side
+	}
+	g.effects.Fog.Density = g.fog.density
+}
+
+// approach moves the value towards the target by at most step
+func approach(value, target, step float32) float32 {
+	if value < target {
+		return mgl.Clamp(value+step, value, target)
+	}
+	return mgl.Clamp(value-step, target, value)
+}
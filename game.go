@@ -1,208 +1,1028 @@
-package main
+// Package pong is the pong game: its simulation, rendering and effects
+package pong
 
 import (
-	"github.com/go-gl/glfw/v3.2/glfw"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
 	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/level"
+	"github.com/lucatironi/go-pong/pkg/particles"
+	"github.com/lucatironi/go-pong/pkg/physics"
+	"github.com/lucatironi/go-pong/pkg/render"
+	"github.com/lucatironi/go-pong/pkg/resources"
+	"github.com/lucatironi/go-pong/pkg/text"
+	"github.com/lucatironi/go-pong/pkg/tween"
+)
+
+// Virtual resolution the game is simulated and rendered at, scaled to fit the window
+const (
+	VirtualWidth  = 1920
+	VirtualHeight = 1080
 )
 
 // GameState represents a state
 type GameState int
 
+// States of the game
 const (
-	gameActive GameState = iota
-	gameMenu
-	gameWin
+	GameActive GameState = iota
+	GameMenu
+	GameWin
+	GamePaused
+	GameTutorial
+	GameSetBreak // Between the sets of a match
+	GameWarmUp   // Before a match, the ball bounces without scoring until both players are ready
+	GameIntro    // Before the first serve, the camera shows the players
+)
+
+// menuHelp are the keys of the menu, a line per group
+var menuHelp = []string{
+	"T tutorial - SPACE warm-up - 1 solo - 2 doubles",
+	"X difficulty - V opponent - M mutators - E levels",
+	"O graphics - K controls - L sides - C profiles",
+}
+
+var gameStateNames = []string{"active", "menu", "win", "paused", "tutorial", "set_break", "warm_up", "intro"}
+
+// String returns the name of the state
+func (s GameState) String() string {
+	if s < 0 || int(s) >= len(gameStateNames) {
+		return "unknown"
+	}
+	return gameStateNames[s]
+}
+
+// Draw layers of the game, composed in ascending order
+const (
+	layerBackground render.Layer = iota
+	layerCourt
+	layerObjects
+	layerParticles
+	layerBall // Over the particles, so its trail and the sparks stay behind it
+	layerUI
+	layerNotifications
+	layerDebug
 )
 
 var (
 	maxScore            = 10
 	shakeTime           = 0.0
-	paddleSize          = mgl.Vec2{20, 100}
-	paddleVelocity      = float32(500)
-	initialBallVelocity = mgl.Vec2{450.0, 300.0}
+	paddleSize          = mgl.Vec2{40, 180}
+	paddleMargin        = float32(20)
+	paddleVelocity      = float32(900)
+	paddleSpin          = float32(0.3) // Fraction of the paddle velocity given to the ball on a hit
+	ballRadius          = float32(20)
+	initialBallVelocity = mgl.Vec2{1080.0, 540.0}
+	ballMaxAngle        = float32(math.Pi / 3) // Steepest angle of the ball leaving a paddle
+	ballMaxSpeed        = float32(2)           // Fastest the ball goes, as a multiple of the speed of the serves
+	cameraFollow        = float32(0.05)
+	cameraGoalPunch     = float32(0.08)
+	cameraWinZoom       = float32(1.3)
 )
 
 // Game represents a game uber object
 type Game struct {
-	state           GameState
-	keys            map[glfw.Key]bool
-	processedKeys   [1024]bool
-	width, height   int
-	renderer        *SpriteRenderer
-	resourceManager *ResourceManager
-	particles       *ParticleGenerator
-	effects         *PostProcessor
-	text            *TextRenderer
-	paddle1         *GameObject
-	paddle2         *GameObject
-	ball            *BallObject
-	paddle1Score    int
-	paddle2Score    int
-}
-
-func newGame(width, height int) *Game {
-	return &Game{
-		state:        gameMenu,
-		keys:         make(map[glfw.Key]bool),
-		width:        width,
-		height:       height,
+	state             GameState
+	width, height     int
+	renderer          *render.SpriteRenderer
+	resourceManager   *resources.ResourceManager
+	particles         *particles.ParticleGenerator
+	fireworks         *particles.Fireworks
+	confetti          *particles.Confetti
+	swirl             *particles.Swirl // Sparks of the gravity wells
+	effects           *render.PostProcessor
+	text              *text.TextRenderer
+	layers            *render.LayerStack
+	viewport          render.Viewport
+	camera            *render.Camera2D
+	paddle1           *GameObject
+	paddle2           *GameObject
+	paddle3           *GameObject // Front paddle of the left team, in doubles
+	paddle4           *GameObject // Front paddle of the right team, in doubles
+	ball              *BallObject
+	paddle1Score      int
+	paddle2Score      int
+	skins             []Skin
+	paddle1Skin       int // Index of the skin picked by each player
+	paddle2Skin       int
+	lastHit           int     // Player whose paddle last hit the ball, zero after a serve
+	trailBudget       float64 // Fraction of a trail particle carried over to the next update
+	trail             string  // Particle preset left by the ball, the trail of the skins when not one of them
+	mods              []Mod   // User content loaded along with the one of the game
+	content           ModContent
+	tutorial          tutorial
+	match             match
+	handicaps         [2]int // Points each player starts the sets with
+	mutators          Mutators
+	difficulty        Difficulty
+	sequences         sequences
+	introLength       float64 // Seconds of the match intro, recorded in the replays
+	victoryLength     float64 // Seconds of the victory sequence, recorded in the replays
+	doubles           bool
+	level             *level.Level // Layout of the court, nil for the classic one
+	editing           bool         // The level editor is shown over the court, hiding the menu
+	rules             rules        // Tuning of the match being played, changed by the mutators
+	playerNames       [2]string
+	ratings           [2]float64 // Ratings of the players, not shown when zero
+	toasts            toasts
+	debug             debugOverlay
+	trajectory        bool // The aiming aid is shown in the tutorial
+	compact           bool // The HUD is simplified for a small window
+	mirrored          bool // The court is drawn mirrored horizontally
+	flip              courtFlip
+	fog               fogOfWar
+	wells             [2]gravityWell
+	powerUps          powerUps
+	hitStop           int // Updates left of the freeze after a hard hit
+	transition        transition
+	tweens            tween.Group // Animations of the presentation, updated with the game
+	menu              menuAnimation
+	scorePop          scorePop
+	warmUp            warmUp
+	attract           attract
+	cues              cueIndicators
+	decals            decals
+	playerTrails      bool // The trail of the ball takes the color of the player who last hit it
+	heatMapShown      bool // The heat map of the match is shown once it's won
+	heatTextures      [2]*render.Texture2D
+	autopilot         [2]bool // The computer plays the paddles of the players who stopped playing
+	rewind            rewindBuffer
+	tutorialCompleted bool // The tutorial was finished or skipped
+	quality           QualitySettings
+	theme             Theme
+	devMode           bool
+	initialized       bool    // The OpenGL resources are loaded
+	time              float64 // Simulated time, drives the postprocessing effects
+	seed              int64
+	random            *rand.Rand    // Random numbers of the simulation, seeded so the runs can be reproduced
+	randomSource      *randomSource // State of the random numbers of the simulation, saved by the snapshots
+	presentation      *rand.Rand    // Random numbers of the particles and of the computer opponents, apart from the simulation
+}
+
+// Options configures a game
+type Options struct {
+	Quality   QualitySettings // Effects settings
+	Theme     *Theme          // Colors of the court, the first theme when nil
+	DevMode   bool            // Hot reload textures, report leaks and show the resource errors on screen
+	Tutorial  bool            // Start with the tutorial instead of the menu
+	Sets      int             // Best of sets of a match, zero plays a single set
+	Handicaps [2]int          // Points each player starts the sets with, up to maxHandicap
+	Mutators  Mutators        // Modifiers of the rules of the matches
+	Doubles   bool            // Two players per side, the players 3 and 4 at the front
+	Level     *level.Level    // Layout of the court, the classic one when nil
+	Mods      []Mod           // User content loaded along with the one of the game
+	Content   ModContent      // Themes and particle presets of the mods, read with LoadModContent
+	Seed      int64           // Seed of the random numbers, the same seed plays the same effects and opponents
+	Intro     float64         // Seconds of the match intro, the default when zero
+	Victory   float64         // Seconds of the victory sequence, the default when zero
+}
+
+// Input is the state of the controls for one fixed update
+type Input struct {
+	Paddle1Up, Paddle1Down bool
+	Paddle2Up, Paddle2Down bool
+	Start                  bool // Starts a match from the menu, the next set or goes back to the menu after a win
+	Skin1Next, Skin2Next   bool // Cycle the skins of the players in the menu
+	Pause                  bool // Pauses or resumes a match
+	Tutorial               bool // Starts the tutorial from the menu
+	Quit                   bool // Abandons the match or the tutorial, back to the menu
+	Paddle3Up, Paddle3Down bool // Front paddles, in doubles
+	Paddle4Up, Paddle4Down bool
+	Well1, Well2           bool // Place a gravity well, with the mutator on
+	WarmUp                 bool // Starts the warm-up from the menu, the match follows once both players are ready
+}
+
+// PaddleController plays a paddle in place of a player, setting its input from the state of the match
+type PaddleController interface {
+	Control(state State, input *Input, deltaTime float64)
+}
+
+// State is a snapshot of the game, positions are in virtual resolution coordinates
+type State struct {
+	Phase            GameState
+	Ball             mgl.Vec2 // Center of the ball
+	BallVelocity     mgl.Vec2
+	Paddle1, Paddle2 mgl.Vec2     // Center of the paddles
+	Paddle3, Paddle4 mgl.Vec2     // Center of the front paddles in doubles
+	PaddleSizes      [4]mgl.Vec2  // Sizes of the paddles, from the first to the fourth, as changed by the mutators and the power-ups
+	BallRadius       float32      // Radius of the ball, as changed by the mutators
+	Level            *level.Level // Walls and obstacles the ball bounces off, nil for the classic court
+	Score1, Score2   int
+	Sets1, Sets2     int // Sets won in the match
+}
+
+// New returns a game simulated at the virtual resolution, ready to be stepped without a window
+// nor an OpenGL context: the rendering resources are only loaded by Init or the first Render
+func New(options Options) *Game {
+	g := &Game{
+		state:        GameMenu,
+		quality:      options.Quality,
+		theme:        themes[0],
+		devMode:      options.DevMode,
+		width:        VirtualWidth,
+		height:       VirtualHeight,
 		paddle1Score: 0,
 		paddle2Score: 0,
+		match:        newMatch(options.Sets),
+		mutators:     options.Mutators,
+		doubles:      options.Doubles,
+		level:        options.Level,
+		mods:         options.Mods,
+		content:      options.Content,
+		playerNames:  [2]string{"Player 1", "Player 2"},
+		debug:        debugOverlay{contact: debugContact{time: -1}, gc: newTelemetry()},
+		menu:         menuAnimation{alpha: 1},
+		seed:         options.Seed,
+		presentation: rand.New(rand.NewSource(options.Seed)),
+	}
+	g.random, g.randomSource = newRandom(options.Seed)
+	g.introLength, g.victoryLength = options.Intro, options.Victory
+	if g.introLength == 0 {
+		g.introLength = introTime
+	}
+	if g.victoryLength == 0 {
+		g.victoryLength = victoryTime
+	}
+	g.SetHandicaps(options.Handicaps[0], options.Handicaps[1])
+	g.rules = g.matchRules()
+	if options.Theme != nil {
+		g.theme = *options.Theme
+	}
+	g.initObjects()
+	if options.Tutorial {
+		g.startTutorial()
 	}
+	// The first scene is shown at once
+	g.transition.scene = g.state
+	return g
 }
 
 // Init initializes a game
 func (g *Game) Init() {
-	g.resourceManager = newResourceManager()
+	g.resourceManager = resources.NewResourceManager()
+	g.resourceManager.HotReload = g.devMode
+	g.resourceManager.LeakCheck = g.devMode
+	g.resourceManager.KeepErrors = g.devMode
 	// Load shaders
-	g.resourceManager.LoadShader("./shaders/sprite.vs", "./shaders/sprite.frag", "sprite")
-	g.resourceManager.LoadShader("./shaders/particle.vs", "./shaders/particle.frag", "particle")
-	g.resourceManager.LoadShader("./shaders/post_processing.vs", "./shaders/post_processing.frag", "postprocessing")
-	g.resourceManager.LoadShader("./shaders/text.vs", "./shaders/text.frag", "text")
+	g.resourceManager.LoadShader("./shaders/sprite.vs", "./shaders/sprite.frag", "", "sprite")
+	g.resourceManager.LoadShader("./shaders/particle.vs", "./shaders/particle.frag", "", "particle")
+	g.resourceManager.LoadShader("./shaders/post_processing.vs", "./shaders/post_processing.frag", "", "postprocessing")
+	g.resourceManager.LoadShader("./shaders/text.vs", "./shaders/text.frag", "", "text")
 	// Configure shaders
 	projection := mgl.Ortho2D(0.0, float32(g.width), float32(g.height), 0.0)
 	g.resourceManager.GetShader("sprite").Use().SetMatrix4("projection", projection, false)
 	g.resourceManager.GetShader("particle").Use().SetMatrix4("projection", projection, false)
 	g.resourceManager.GetShader("text").Use().SetMatrix4("projection", projection, false)
 	// Set render-specific controls
-	g.renderer = newSpriteRenderer(g.resourceManager.GetShader("sprite"))
-	g.particles = newParticleGenerator(g.resourceManager.GetShader("particle"), 50)
-	g.effects = newPostProcessor(g.resourceManager.GetShader("postprocessing"), int32(g.width), int32(g.height))
-	g.text = newTextRenderer(g.resourceManager.GetShader("text"))
-	g.text.LoadFont("./assets/Roboto-Bold.ttf", 48)
-	// Configure game objects
+	g.renderer = render.NewSpriteRenderer(g.resourceManager.GetShader("sprite"))
+	g.initEffects(int32(g.width), int32(g.height))
+	g.text = text.NewTextRenderer(g.resourceManager.GetShader("text"), g.resourceManager.LoadFont("roboto", "./assets/Roboto-Bold.ttf", 96))
+	g.camera = render.NewCamera2D(float32(g.width), float32(g.height))
+	g.skins = loadSkins(contentDirs(skinsDir, g.mods, modSkins), g.resourceManager)
+	g.applySkins()
+	g.initHeatMap()
+	// Register drawables with their layers
+	g.layers = render.NewLayerStack()
+	g.layers.Register(layerCourt, render.DrawFunc(func(float32) { g.drawMarkings() }))
+	g.layers.Register(layerCourt, render.DrawFunc(func(float32) { g.drawLevel() }))
+	g.layers.Register(layerCourt, render.DrawFunc(func(float32) { g.drawHeatMap() }))
+	g.layers.Register(layerCourt, render.DrawFunc(func(float32) { g.drawWells() }))
+	g.layers.Register(layerObjects, render.DrawFunc(func(alpha float32) { g.paddle1.Draw(g.renderer, alpha) }))
+	g.layers.Register(layerObjects, render.DrawFunc(func(alpha float32) { g.paddle2.Draw(g.renderer, alpha) }))
+	g.layers.Register(layerObjects, render.DrawFunc(g.drawFrontPaddles))
+	g.layers.Register(layerObjects, render.DrawFunc(g.drawDecals))
+	g.layers.Register(layerObjects, render.DrawFunc(g.drawPowerUps))
+	g.layers.Register(layerObjects, render.DrawFunc(func(float32) { g.drawTrajectory() }))
+	g.layers.Register(layerParticles, render.DrawFunc(func(float32) { g.particles.Draw() }))
+	g.layers.Register(layerParticles, render.DrawFunc(func(float32) { g.fireworks.Draw() }))
+	g.layers.Register(layerParticles, render.DrawFunc(func(float32) { g.confetti.Draw() }))
+	g.layers.Register(layerParticles, render.DrawFunc(func(float32) { g.swirl.Draw() }))
+	g.layers.Register(layerBall, render.DrawFunc(func(alpha float32) { g.ball.Draw(g.renderer, alpha) }))
+	g.layers.Register(layerBall, render.DrawFunc(g.drawExtraBalls))
+	g.layers.Register(layerBall, render.DrawFunc(g.drawCollisionShapes))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawUI() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawFlipWarning() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawWellCharges() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawPowerUpEffects() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawHeatMapLegend() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawCues() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawAutopilot() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawWarmUp() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawAttract() }))
+	g.layers.Register(layerUI, render.DrawFunc(func(float32) { g.drawIntro() }))
+	g.layers.Register(layerNotifications, render.DrawFunc(func(float32) { g.drawToasts() }))
+	g.layers.Register(layerDebug, render.DrawFunc(func(float32) { g.drawErrors() }))
+	g.layers.Register(layerDebug, render.DrawFunc(func(float32) { g.drawDebug() }))
+	g.layers.Register(layerDebug, render.DrawFunc(func(float32) { g.drawRewind() }))
+	g.initialized = true
+	g.applyMirror()
+}
+
+// initObjects creates the game objects, it needs no window nor OpenGL context so the simulation can run headless
+func (g *Game) initObjects() {
+	size, radius := g.rules.paddleSize, g.rules.ballRadius
 	paddle1Position := mgl.Vec2{
-		10,
-		float32(g.height/2) - paddleSize.Y()/2}
-	g.paddle1 = newGameObject(paddle1Position, paddleSize)
+		paddleMargin,
+		float32(g.height/2) - size.Y()/2}
+	g.paddle1 = newGameObject(paddle1Position, size)
 	paddle2Position := mgl.Vec2{
-		float32(g.width) - paddleSize.X() - 10,
-		float32(g.height/2) - paddleSize.Y()/2}
-	g.paddle2 = newGameObject(paddle2Position, paddleSize)
-	g.ball = newBallObject(mgl.Vec2{float32(g.width/2) - 10, float32(g.height/2) - 10}, 10, initialBallVelocity)
+		float32(g.width) - size.X() - paddleMargin,
+		float32(g.height/2) - size.Y()/2}
+	g.paddle2 = newGameObject(paddle2Position, size)
+	paddle3Position, paddle4Position := g.frontPositions()
+	g.paddle3 = newGameObject(paddle3Position, size)
+	g.paddle4 = newGameObject(paddle4Position, size)
+	g.ball = newBallObject(mgl.Vec2{float32(g.width/2) - radius, float32(g.height/2) - radius}, radius, g.rules.ballVelocity)
+	g.ball.wrap = g.rules.wrap
+}
+
+// storePositions keeps the positions of the objects before an update, to interpolate them
+func (g *Game) storePositions() {
+	g.paddle1.StorePosition()
+	g.paddle2.StorePosition()
+	g.paddle3.StorePosition()
+	g.paddle4.StorePosition()
+	g.ball.StorePosition()
+}
+
+// Step advances the game by one fixed update with the given input
+func (g *Game) Step(input Input, deltaTime float64) {
+	// The hit stops freeze the game, the rewind records every update anyway
+	events, stepTime := g.stepSimulation(input, deltaTime)
+	if g.initialized {
+		g.update(events, stepTime)
+	}
+	g.time += stepTime
+	if g.devMode && g.state == GameActive {
+		g.rewind.record(g.snapshot(), deltaTime)
+	}
+}
+
+// State returns a snapshot of the game
+func (g *Game) State() State {
+	sets1, sets2 := g.match.setsWon()
+	return State{
+		Phase:        g.state,
+		Ball:         g.ball.Circle().Center,
+		BallVelocity: g.ball.velocity,
+		Paddle1:      g.paddle1.AABB().Center(),
+		Paddle2:      g.paddle2.AABB().Center(),
+		Paddle3:      g.paddle3.AABB().Center(),
+		Paddle4:      g.paddle4.AABB().Center(),
+		PaddleSizes:  [4]mgl.Vec2{g.paddle1.size, g.paddle2.size, g.paddle3.size, g.paddle4.size},
+		BallRadius:   g.ball.radius,
+		Level:        g.rules.level,
+		Score1:       g.paddle1Score,
+		Score2:       g.paddle2Score,
+		Sets1:        sets1,
+		Sets2:        sets2,
+	}
+}
+
+// Seed returns the seed of the random numbers of the game
+func (g *Game) Seed() int64 {
+	return g.seed
+}
+
+// Random returns the random numbers of the presentation of the game, for the computer opponents
+// playing it: they don't change the ones of the simulation
+func (g *Game) Random() *rand.Rand {
+	return g.presentation
+}
+
+// SetPlayerNames names the left and the right player
+func (g *Game) SetPlayerNames(name1, name2 string) {
+	g.playerNames = [2]string{name1, name2}
 }
 
 // ProcessInput processes the input
-func (g *Game) ProcessInput(deltaTime float64) {
+func (g *Game) ProcessInput(input Input, deltaTime float64) {
 	switch g.state {
-	case gameMenu:
-		if g.keys[glfw.KeyEnter] {
+	case GameMenu:
+		if g.processAttract(input, deltaTime) {
+			return
+		}
+		if input.Start {
 			g.Reset()
-			g.state = gameActive
-			g.processedKeys[glfw.KeyEnter] = true
-		}
-	case gameWin:
-		if g.keys[glfw.KeyEnter] {
-			g.state = gameMenu
-			g.processedKeys[glfw.KeyEnter] = true
-		}
-	case gameActive:
-		deltaSpace := paddleVelocity * float32(deltaTime)
-		// Move paddle one
-		if g.keys[glfw.KeyW] {
-			if g.paddle1.position.Y() >= 0 {
-				g.paddle1.position[1] -= deltaSpace
-			}
+			g.startIntro()
+		} else if input.Tutorial {
+			g.startTutorial()
+		} else if input.WarmUp {
+			g.startWarmUp()
 		}
-		if g.keys[glfw.KeyS] {
-			if g.paddle1.position.Y() <= float32(g.height)-g.paddle1.size.Y() {
-				g.paddle1.position[1] += deltaSpace
+		if len(g.skins) > 0 && (input.Skin1Next || input.Skin2Next) {
+			if input.Skin1Next {
+				g.paddle1Skin = (g.paddle1Skin + 1) % len(g.skins)
 			}
-		}
-		// Move paddle two
-		if g.keys[glfw.KeyUp] {
-			if g.paddle2.position.Y() >= 0 {
-				g.paddle2.position[1] -= deltaSpace
+			if input.Skin2Next {
+				g.paddle2Skin = (g.paddle2Skin + 1) % len(g.skins)
 			}
+			g.applySkins()
+		}
+	case GameWin:
+		if g.processVictory(input, deltaTime) {
+			return
 		}
-		if g.keys[glfw.KeyDown] {
-			if g.paddle2.position.Y() <= float32(g.height)-g.paddle2.size.Y() {
-				g.paddle2.position[1] += deltaSpace
+		if input.Start {
+			if g.initialized {
+				g.camera.Reset()
+				g.fireworks.Stop()
+				g.confetti.Stop()
 			}
+			g.state = GameMenu
+		}
+	case GameSetBreak:
+		if input.Quit {
+			g.Reset()
+			g.state = GameMenu
+		} else if input.Start {
+			g.startNextSet()
+		}
+	case GamePaused:
+		if input.Quit {
+			g.Reset()
+			g.state = GameMenu
+		} else if input.Pause || input.Start {
+			g.rewind.resume()
+			g.state = GameActive
+		}
+	case GameActive:
+		if input.Quit {
+			g.Reset()
+			g.state = GameMenu
+			return
+		}
+		if input.Pause {
+			g.state = GamePaused
+			return
+		}
+		if input.Well1 {
+			g.placeWell(1)
+		}
+		if input.Well2 {
+			g.placeWell(2)
+		}
+		g.movePaddles(input, deltaTime)
+	case GameWarmUp:
+		if input.Quit {
+			g.Reset()
+			g.state = GameMenu
+			return
+		}
+		g.processWarmUp(input, deltaTime)
+	case GameIntro:
+		if input.Quit {
+			g.Reset()
+			g.state = GameMenu
+			return
+		}
+		g.processIntro(input)
+	case GameTutorial:
+		if input.Start || input.Quit {
+			// Finishing or skipping the tutorial
+			g.tutorialCompleted = true
+			g.Reset()
+			g.state = GameMenu
+			return
 		}
+		g.movePaddles(input, deltaTime)
 	}
 }
 
-// Update updates the game
-func (g *Game) Update(deltaTime float64) {
-	if g.state == gameActive {
-		// Update objects
-		g.ball.Move(deltaTime, g.width, g.height)
-		// Check for collisions
-		g.DoCollisions()
-		// Update particles
-		g.particles.Update(deltaTime, &g.ball.GameObject, 1, mgl.Vec2{g.ball.radius, g.ball.radius})
+// movePaddles moves the paddles following the input
+func (g *Game) movePaddles(input Input, deltaTime float64) {
+	g.movePaddle(g.paddle1, input.Paddle1Up, input.Paddle1Down, deltaTime)
+	g.movePaddle(g.paddle2, input.Paddle2Up, input.Paddle2Down, deltaTime)
+	if g.rules.doubles {
+		g.movePaddle(g.paddle3, input.Paddle3Up, input.Paddle3Down, deltaTime)
+		g.movePaddle(g.paddle4, input.Paddle4Up, input.Paddle4Down, deltaTime)
+	}
+}
+
+// movePaddle moves a paddle up or down, within the court
+func (g *Game) movePaddle(paddle *GameObject, up, down bool, deltaTime float64) {
+	paddleVelocity := g.rules.paddleVelocity
+	deltaSpace := paddleVelocity * float32(deltaTime)
+	// Keep track of the paddle velocity to give spin to the ball
+	paddle.velocity[1] = 0
+	if up {
+		if paddle.position.Y() >= 0 {
+			paddle.position[1] -= deltaSpace
+			paddle.velocity[1] = -paddleVelocity
+		}
+	}
+	if down {
+		if paddle.position.Y() <= float32(g.height)-paddle.size.Y() {
+			paddle.position[1] += deltaSpace
+			paddle.velocity[1] = paddleVelocity
+		}
+	}
+}
+
+// update presents an update of the simulation: the effects, the particles and the camera
+func (g *Game) update(events simulationEvents, deltaTime float64) {
+	// Pick up changes to the textures in development mode
+	g.resourceManager.ReloadTextures(deltaTime)
+	g.toasts.update(deltaTime)
+	if events.phase == GameActive {
+		g.updateTrail(deltaTime)
+		g.updateFlip(deltaTime)
+		g.squash(events)
+		g.cue(events)
+		g.updateDecals(events, deltaTime)
+		if events.paddleHit {
+			shakeTime = 0.1
+			g.effects.Shake = true
+			g.applySkins()
+		}
 		// Reduce shake time
 		if shakeTime > 0.0 {
 			shakeTime -= deltaTime
 			if shakeTime <= 0.0 {
-				g.effects.shake = false
+				g.effects.Shake = false
 			}
 		}
-		// Check loss condition
-		if g.ball.position.X() <= 0.0 {
-			// paddle2 scored
-			g.paddle2Score++
-			g.ball.Reset(mgl.Vec2{float32(g.width / 2), float32(g.height / 2)}, initialBallVelocity.Mul(-1))
-		} else if g.ball.position.X()+g.ball.size.X() >= float32(g.width) {
-			// paddle1 scored
-			g.paddle1Score++
-			g.ball.Reset(mgl.Vec2{float32(g.width / 2), float32(g.height / 2)}, initialBallVelocity)
-		}
-
-		if g.paddle1Score >= maxScore || g.paddle2Score >= maxScore {
-			g.state = gameWin
-		}
-	}
-}
-
-// Draw draws the game
-func (g *Game) Draw() {
-	if g.state == gameActive || g.state == gameMenu || g.state == gameWin {
-		// Begin rendering to postprocessing quad
-		g.effects.BeginRender()
-		// Draw paddles
-		g.paddle1.Draw(g.renderer)
-		g.paddle2.Draw(g.renderer)
-		// Draw particles
-		g.particles.Draw()
-		// Draw ball
-		g.ball.Draw(g.renderer)
-		// End rendering to postprocessing quad
-		g.effects.EndRender()
-		// Render postprocessing quad
-		g.effects.Render(float32(glfw.GetTime()))
-		// Render text
-		g.text.RenderText(float32(g.width/2)-50, 50, 1, mgl.Vec3{1.0, 1.0, 1.0}, "%v : %v", g.paddle1Score, g.paddle2Score)
-	}
-	if g.state == gameMenu || g.state == gameWin {
-		g.text.RenderText(290, float32(g.height/2)-20, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "Press ENTER to start")
-	}
-	if g.state == gameWin {
-		var winText string
-		if g.paddle1Score > g.paddle2Score {
-			winText = "Player 1 Won!"
+		if events.scored != 0 {
+			g.camera.ZoomPunch(cameraGoalPunch, 0.3)
+			g.popScore(events.goals)
+			g.applySkins()
+		}
+		// Subtly follow the ball
+		center := mgl.Vec2{float32(g.width / 2), float32(g.height / 2)}
+		ballCenter := g.ball.position.Add(mgl.Vec2{g.ball.radius, g.ball.radius})
+		g.camera.LookAt(center.Add(ballCenter.Sub(center).Mul(cameraFollow)))
+
+		if events.won {
+			// Zoom in on the winner
+			winner := g.paddle1
+			if g.paddle2Score > g.paddle1Score {
+				winner = g.paddle2
+			}
+			g.camera.LookAt(center.Add(winner.position.Add(winner.size.Mul(0.5)).Sub(center).Mul(0.5)))
+			g.camera.SetZoom(cameraWinZoom)
+			// Celebrate
+			g.fireworks.Start()
+			g.confetti.Start()
+			g.updateHeatMap()
+		}
+	} else if events.phase == GameWin {
+		g.fireworks.Update(deltaTime)
+		g.confetti.Update(deltaTime)
+	} else if events.phase == GameMenu && g.Attracting() {
+		g.updateTrail(deltaTime)
+		g.squash(events)
+		g.updateDecals(events, deltaTime)
+		if events.paddleHit {
+			g.applySkins()
+		} else if events.scored != 0 {
+			g.popScore(events.goals)
+			g.applySkins()
+		}
+	} else if events.phase == GameIntro {
+		g.directIntro()
+	} else if events.phase == GameWarmUp || events.phase == GameTutorial {
+		g.updateTrail(deltaTime)
+		g.squash(events)
+		g.cue(events)
+		g.updateDecals(events, deltaTime)
+		if events.paddleHit {
+			g.applySkins()
+		}
+	} else if events.phase == GamePaused {
+		// Hold the effects still until the match resumes
+		g.effects.Shake = false
+	}
+	g.updateTransition(deltaTime)
+	g.updateFog(deltaTime)
+	g.swirl.Update(deltaTime, g.wellCenters())
+	g.tweens.Update(deltaTime)
+	// Update camera
+	g.camera.Update(deltaTime)
+}
+
+// updateTrail spawns the particles following the ball
+func (g *Game) updateTrail(deltaTime float64) {
+	g.trailBudget += g.quality.trailRate * deltaTime
+	trailParticles := int(g.trailBudget)
+	g.trailBudget -= float64(trailParticles)
+	g.particles.Update(deltaTime, g.ball, trailParticles, mgl.Vec2{g.ball.radius, g.ball.radius})
+}
+
+// simulationEvents reports what happened during a simulation update
+type simulationEvents struct {
+	phase     GameState // State of the game the update simulated
+	paddleHit bool      // The ball bounced on a paddle
+	wallHit   bool      // The ball bounced on the top or the bottom of the court
+	hitBy     int       // Player whose paddle the ball bounced on, the team in doubles
+	hitPaddle *GameObject
+	scored    int    // Player who scored, zero when nobody did
+	goals     [2]int // Goals of each player, more than one with the extra balls of the multi-ball
+	setWon    bool   // A set ended with more to play
+	won       bool   // The match ended
+}
+
+// simulate moves the ball, checks the collisions and keeps the score without touching the
+// presentation (camera, particles, effects), so it can run headless
+func (g *Game) simulate(deltaTime float64) simulationEvents {
+	var events simulationEvents
+	// A frozen game moves nothing, the ball would bounce again off the paddle it touches
+	if deltaTime == 0 {
+		return events
+	}
+	// Update objects
+	g.updateWells(deltaTime)
+	g.updatePowerUps(deltaTime)
+	g.pullBall(deltaTime)
+	incoming := g.ball.velocity
+	// A ball held by a sticky paddle follows it instead
+	held := g.holdBall(deltaTime)
+	if !held {
+		g.ball.Move(deltaTime, g.width, g.height)
+	}
+	events.wallHit = g.ball.velocity.Y() != incoming.Y()
+	events.wallHit = g.bounceLevel(g.ball) || events.wallHit
+	// Check for collisions
+	incoming = g.ball.velocity
+	if !held {
+		events.hitBy, events.hitPaddle = g.DoCollisions()
+	}
+	events.paddleHit = events.hitBy != 0
+	g.match.duration += deltaTime
+	if events.paddleHit {
+		g.match.rallies[events.hitBy-1]++
+		g.match.heat.returns[events.hitBy-1][g.match.heat.bin(g.ball.Circle().Center.Y())]++
+		g.startHitStop(events.hitPaddle, incoming)
+		g.stickBall(events.hitBy, events.hitPaddle)
+		g.collectPowerUps(g.ball, events.hitBy)
+	} else {
+		g.collectPowerUps(g.ball, g.lastHit)
+	}
+	// Check loss condition, outside of the goals the ends of the court are walls
+	events.wallHit = g.bounceEnds(g.ball) || events.wallHit
+	switch events.scored = g.scoreGoal(g.ball, &events); events.scored {
+	case 1:
+		g.ball.Reset(g.ballSpawn(), g.rules.ballVelocity)
+	case 2:
+		g.ball.Reset(g.ballSpawn(), g.rules.ballVelocity.Mul(-1))
+	}
+	g.moveExtraBalls(deltaTime, &events)
+	if g.paddle1Score >= maxScore || g.paddle2Score >= maxScore {
+		if g.match.finishSet(g.paddle1Score, g.paddle2Score) {
+			g.state = GameWin
+			g.startVictory()
+			events.won = true
 		} else {
-			winText = "Player 2 Won!"
+			g.state = GameSetBreak
+			events.setWon = true
 		}
-		g.text.RenderText(330, float32(g.height/2)-50, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, winText)
 	}
+	return events
 }
 
-// DoCollisions checks if gameobjects collided
-func (g *Game) DoCollisions() {
-	if g.ball.CheckCollision(g.paddle1) || g.ball.CheckCollision(g.paddle2) {
-		shakeTime = 0.1
-		g.effects.shake = true
-		g.ball.velocity[0] = -g.ball.velocity.X()
+// scoreGoal gives the point to the player the ball left the court towards the goal of, it
+// returns the player who scored or zero
+func (g *Game) scoreGoal(ball *BallObject, events *simulationEvents) int {
+	scored := 0
+	crossed := g.match.heat.bin(ball.Circle().Center.Y())
+	if ball.position.X() <= 0.0 {
+		// paddle2 scored
+		g.paddle2Score++
+		g.match.heat.goals[0][crossed]++
+		scored = 2
+	} else if ball.position.X()+ball.size.X() >= float32(g.width) {
+		// paddle1 scored
+		g.paddle1Score++
+		g.match.heat.goals[1][crossed]++
+		scored = 1
+	}
+	if scored != 0 {
+		g.match.point(scored)
+		events.goals[scored-1]++
+	}
+	return scored
+}
+
+// Draw draws the game into the window composing the registered layers in order,
+// alpha is how far the frame is between the previous and the current fixed update
+func (g *Game) Draw(alpha float32) {
+	g.draw(alpha, nil)
+}
+
+// Render draws the latest state of the game filling the target, it loads the rendering
+// resources on first use so it needs a current OpenGL context
+func (g *Game) Render(target *render.RenderTarget) {
+	if !g.initialized {
+		g.Init()
+	}
+	width, height := target.Size()
+	if render.NewViewport(int(width), int(height), g.width, g.height) != g.viewport {
+		g.Resize(int(width), int(height))
+	}
+	g.draw(1, target)
+}
+
+// draw composes the layers into the target, or into the window when the target is nil
+func (g *Game) draw(alpha float32, target *render.RenderTarget) {
+	// The court and the borders around it are cleared to the background of the theme
+	background := g.theme.Background
+	gl.ClearColor(background.X(), background.Y(), background.Z(), 1.0)
+	// Render the world through the camera
+	g.camera.Apply(g.resourceManager.GetShader("sprite"), g.resourceManager.GetShader("particle"), g.resourceManager.GetShader("text"))
+	// Begin rendering to postprocessing quad
+	g.effects.BeginRender()
+	// Draw the layers affected by postprocessing effects
+	g.layers.DrawRange(layerBackground, layerBall, alpha)
+	// End rendering to postprocessing quad
+	g.effects.EndRender()
+	if target != nil {
+		target.Bind()
+	}
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	// Scale the virtual resolution to the window
+	g.viewport.Apply()
+	// Render postprocessing quad
+	g.updateLight(alpha)
+	g.applyFog()
+	g.applyTwists()
+	g.effects.Render(float32(g.time))
+	// Draw the layers on top of the postprocessed scene, UI is not affected by the camera
+	g.resourceManager.GetShader("sprite").Use().SetMatrix4("view", mgl.Ident4(), false)
+	g.resourceManager.GetShader("text").Use().SetMatrix4("view", mgl.Ident4(), false)
+	g.layers.DrawRange(layerUI, layerDebug, alpha)
+	if target != nil {
+		target.Resolve()
+	}
+}
+
+// drawUI renders the score and the menu texts
+func (g *Game) drawUI() {
+	if g.editing {
+		return
+	}
+	if g.compact {
+		g.drawCompactUI()
+		return
+	}
+	g.drawScore()
+	if g.match.bestOf > 1 && g.state != GameMenu {
+		sets1, sets2 := g.match.setsWon()
+		if g.mirrored {
+			sets1, sets2 = sets2, sets1
+		}
+		g.text.RenderText(float32(g.width/2)-70, 220, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "Sets %v : %v", sets1, sets2)
+	}
+	if g.state == GameMenu {
+		g.text.Alpha = g.menu.alpha
+	}
+	if g.state == GameMenu || g.state == GameWin {
+		g.text.RenderText(float32(g.width/2)-220, float32(g.height/2)-40, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "Press ENTER to start")
+	}
+	if g.state == GameMenu {
+		for i, line := range menuHelp {
+			g.drawCentered(float32(g.height/2)+20+float32(i)*30, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, line)
+		}
+		if g.mutators != 0 {
+			g.drawCentered(float32(g.height/2)+140, 0.3, mgl.Vec3{1.0, 0.8, 0.1}, "Mutators: "+g.mutators.String())
+		}
+		if g.level != nil {
+			g.drawCentered(float32(g.height/2)+180, 0.3, mgl.Vec3{1.0, 0.8, 0.1}, "Level: "+g.level.Name)
+		}
+	}
+	if g.state == GameTutorial {
+		g.drawTutorial()
+	}
+	if g.state == GameMenu {
+		// The players are listed on the side of the screen they play on
+		// The ratings are of the players alone
+		rated := g.ratings[0] > 0 && g.ratings[1] > 0 && !g.doubles
+		chance := ExpectedScore(g.ratings[0], g.ratings[1])
+		chances := [2]float64{chance, 1 - chance}
+		skins := [2]int{g.paddle1Skin, g.paddle2Skin}
+		skinKeys := [2]string{"D", "RIGHT"}
+		for side, player := range g.screenPlayers() {
+			x := 60 - g.menu.offset
+			if side == 1 {
+				x = float32(g.width) - 460 + g.menu.offset
+			}
+			g.text.RenderText(x, float32(g.height)-160, 0.45, mgl.Vec3{1.0, 1.0, 1.0}, "%v", g.playerNames[player-1])
+			if g.doubles {
+				g.text.RenderText(x, float32(g.height)-250, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "with Player %v at the front", player+2)
+			}
+			if rated {
+				g.text.RenderText(x, float32(g.height)-210, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "Rating %.0f - %.0f%% to win", g.ratings[player-1], chances[player-1]*100)
+			}
+			if len(g.skins) > 1 {
+				skin := g.skins[skins[player-1]]
+				g.text.RenderText(x, float32(g.height)-100, 0.35, skin.Color, "Skin: %v (%v)", skin.Name, skinKeys[side])
+			}
+		}
+	}
+	g.text.Alpha = 1
+	if g.state == GamePaused {
+		g.text.RenderText(float32(g.width/2)-110, float32(g.height/2)-100, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "PAUSED")
+		g.text.RenderText(float32(g.width/2)-260, float32(g.height/2)-40, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "Press P or ENTER to resume")
+	}
+	if g.state == GameWin {
+		g.text.Alpha = g.sequences.alpha
+		g.text.RenderText(float32(g.width/2)-140, float32(g.height/2)-100-g.sequences.slide, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "%v Won!", g.teamName(g.winner()))
+		g.text.Alpha = 1
+	}
+	if g.state == GameSetBreak {
+		winner := 1
+		if g.paddle2Score > g.paddle1Score {
+			winner = 2
+		}
+		g.text.RenderText(float32(g.width/2)-200, float32(g.height/2)-100, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "%v takes set %v", g.teamName(winner), len(g.match.sets))
+		g.text.RenderText(float32(g.width/2)-280, float32(g.height/2)-40, 0.5, mgl.Vec3{1.0, 1.0, 1.0}, "Press ENTER for the next set")
+	}
+	if g.match.bestOf > 1 && (g.state == GameSetBreak || g.state == GameWin) {
+		g.drawScoreboard()
+	}
+}
+
+// drawErrors renders a panel listing the errors met while loading the resources
+func (g *Game) drawErrors() {
+	errors := g.resourceManager.Errors()
+	if len(errors) == 0 {
+		return
+	}
+	var lines []string
+	for _, err := range errors {
+		lines = append(lines, strings.Split(err, "\n")...)
+	}
+	g.renderer.Draw(mgl.Vec2{20, 20}, mgl.Vec2{float32(g.width) - 40, float32(len(lines)+1) * 30}, 0, mgl.Vec3{0.4, 0.0, 0.0})
+	for i, line := range lines {
+		g.text.RenderText(40, float32(i+1)*30+20, 0.25, mgl.Vec3{1.0, 1.0, 1.0}, "%v", line)
+	}
+}
+
+// DoCollisions checks if gameobjects collided, bouncing the ball on the paddles,
+// it returns the player, or the team in doubles, whose paddle was hit or zero, and the paddle
+func (g *Game) DoCollisions() (int, *GameObject) {
+	return g.bounceOffPaddles(g.ball)
+}
+
+// bounceOffPaddles bounces a ball on the paddles it touches, it returns the player, or the team
+// in doubles, whose paddle was hit or zero, and the paddle
+func (g *Game) bounceOffPaddles(ball *BallObject) (int, *GameObject) {
+	paddles, teams := g.paddles()
+	for i, paddle := range paddles {
+		if g.passesThrough(ball, teams[i]) {
+			continue
+		}
+		if contact, ok := physics.CircleAABB(ball.Circle(), paddle.AABB()); ok {
+			g.recordContact(ball.Circle(), contact)
+			// Push the ball out of the paddle, then bounce it taking some of the paddle movement
+			ball.position = ball.position.Add(contact.Normal.Mul(contact.Penetration))
+			velocity := physics.Reflect(ball.velocity, contact.Normal)
+			velocity = physics.Spin(velocity, contact.Normal, paddle.velocity, paddleSpin)
+			ball.velocity = physics.LimitAngle(velocity, contact.Normal, ballMaxAngle)
+			return teams[i], paddle
+		}
+	}
+	return 0, nil
+}
+
+// applySkins gives the paddles the skins picked by the players and the ball the skin of the last hitter
+func (g *Game) applySkins() {
+	if len(g.skins) == 0 {
+		return
+	}
+	g.paddle1.ApplySkin(g.skins[g.paddle1Skin])
+	g.paddle2.ApplySkin(g.skins[g.paddle2Skin])
+	g.paddle3.ApplySkin(g.skins[g.paddle1Skin])
+	g.paddle4.ApplySkin(g.skins[g.paddle2Skin])
+	ballSkin := g.skins[0]
+	switch g.lastHit {
+	case 1:
+		ballSkin = g.skins[g.paddle1Skin]
+	case 2:
+		ballSkin = g.skins[g.paddle2Skin]
+	}
+	g.ball.ApplySkin(ballSkin)
+	g.particles.Trail = ballSkin.Trail
+	if preset, ok := g.content.Trails[g.trail]; ok {
+		g.particles.Trail = &preset
+	}
+	if g.playerTrails && g.lastHit != 0 {
+		trail := defaultSkinTrail
+		if g.particles.Trail != nil {
+			trail = *g.particles.Trail
+		}
+		trail.Color = playerTrailColors[g.lastHit-1]
+		g.particles.Trail = &trail
+	}
+}
+
+// initEffects creates the particle generators and the postprocessor as set by the quality
+func (g *Game) initEffects(width, height int32) {
+	g.particles = particles.NewParticleGenerator(g.resourceManager.GetShader("particle"), g.quality.particleAmount(50), g.presentation)
+	g.fireworks = particles.NewFireworks(g.resourceManager.GetShader("particle"), g.quality.particleAmount(600), float32(g.width), float32(g.height), g.presentation)
+	g.confetti = particles.NewConfetti(g.resourceManager.GetShader("particle"), g.quality.particleAmount(1000), float32(g.width), float32(g.height), g.presentation)
+	g.swirl = particles.NewSwirl(g.resourceManager.GetShader("particle"), g.quality.particleAmount(200), g.presentation)
+	g.effects = render.NewPostProcessor(g.resourceManager.GetShader("postprocessing"), width, height, g.quality.samples)
+	g.effects.Bloom = g.quality.bloom
+}
+
+// SetQuality changes the effects settings, recreating the effects of an initialized game:
+// the particles alive are lost and the celebrations of a win are restarted
+func (g *Game) SetQuality(quality QualitySettings) {
+	g.quality = quality
+	if !g.initialized {
+		return
+	}
+	g.particles.Delete()
+	g.fireworks.Delete()
+	g.confetti.Delete()
+	g.swirl.Delete()
+	g.effects.Delete()
+	width, height := g.viewport.Width, g.viewport.Height
+	if width == 0 {
+		// Not resized yet
+		width, height = int32(g.width), int32(g.height)
+	}
+	g.initEffects(width, height)
+	g.applySkins()
+	if g.state == GameWin {
+		g.fireworks.Start()
+		g.confetti.Start()
+	}
+}
+
+// Resize fits the game virtual resolution into the given framebuffer size
+func (g *Game) Resize(framebufferWidth, framebufferHeight int) {
+	g.viewport = render.NewViewport(framebufferWidth, framebufferHeight, g.width, g.height)
+	// Render the scene at the displayed size
+	if g.initialized {
+		g.effects.Resize(g.viewport.Width, g.viewport.Height)
+	}
+}
+
+// Close releases the resources held by the game
+func (g *Game) Close() {
+	if !g.initialized {
+		return
+	}
+	g.renderer.Delete()
+	g.particles.Delete()
+	g.fireworks.Delete()
+	g.confetti.Delete()
+	g.swirl.Delete()
+	g.effects.Delete()
+	g.text.Delete()
+	for _, texture := range g.heatTextures {
+		texture.Delete()
+	}
+	releaseSkins(g.skins, g.resourceManager)
+	g.resourceManager.ReleaseFont("roboto")
+	g.resourceManager.ReportLeaks()
+	g.resourceManager.Clear()
+	if g.devMode {
+		render.Objects.ReportLeaks()
 	}
 }
 
 // Reset resets the game to initial conditions
 func (g *Game) Reset() {
-	g.paddle1Score = 0
-	g.paddle2Score = 0
-	g.paddle1.Reset(mgl.Vec2{10, float32(g.height/2) - paddleSize.Y()/2})
-	g.paddle2.Reset(mgl.Vec2{float32(g.width) - paddleSize.X() - 10, float32(g.height/2) - paddleSize.Y()/2})
-	g.ball.Reset(mgl.Vec2{float32(g.width / 2), float32(g.height / 2)}, initialBallVelocity)
+	g.reset(g.matchRules())
+}
+
+// reset resets the game to initial conditions playing with the rules
+func (g *Game) reset(r rules) {
+	g.applyRules(r)
+	g.resetObjects()
+	g.match.reset()
+	g.lastHit = 0
+	g.hitStop = 0
+	g.sequences = sequences{}
+	g.rewind.clear()
+	if !g.initialized {
+		return
+	}
+	g.applySkins()
+	g.camera.Reset()
+	g.SetCourtFlip(g.flip.enabled)
+	g.particles.Reset()
+	g.decals.marks = g.decals.marks[:0]
+	g.fireworks.Stop()
+	g.confetti.Stop()
+	g.swirl.Stop()
+}
+
+// resetObjects resets the scores to the handicaps and the game objects
+func (g *Game) resetObjects() {
+	g.paddle1Score = g.handicaps[0]
+	g.paddle2Score = g.handicaps[1]
+	g.wells = [2]gravityWell{}
+	g.resetPowerUps()
+	spawns := g.paddleSpawns()
+	g.paddle1.Reset(spawns[0])
+	g.paddle2.Reset(spawns[1])
+	paddle3Position, paddle4Position := g.frontPositions()
+	g.paddle3.Reset(paddle3Position)
+	g.paddle4.Reset(paddle4Position)
+	g.ball.Reset(g.ballSpawn(), g.rules.ballVelocity)
 }
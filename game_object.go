@@ -1,81 +1,132 @@
-package main
+package pong
 
-import mgl "github.com/go-gl/mathgl/mgl32"
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/physics"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
 
 // GameObject holds the structure of a object in the game with a position and a size
 type GameObject struct {
-	position mgl.Vec2
-	size     mgl.Vec2
-	velocity mgl.Vec2
-	color    mgl.Vec3
-	rotation float32
+	position         mgl.Vec2
+	previousPosition mgl.Vec2 // Position at the previous fixed update, to interpolate the rendering
+	size             mgl.Vec2
+	velocity         mgl.Vec2
+	color            mgl.Vec3
+	texture          *render.Texture2D // Drawn tinted by the color, a plain quad when nil
+	rotation         float32
+	scale            mgl.Vec2 // Deformation of the look, the size is drawn scaled by it around the center
 }
 
 func newGameObject(position, size mgl.Vec2) *GameObject {
 	return &GameObject{
-		position: position,
-		size:     size,
-		velocity: mgl.Vec2{0, 0},
-		rotation: 0,
-		color:    mgl.Vec3{1, 1, 1}}
+		position:         position,
+		previousPosition: position,
+		size:             size,
+		velocity:         mgl.Vec2{0, 0},
+		rotation:         0,
+		scale:            mgl.Vec2{1, 1},
+		color:            mgl.Vec3{1, 1, 1}}
 }
 
-// Draw renders a GameObject using the provided renderer
-func (o *GameObject) Draw(renderer *SpriteRenderer) {
-	renderer.Draw(o.position, o.size, o.rotation, o.color)
+// Draw renders a GameObject using the provided renderer, interpolating between the previous and current positions
+func (o *GameObject) Draw(renderer *render.SpriteRenderer, alpha float32) {
+	// Deform around the center
+	size := mgl.Vec2{o.size.X() * o.scale.X(), o.size.Y() * o.scale.Y()}
+	position := o.RenderPosition(alpha).Add(o.size.Sub(size).Mul(0.5))
+	if o.texture != nil {
+		renderer.DrawTexture(o.texture, position, size, o.rotation, o.color)
+		return
+	}
+	renderer.Draw(position, size, o.rotation, o.color)
+}
+
+// ApplySkin gives the GameObject the look of the skin
+func (o *GameObject) ApplySkin(skin Skin) {
+	o.color = skin.Color
+	o.texture = skin.Texture
+}
+
+// StorePosition remembers the current position as the previous one, call it before each fixed update
+func (o *GameObject) StorePosition() {
+	o.previousPosition = o.position
+}
+
+// RenderPosition returns the position between the previous (alpha 0) and the current one (alpha 1)
+func (o *GameObject) RenderPosition(alpha float32) mgl.Vec2 {
+	return o.previousPosition.Mul(1 - alpha).Add(o.position.Mul(alpha))
+}
+
+// Position returns the top-left corner of the GameObject
+func (o *GameObject) Position() mgl.Vec2 {
+	return o.position
+}
+
+// Velocity returns the velocity of the GameObject
+func (o *GameObject) Velocity() mgl.Vec2 {
+	return o.velocity
 }
 
 // Reset resets a GameObject
 func (o *GameObject) Reset(position mgl.Vec2) {
 	o.position = position
+	// Don't interpolate a teleport
+	o.previousPosition = position
+}
+
+// AABB returns the bounding box of the GameObject
+func (o *GameObject) AABB() physics.AABB {
+	return physics.AABB{Position: o.position, Size: o.size}
 }
 
 // CheckCollision checks collisions between two game objects using o - AABB
 func (o *GameObject) CheckCollision(other *GameObject) bool {
-	// Collision x-axis?
-	collisionX := o.position.X()+o.size.X() >= other.position.X() &&
-		other.position.X()+other.size.X() >= o.position.X()
-	// Collision y-axis?
-	collisionY := o.position.Y()+o.size.Y() >= other.position.Y() &&
-		other.position.Y()+other.size.Y() >= o.position.Y()
-	// Collision only if on both axes
-	return collisionX && collisionY
+	return o.AABB().Overlaps(other.AABB())
 }
 
 // BallObject is a special game object to handle the ball
 type BallObject struct {
 	GameObject
 	radius float32
+	wrap   bool // Goes through the top and the bottom of the window instead of bouncing
 }
 
 func newBallObject(position mgl.Vec2, radius float32, velocity mgl.Vec2) *BallObject {
 	return &BallObject{
 		radius: radius,
 		GameObject: GameObject{
-			position: position,
-			size:     mgl.Vec2{radius * 2, radius * 2},
-			velocity: velocity,
-			rotation: 0,
-			color:    mgl.Vec3{1, 1, 1}}}
+			position:         position,
+			previousPosition: position,
+			size:             mgl.Vec2{radius * 2, radius * 2},
+			velocity:         velocity,
+			rotation:         0,
+			scale:            mgl.Vec2{1, 1},
+			color:            mgl.Vec3{1, 1, 1}}}
 }
 
-// Move moves the ball
+// Circle returns the bounding circle of the ball
+func (b *BallObject) Circle() physics.Circle {
+	return physics.Circle{Center: b.position.Add(mgl.Vec2{b.radius, b.radius}), Radius: b.radius}
+}
+
+// Move moves the ball, bouncing it on the top and bottom of the window or wrapping it around
 func (b *BallObject) Move(deltaTime float64, windowWidth, windowHeight int) mgl.Vec2 {
-	b.position = b.position.Add(b.velocity.Mul(float32(deltaTime)))
-	// Check if outside window bounds; if so, reverse velocity and restore at correct position
-	if b.position.Y() <= 0.0 {
-		b.velocity[1] = -b.velocity.Y()
-		b.position[1] = 0.0
-	} else if b.position.Y()+b.size.Y() >= float32(windowHeight) {
-		b.velocity[1] = -b.velocity.Y()
-		b.position[1] = float32(windowHeight) - b.size.Y()
+	if !b.wrap {
+		b.position, b.velocity = physics.Move(b.AABB(), b.velocity, deltaTime, float32(windowHeight))
+		return b.position
+	}
+	var wrapped bool
+	b.position, wrapped = physics.Wrap(b.AABB(), b.velocity, deltaTime, float32(windowHeight))
+	if wrapped {
+		// Don't draw the ball sweeping across the court
+		b.previousPosition = b.position
 	}
-
 	return b.position
 }
 
 // Reset resets the ball
 func (b *BallObject) Reset(position, velocity mgl.Vec2) {
 	b.position = position
+	b.previousPosition = position
 	b.velocity = velocity
 }
@@ -0,0 +1,131 @@
+package pong
+
+import (
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+var (
+	wellDuration = 3.0           // Seconds a gravity well stays on the court
+	wellCooldown = 10.0          // Seconds from placing a well to the next one, per player
+	wellRadius   = float32(360)  // Reach of the pull of a well
+	wellPull     = float32(3000) // Acceleration of the ball at the center of a well, fading to none at its reach
+	wellTwist    = float32(2.5)  // Twist of the court at the center of a well, in radians
+	wellFade     = 0.3           // Seconds the twist of a well takes to come and go
+	wellColor    = mgl.Vec3{0.6, 0.3, 1.0}
+)
+
+// gravityWell is an attractor a player places on the half of the opponent, bending the ball
+// towards it while it lasts
+type gravityWell struct {
+	position mgl.Vec2
+	time     float64 // Seconds left on the court, the well is gone at zero
+	cooldown float64 // Seconds before the player can place the next one
+}
+
+// active tells if the well is on the court
+func (w gravityWell) active() bool {
+	return w.time > 0
+}
+
+// placeWell puts the well of the player in the middle of the half of the opponent, at the height
+// of the paddle of the player, if the mutator is on and the last one cooled down
+func (g *Game) placeWell(player int) {
+	w := &g.wells[player-1]
+	if !g.rules.wells || w.cooldown > 0 {
+		return
+	}
+	paddle, x := g.paddle1, float32(g.width)*3/4
+	if player == 2 {
+		paddle, x = g.paddle2, float32(g.width)/4
+	}
+	w.position = mgl.Vec2{x, paddle.position.Y() + paddle.size.Y()/2}
+	w.time = wellDuration
+	w.cooldown = wellCooldown
+}
+
+// pullBall bends the path of the ball towards the wells on the court, keeping its speed and
+// never turning it back
+func (g *Game) pullBall(deltaTime float64) {
+	speed := g.ball.velocity.Len()
+	if speed == 0 {
+		return
+	}
+	center := g.ball.Circle().Center
+	velocity := g.ball.velocity
+	for _, w := range g.wells {
+		offset := w.position.Sub(center)
+		if distance := offset.Len(); w.active() && distance > 0 && distance < wellRadius {
+			velocity = velocity.Add(offset.Mul(wellPull * (1 - distance/wellRadius) * float32(deltaTime) / distance))
+		}
+	}
+	axis := mgl.Vec2{1, 0}
+	if g.ball.velocity.X() < 0 {
+		axis = mgl.Vec2{-1, 0}
+	}
+	g.ball.velocity = physics.LimitAngle(velocity.Normalize().Mul(speed), axis, ballMaxAngle)
+}
+
+// updateWells counts down the time left of the wells and their cooldowns
+func (g *Game) updateWells(deltaTime float64) {
+	for i := range g.wells {
+		w := &g.wells[i]
+		w.time = math.Max(w.time-deltaTime, 0)
+		w.cooldown = math.Max(w.cooldown-deltaTime, 0)
+	}
+}
+
+// wellCenters returns the positions of the wells on the court
+func (g *Game) wellCenters() []mgl.Vec2 {
+	var centers []mgl.Vec2
+	for _, w := range g.wells {
+		if w.active() {
+			centers = append(centers, w.position)
+		}
+	}
+	return centers
+}
+
+// applyTwists twists the court around the wells through the camera, easing in and out
+func (g *Game) applyTwists() {
+	for i, w := range g.wells {
+		g.effects.Twists[i].Angle = 0
+		if !w.active() {
+			continue
+		}
+		position := g.camera.View().Mul4x1(mgl.Vec4{w.position.X(), w.position.Y(), 0, 1})
+		strength := math.Min(math.Min(w.time, wellDuration-w.time)/wellFade, 1)
+		g.effects.Twists[i].Position = mgl.Vec2{position.X() / float32(g.width), 1 - position.Y()/float32(g.height)}
+		g.effects.Twists[i].Radius = wellRadius / float32(g.height)
+		g.effects.Twists[i].Angle = wellTwist * float32(strength)
+	}
+}
+
+// drawWells renders the reach of the wells on the court
+func (g *Game) drawWells() {
+	for _, w := range g.wells {
+		if w.active() {
+			g.renderer.DrawCircleOutline(w.position, wellRadius, 4, wellColor.Mul(0.5))
+		}
+	}
+}
+
+// drawWellCharges shows when the players can place their next well, under their scores
+func (g *Game) drawWellCharges() {
+	if !g.rules.wells || (g.state != GameActive && g.state != GamePaused) {
+		return
+	}
+	for side, player := range g.screenPlayers() {
+		x := float32(g.width/2) - 300
+		if side == 1 {
+			x = float32(g.width/2) + 180
+		}
+		if cooldown := g.wells[player-1].cooldown; cooldown > 0 {
+			g.text.RenderText(x, 260, 0.25, mgl.Vec3{0.6, 0.6, 0.6}, "Well in %.0fs", math.Ceil(cooldown))
+		} else {
+			g.text.RenderText(x, 260, 0.25, wellColor, "Well ready")
+		}
+	}
+}
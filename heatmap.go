@@ -0,0 +1,142 @@
+package pong
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+var (
+	heatMapBins    = 24 // Bands the court height is split in
+	heatMapColumns = 16 // Pixels of the texture across a band, fading away from the goal line
+	heatMapWidth   = float32(240)
+	heatMapOpacity = float32(0.8) // Opacity of the band hit the most
+)
+
+// heatMap counts where the ball crossed the goal line of each player and where each player
+// returned it, by band of the court height
+type heatMap struct {
+	goals   [2][]int
+	returns [2][]int
+}
+
+// newHeatMap returns a heat map with no contacts
+func newHeatMap() heatMap {
+	var h heatMap
+	for player := range h.goals {
+		h.goals[player] = make([]int, heatMapBins)
+		h.returns[player] = make([]int, heatMapBins)
+	}
+	return h
+}
+
+// clone returns a copy of the heat map not sharing the counts
+func (h heatMap) clone() heatMap {
+	for player := range h.goals {
+		h.goals[player] = append([]int(nil), h.goals[player]...)
+		h.returns[player] = append([]int(nil), h.returns[player]...)
+	}
+	return h
+}
+
+// bin returns the band of the court height the position is in
+func (h heatMap) bin(y float32) int {
+	bin := int(y / VirtualHeight * float32(heatMapBins))
+	if bin < 0 {
+		return 0
+	} else if bin >= heatMapBins {
+		return heatMapBins - 1
+	}
+	return bin
+}
+
+// pixels returns the heat map of the player as an RGBA image, one row per band from the top fading
+// away from the goal line of the player: red for the goals conceded, green for the returns, both
+// relative to the band hit the most by anyone
+func (h heatMap) pixels(player int) []byte {
+	most := 1
+	for p := range h.goals {
+		for bin := 0; bin < heatMapBins; bin++ {
+			if h.goals[p][bin] > most {
+				most = h.goals[p][bin]
+			}
+			if h.returns[p][bin] > most {
+				most = h.returns[p][bin]
+			}
+		}
+	}
+	data := make([]byte, 0, heatMapBins*heatMapColumns*4)
+	for bin := 0; bin < heatMapBins; bin++ {
+		goals := float32(h.goals[player-1][bin]) / float32(most)
+		returns := float32(h.returns[player-1][bin]) / float32(most)
+		color := mgl.Vec3{1.0, 0.2, 0.1}.Mul(goals).Add(mgl.Vec3{0.2, 1.0, 0.3}.Mul(returns))
+		opacity := goals
+		if returns > opacity {
+			opacity = returns
+		}
+		for column := 0; column < heatMapColumns; column++ {
+			fade := 1 - float32(column)/float32(heatMapColumns)
+			if player == 2 {
+				fade = float32(column+1) / float32(heatMapColumns)
+			}
+			data = append(data, toByte(color.X()), toByte(color.Y()), toByte(color.Z()), toByte(opacity*fade*heatMapOpacity))
+		}
+	}
+	return data
+}
+
+// toByte converts a color channel from 0 to 1 to a byte, clamping it
+func toByte(v float32) byte {
+	if v > 1 {
+		v = 1
+	}
+	return byte(v * 255)
+}
+
+// ToggleHeatMap shows or hides the heat map of the match over the court, it's only shown once the match is won
+func (g *Game) ToggleHeatMap() {
+	g.heatMapShown = !g.heatMapShown
+	g.updateHeatMap()
+}
+
+// updateHeatMap fills the textures with the heat map of the match, while it's shown
+func (g *Game) updateHeatMap() {
+	if !g.heatMapShown || !g.initialized {
+		return
+	}
+	for player, texture := range g.heatTextures {
+		texture.Generate(int32(heatMapColumns), int32(heatMapBins), g.match.heat.pixels(player+1))
+	}
+}
+
+// initHeatMap creates the textures of the heat map of each player
+func (g *Game) initHeatMap() {
+	for player := range g.heatTextures {
+		texture := render.NewTexture2D()
+		texture.SetFormat(gl.RGBA, gl.RGBA)
+		texture.SetWrap(gl.CLAMP_TO_EDGE, gl.CLAMP_TO_EDGE)
+		g.heatTextures[player] = texture
+	}
+}
+
+// drawHeatMap renders the heat map of each player as a band fading away from their goal line
+func (g *Game) drawHeatMap() {
+	if !g.heatMapShown || g.state != GameWin {
+		return
+	}
+	size := mgl.Vec2{heatMapWidth, float32(g.height)}
+	g.renderer.DrawTexture(g.heatTextures[0], mgl.Vec2{0, 0}, size, 0, mgl.Vec3{1.0, 1.0, 1.0})
+	g.renderer.DrawTexture(g.heatTextures[1], mgl.Vec2{float32(g.width) - heatMapWidth, 0}, size, 0, mgl.Vec3{1.0, 1.0, 1.0})
+}
+
+// drawHeatMapLegend explains the colors of the heat map
+func (g *Game) drawHeatMapLegend() {
+	if g.state != GameWin {
+		return
+	}
+	hint := "H heat map"
+	if g.heatMapShown {
+		hint = "Red: goals conceded - Green: returns - H hide"
+	}
+	g.drawCentered(float32(g.height)-80, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, hint)
+}
@@ -0,0 +1,29 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	hitStopTicks   = 2 // Updates the game freezes for when a moving paddle hits the ball
+	hitStopCounter = 4 // Updates it freezes for when the paddle moves against the ball, smashing it back
+)
+
+// startHitStop freezes the game for a few updates when the paddle hit the ball while
+// moving, the longest when it moved against the incoming ball
+func (g *Game) startHitStop(paddle *GameObject, incoming mgl.Vec2) {
+	if paddle.velocity.Y() == 0 {
+		return
+	}
+	g.hitStop = hitStopTicks
+	if (paddle.velocity.Y() > 0) != (incoming.Y() > 0) {
+		g.hitStop = hitStopCounter
+	}
+}
+
+// stepTime returns the time of an update, none while a hit stop lasts
+func (g *Game) stepTime(deltaTime float64) float64 {
+	if g.hitStop > 0 {
+		g.hitStop--
+		return 0
+	}
+	return deltaTime
+}
@@ -0,0 +1,156 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// inspectorField is an editable field of the entities, changed by the step with left and right
+type inspectorField struct {
+	name string
+	step float32
+	get  func(o *GameObject) float32
+	set  func(o *GameObject, value float32)
+}
+
+var inspectorFields = []inspectorField{
+	{"X", 10, func(o *GameObject) float32 { return o.position.X() }, func(o *GameObject, v float32) { o.position[0] = v }},
+	{"Y", 10, func(o *GameObject) float32 { return o.position.Y() }, func(o *GameObject, v float32) { o.position[1] = v }},
+	{"Velocity X", 50, func(o *GameObject) float32 { return o.velocity.X() }, func(o *GameObject, v float32) { o.velocity[0] = v }},
+	{"Velocity Y", 50, func(o *GameObject) float32 { return o.velocity.Y() }, func(o *GameObject, v float32) { o.velocity[1] = v }},
+	{"Width", 10, func(o *GameObject) float32 { return o.size.X() }, func(o *GameObject, v float32) { o.size[0] = v }},
+	{"Height", 10, func(o *GameObject) float32 { return o.size.Y() }, func(o *GameObject, v float32) { o.size[1] = v }},
+	{"Red", 0.05, func(o *GameObject) float32 { return o.color.X() }, func(o *GameObject, v float32) { o.color[0] = v }},
+	{"Green", 0.05, func(o *GameObject) float32 { return o.color.Y() }, func(o *GameObject, v float32) { o.color[1] = v }},
+	{"Blue", 0.05, func(o *GameObject) float32 { return o.color.Z() }, func(o *GameObject, v float32) { o.color[2] = v }},
+}
+
+// InspectorControls are the commands of the entity inspector for one frame
+type InspectorControls struct {
+	Navigation       // Activating a row moves to the next entity
+	Left, Right bool // Decrease or increase the focused field, or change the entity on the first row
+	Fine        bool // Change the fields by a tenth of their step
+	Close       bool
+	Pointer     Pointer
+}
+
+// Inspector is a debug panel listing the fields of the paddles and the ball, the changes apply to
+// the simulation right away; the colors last until the next skin is applied
+type Inspector struct {
+	game   *Game
+	entity int // Index of the entity inspected
+	focus  focus
+	open   bool
+}
+
+// NewInspector returns a closed entity inspector
+func NewInspector(game *Game) *Inspector {
+	s := &Inspector{game: game}
+	if game.initialized {
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { s.draw() }))
+	}
+	return s
+}
+
+// Open shows the inspector on the first entity
+func (s *Inspector) Open() {
+	s.open = true
+	s.entity = 0
+	s.focus.reset(len(inspectorFields) + 1)
+}
+
+// IsOpen tells if the inspector is shown
+func (s *Inspector) IsOpen() bool {
+	return s.open
+}
+
+// entities returns the names and the objects of the entities
+func (s *Inspector) entities() ([]string, []*GameObject) {
+	g := s.game
+	return []string{"Left paddle", "Right paddle", "Ball"}, []*GameObject{g.paddle1, g.paddle2, &g.ball.GameObject}
+}
+
+// Update applies the controls
+func (s *Inspector) Update(controls InspectorControls) {
+	if !s.open {
+		return
+	}
+	if controls.Close {
+		s.open = false
+		return
+	}
+	names, objects := s.entities()
+	if s.focus.update(controls.Navigation, controls.Pointer, s.row) {
+		s.entity = (s.entity + 1) % len(names)
+		return
+	}
+	if !controls.Left && !controls.Right {
+		return
+	}
+	if s.focus.index == 0 {
+		if controls.Left {
+			s.entity = (s.entity + len(names) - 1) % len(names)
+		} else {
+			s.entity = (s.entity + 1) % len(names)
+		}
+		return
+	}
+	field := inspectorFields[s.focus.index-1]
+	step := field.step
+	if controls.Fine {
+		step /= 10
+	}
+	if controls.Left {
+		step = -step
+	}
+	object := objects[s.entity]
+	field.set(object, field.get(object)+step)
+	// Don't interpolate the change
+	object.StorePosition()
+	if object == &s.game.ball.GameObject {
+		// The ball stays round, the collisions use its radius
+		ball := s.game.ball
+		if field.name == "Height" {
+			ball.size[0] = ball.size.Y()
+		}
+		ball.radius = ball.size.X() / 2
+		ball.size[1] = ball.size.X()
+	}
+}
+
+// row returns the area of the entity, then of the field at the index
+func (s *Inspector) row(i int) (mgl.Vec2, mgl.Vec2) {
+	return mgl.Vec2{float32(s.game.width) - 620, 200 + float32(i)*56}, mgl.Vec2{560, 48}
+}
+
+// draw renders the fields of the entity in a panel on the right, leaving the court in sight
+func (s *Inspector) draw() {
+	if !s.open {
+		return
+	}
+	g := s.game
+	names, objects := s.entities()
+	object := objects[s.entity]
+	x := float32(g.width) - 600
+	g.renderer.Draw(mgl.Vec2{x - 40, 120}, mgl.Vec2{640, float32(len(inspectorFields)+1)*56 + 200}, 0, mgl.Vec3{0.1, 0.1, 0.1})
+	g.text.RenderText(x, 140, 0.4, mgl.Vec3{1.0, 1.0, 1.0}, "INSPECTOR")
+	for i := 0; i <= len(inspectorFields); i++ {
+		position, size := s.row(i)
+		y := position.Y() + 10
+		color := mgl.Vec3{0.6, 0.6, 0.6}
+		if i == s.focus.index {
+			g.drawFocusRing(position, size)
+			color = mgl.Vec3{1.0, 0.8, 0.1}
+		}
+		if i == 0 {
+			g.text.RenderText(x, y, 0.3, color, "< %v >", names[s.entity])
+			continue
+		}
+		field := inspectorFields[i-1]
+		g.text.RenderText(x, y, 0.3, color, "%v", field.name)
+		g.text.RenderText(x+280, y, 0.3, color, "%.2f", field.get(object))
+	}
+	bottom := 200 + float32(len(inspectorFields)+1)*56
+	g.text.RenderText(x, bottom+10, 0.22, mgl.Vec3{0.6, 0.6, 0.6}, "UP/DOWN select - LEFT/RIGHT change - SHIFT fine")
+	g.text.RenderText(x, bottom+50, 0.22, mgl.Vec3{0.6, 0.6, 0.6}, "ENTER next entity - ESC back")
+}
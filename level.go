@@ -0,0 +1,235 @@
+package pong
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/level"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+// LevelsDir is where the levels are loaded from, one <name>.json each
+const LevelsDir = "./levels"
+
+// ClassicLevel is the name of the court without a level: no walls nor obstacles, goals as tall as the court
+const ClassicLevel = "classic"
+
+var (
+	levelColor    = mgl.Vec3{0.5, 0.5, 0.55} // Color of the walls and the obstacles with a theme without markings
+	levelEndWidth = float32(8)               // Width of the ends of the court outside of the goals
+)
+
+// LoadLevels loads the levels of the game and of the mods through the resource manager sorted by name,
+// it returns the ones read along with the last error met
+func (g *Game) LoadLevels() ([]*level.Level, error) {
+	var levels []*level.Level
+	var err error
+	for _, dir := range contentDirs(LevelsDir, g.mods, modLevels) {
+		loaded, dirErr := g.loadLevels(dir, levels)
+		levels = append(levels, loaded...)
+		if dirErr != nil {
+			err = dirErr
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Name < levels[j].Name })
+	return levels, err
+}
+
+// loadLevels loads the levels found in the directory, skipping the ones named like the levels
+// loaded already, it returns the ones read along with the last error met
+func (g *Game) loadLevels(dir string, loaded []*level.Level) ([]*level.Level, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var levels []*level.Level
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), ".json")
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		if name == ClassicLevel || findLevel(loaded, name) != nil {
+			err = fmt.Errorf("level %v of %v already exists", name, dir)
+			continue
+		}
+		l, loadErr := g.resourceManager.LoadLevel(filepath.Join(dir, file.Name()), name)
+		if loadErr == nil {
+			loadErr = l.Validate(float32(g.width), float32(g.height))
+		}
+		if loadErr != nil {
+			err = fmt.Errorf("failed to load level %v: %v", name, loadErr)
+			continue
+		}
+		levels = append(levels, l)
+	}
+	return levels, err
+}
+
+// findLevel returns the level with the name, nil if none has it
+func findLevel(levels []*level.Level, name string) *level.Level {
+	for _, l := range levels {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// SaveLevel writes the level to the directory as <name>.json and loads it again through the resource
+// manager, it returns the level loaded
+func (g *Game) SaveLevel(dir string, l *level.Level) (*level.Level, error) {
+	if err := l.Validate(float32(g.width), float32(g.height)); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	file := filepath.Join(dir, l.Name+".json")
+	if err := l.Save(file); err != nil {
+		return nil, err
+	}
+	g.resourceManager.ReleaseLevel(l.Name)
+	return g.resourceManager.LoadLevel(file, l.Name)
+}
+
+// SetLevel changes the layout of the court from the next match, the classic court when nil, the
+// menu shows it at once
+func (g *Game) SetLevel(l *level.Level) {
+	g.level = l
+	if g.state == GameMenu {
+		g.applyRules(g.matchRules())
+		g.resetObjects()
+	}
+}
+
+// Level returns the layout of the court of the matches, nil for the classic court
+func (g *Game) Level() *level.Level {
+	return g.level
+}
+
+// ballSpawn returns where the ball is served from, the top left corner of the ball
+func (g *Game) ballSpawn() mgl.Vec2 {
+	if g.rules.level == nil || g.rules.level.Spawns.Ball == nil {
+		return mgl.Vec2{float32(g.width / 2), float32(g.height / 2)}
+	}
+	radius := g.rules.ballRadius
+	return g.rules.level.Spawns.Ball.Vec2().Sub(mgl.Vec2{radius, radius})
+}
+
+// paddleSpawns returns where the left and the right paddle start, their top left corners
+func (g *Game) paddleSpawns() [2]mgl.Vec2 {
+	size := g.rules.paddleSize
+	spawns := [2]mgl.Vec2{
+		{paddleMargin, float32(g.height/2) - size.Y()/2},
+		{float32(g.width) - size.X() - paddleMargin, float32(g.height/2) - size.Y()/2},
+	}
+	if g.rules.level == nil {
+		return spawns
+	}
+	for i, spawn := range g.rules.level.Spawns.Paddles {
+		if spawn != nil {
+			spawns[i] = spawn.Vec2().Sub(size.Mul(0.5))
+		}
+	}
+	return spawns
+}
+
+// bounceLevel bounces a ball off the walls and the obstacles of the level, it tells if it hit one
+func (g *Game) bounceLevel(ball *BallObject) bool {
+	if g.rules.level == nil {
+		return false
+	}
+	var contacts []physics.Collision
+	for _, wall := range g.rules.level.Walls {
+		if contact, ok := physics.CircleAABB(ball.Circle(), wall.AABB()); ok {
+			contacts = append(contacts, contact)
+		}
+	}
+	for _, obstacle := range g.rules.level.Obstacles {
+		if contact, ok := physics.CircleCircle(ball.Circle(), obstacle.Circle()); ok {
+			contacts = append(contacts, contact)
+		}
+	}
+	for _, contact := range contacts {
+		g.recordContact(ball.Circle(), contact)
+		ball.position = ball.position.Add(contact.Normal.Mul(contact.Penetration))
+		ball.velocity = physics.Reflect(ball.velocity, contact.Normal)
+	}
+	if len(contacts) > 0 {
+		// The rally has to go on: a ball bounced too steep would never reach a paddle
+		axis := mgl.Vec2{1, 0}
+		if ball.velocity.X() < 0 {
+			axis = mgl.Vec2{-1, 0}
+		}
+		ball.velocity = physics.LimitAngle(ball.velocity, axis, ballMaxAngle)
+	}
+	return len(contacts) > 0
+}
+
+// bounceEnds bounces a ball off the ends of the court outside of the goals of the level,
+// it tells if it hit one
+func (g *Game) bounceEnds(ball *BallObject) bool {
+	if g.rules.level == nil {
+		return false
+	}
+	center := ball.Circle().Center.Y()
+	if ball.position.X() <= 0 && !g.inGoal(0, center) {
+		ball.position[0] = 0
+		ball.velocity[0] = abs(ball.velocity.X())
+		return true
+	}
+	if ball.position.X()+ball.size.X() >= float32(g.width) && !g.inGoal(1, center) {
+		ball.position[0] = float32(g.width) - ball.size.X()
+		ball.velocity[0] = -abs(ball.velocity.X())
+		return true
+	}
+	return false
+}
+
+// inGoal tells if the height is within the goal of the left (0) or the right (1) end
+func (g *Game) inGoal(end int, y float32) bool {
+	top, bottom := g.rules.level.Goals[end].Span(float32(g.height))
+	return y >= top && y <= bottom
+}
+
+// drawLevel renders the walls, the obstacles and the ends of the court outside of the goals
+func (g *Game) drawLevel() {
+	l := g.rules.level
+	if l == nil {
+		return
+	}
+	color := g.theme.Markings
+	if color == (mgl.Vec3{}) {
+		color = levelColor
+	}
+	for _, wall := range l.Walls {
+		g.renderer.Draw(mgl.Vec2{wall.X, wall.Y}, mgl.Vec2{wall.Width, wall.Height}, 0, color)
+	}
+	for _, obstacle := range l.Obstacles {
+		// Filled by the outline as thick as the radius
+		g.renderer.DrawCircleOutline(mgl.Vec2{obstacle.X, obstacle.Y}, obstacle.Radius/2, obstacle.Radius, color)
+	}
+	width, height := float32(g.width), float32(g.height)
+	for end, x := range []float32{0, width - levelEndWidth} {
+		top, bottom := l.Goals[end].Span(height)
+		if top > 0 {
+			g.renderer.Draw(mgl.Vec2{x, 0}, mgl.Vec2{levelEndWidth, top}, 0, color)
+		}
+		if bottom < height {
+			g.renderer.Draw(mgl.Vec2{x, bottom}, mgl.Vec2{levelEndWidth, height - bottom}, 0, color)
+		}
+	}
+}
+
+// abs returns the absolute value
+func abs(value float32) float32 {
+	return float32(math.Abs(float64(value)))
+}
@@ -0,0 +1,412 @@
+package pong
+
+import (
+	"bytes"
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/level"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+var (
+	editorGrid    = float32(40) // Size of the cells the shapes snap to
+	editorHandle  = float32(24) // Size of the handles scaling the shapes
+	editorMinSize = float32(20) // Smallest width, height, diameter or goal placed, smaller ones are dropped
+	editorHistory = 100         // Changes that can be undone
+	editorGoal    = float32(60) // Width of the goal zones drawn at the ends
+)
+
+// editorTool is what dragging on an empty spot of the court places
+type editorTool int
+
+const (
+	toolWall editorTool = iota
+	toolObstacle
+	toolGoal
+)
+
+var editorToolNames = []string{"wall", "obstacle", "goal"}
+
+// editorShapeKind tells the walls from the obstacles
+type editorShapeKind int
+
+const (
+	shapeNone editorShapeKind = iota
+	shapeWall
+	shapeObstacle
+)
+
+// editorShape is a wall or an obstacle of the level, by index
+type editorShape struct {
+	kind  editorShapeKind
+	index int
+}
+
+// editorDragKind is what a drag does
+type editorDragKind int
+
+const (
+	dragNone editorDragKind = iota
+	dragCreate
+	dragMove
+	dragScale
+	dragGoal
+)
+
+// editorDrag is the shape being dragged with the pointer
+type editorDrag struct {
+	kind   editorDragKind
+	shape  editorShape
+	anchor mgl.Vec2     // Where a new shape started, or the pointer from the shape origin when moving
+	end    int          // End of the court whose goal is dragged
+	before *level.Level // The level before the drag, to undo it
+}
+
+// LevelEditorControls are the commands of the level editor for one frame
+type LevelEditorControls struct {
+	Pointer    Pointer // Dragging places, moves and scales the shapes, the right button removes them
+	NextTool   bool
+	Snap       bool // Turns the grid snapping on or off
+	Undo, Redo bool
+}
+
+// LevelEditor places the walls, the obstacles and the goals of a level with the mouse over the court,
+// the court shows the level as it's edited. Saving and test-playing it are up to the owner
+type LevelEditor struct {
+	game  *Game
+	level *level.Level
+	saved []byte // The level as last saved, to tell if it changed
+	tool  editorTool
+	snap  bool
+	drag  editorDrag
+	hover editorShape
+	scale bool // The pointer is on the handle of the hovered shape
+	undos []*level.Level
+	redos []*level.Level
+	open  bool
+}
+
+// NewLevelEditor returns a closed level editor
+func NewLevelEditor(game *Game) *LevelEditor {
+	e := &LevelEditor{game: game, snap: true}
+	if game.initialized {
+		game.layers.Register(layerCourt, render.DrawFunc(func(float32) { e.drawGrid() }))
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { e.draw() }))
+	}
+	return e
+}
+
+// Open starts editing a copy of the level, a new empty level with the name when nil
+func (e *LevelEditor) Open(l *level.Level, name string) {
+	if l == nil {
+		l = &level.Level{Name: name}
+	}
+	e.level = l.Copy()
+	e.saved = e.level.Encode()
+	e.undos, e.redos = nil, nil
+	e.drag = editorDrag{}
+	e.Resume()
+}
+
+// Resume shows the editor again on the level it was editing
+func (e *LevelEditor) Resume() {
+	e.open = true
+	e.game.editing = true
+	e.game.SetLevel(e.level)
+}
+
+// Close hides the editor, the game keeps the level edited until it's changed
+func (e *LevelEditor) Close() {
+	e.open = false
+	e.game.editing = false
+}
+
+// IsOpen tells if the editor is shown
+func (e *LevelEditor) IsOpen() bool {
+	return e.open
+}
+
+// Level returns the level edited
+func (e *LevelEditor) Level() *level.Level {
+	return e.level
+}
+
+// Changed tells if the level changed since it was opened or saved
+func (e *LevelEditor) Changed() bool {
+	return !bytes.Equal(e.level.Encode(), e.saved)
+}
+
+// Saved marks the level as saved
+func (e *LevelEditor) Saved() {
+	e.saved = e.level.Encode()
+}
+
+// Update applies the controls
+func (e *LevelEditor) Update(controls LevelEditorControls) {
+	if !e.open {
+		return
+	}
+	if controls.NextTool {
+		e.tool = (e.tool + 1) % editorTool(len(editorToolNames))
+	}
+	if controls.Snap {
+		e.snap = !e.snap
+	}
+	if e.drag.kind == dragNone {
+		if controls.Undo {
+			e.undo()
+		} else if controls.Redo {
+			e.redo()
+		}
+	}
+	width, height := float32(e.game.width), float32(e.game.height)
+	pointer := controls.Pointer.Position
+	pointer = mgl.Vec2{mgl.Clamp(pointer.X(), 0, width), mgl.Clamp(pointer.Y(), 0, height)}
+	if e.drag.kind != dragNone {
+		if controls.Pointer.Down {
+			e.dragTo(pointer)
+		} else {
+			e.endDrag()
+		}
+		return
+	}
+	e.hover, e.scale = e.shapeAt(pointer)
+	if controls.Pointer.Erase && e.hover.kind != shapeNone {
+		e.record(e.level.Copy())
+		e.remove(e.hover)
+		e.hover = editorShape{}
+	} else if controls.Pointer.Click {
+		e.startDrag(pointer)
+	}
+}
+
+// record keeps the level before a change to undo it, dropping the changes undone
+func (e *LevelEditor) record(before *level.Level) {
+	e.undos = append(e.undos, before)
+	if len(e.undos) > editorHistory {
+		e.undos = e.undos[1:]
+	}
+	e.redos = nil
+}
+
+// undo goes back to the level before the last change
+func (e *LevelEditor) undo() {
+	if len(e.undos) == 0 {
+		return
+	}
+	e.redos = append(e.redos, e.level.Copy())
+	// The game plays the same level, it's changed in place
+	*e.level = *e.undos[len(e.undos)-1]
+	e.undos = e.undos[:len(e.undos)-1]
+}
+
+// redo applies again the last change undone
+func (e *LevelEditor) redo() {
+	if len(e.redos) == 0 {
+		return
+	}
+	e.undos = append(e.undos, e.level.Copy())
+	*e.level = *e.redos[len(e.redos)-1]
+	e.redos = e.redos[:len(e.redos)-1]
+}
+
+// snapped returns the position on the nearest corner of the grid, when snapping
+func (e *LevelEditor) snapped(position mgl.Vec2) mgl.Vec2 {
+	if !e.snap {
+		return position
+	}
+	return mgl.Vec2{e.snappedLength(position.X()), e.snappedLength(position.Y())}
+}
+
+// snappedLength returns the length rounded to the grid, when snapping
+func (e *LevelEditor) snappedLength(length float32) float32 {
+	if !e.snap {
+		return length
+	}
+	return float32(math.Round(float64(length/editorGrid))) * editorGrid
+}
+
+// shapeAt returns the shape under the position, the last drawn first, and if the position is on its handle
+func (e *LevelEditor) shapeAt(position mgl.Vec2) (editorShape, bool) {
+	for i := len(e.level.Obstacles) - 1; i >= 0; i-- {
+		obstacle := e.level.Obstacles[i]
+		distance := position.Sub(mgl.Vec2{obstacle.X, obstacle.Y}).Len()
+		if distance <= obstacle.Radius+editorHandle/2 {
+			return editorShape{shapeObstacle, i}, distance >= obstacle.Radius-editorHandle/2
+		}
+	}
+	for i := len(e.level.Walls) - 1; i >= 0; i-- {
+		wall := e.level.Walls[i]
+		corner := mgl.Vec2{wall.X + wall.Width, wall.Y + wall.Height}
+		if handle := position.Sub(corner); abs(handle.X()) <= editorHandle/2 && abs(handle.Y()) <= editorHandle/2 {
+			return editorShape{shapeWall, i}, true
+		}
+		if (Pointer{Position: position}).Over(mgl.Vec2{wall.X, wall.Y}, mgl.Vec2{wall.Width, wall.Height}) {
+			return editorShape{shapeWall, i}, false
+		}
+	}
+	return editorShape{}, false
+}
+
+// remove takes the shape out of the level
+func (e *LevelEditor) remove(shape editorShape) {
+	switch shape.kind {
+	case shapeWall:
+		e.level.Walls = append(e.level.Walls[:shape.index], e.level.Walls[shape.index+1:]...)
+	case shapeObstacle:
+		e.level.Obstacles = append(e.level.Obstacles[:shape.index], e.level.Obstacles[shape.index+1:]...)
+	}
+}
+
+// startDrag moves or scales the shape under the position, or places a new one with the tool
+func (e *LevelEditor) startDrag(position mgl.Vec2) {
+	e.drag = editorDrag{shape: e.hover, before: e.level.Copy()}
+	switch {
+	case e.hover.kind != shapeNone && e.scale:
+		e.drag.kind = dragScale
+	case e.hover.kind == shapeWall:
+		wall := e.level.Walls[e.hover.index]
+		e.drag.kind = dragMove
+		e.drag.anchor = position.Sub(mgl.Vec2{wall.X, wall.Y})
+	case e.hover.kind == shapeObstacle:
+		obstacle := e.level.Obstacles[e.hover.index]
+		e.drag.kind = dragMove
+		e.drag.anchor = position.Sub(mgl.Vec2{obstacle.X, obstacle.Y})
+	case e.tool == toolGoal:
+		e.drag.kind = dragGoal
+		e.drag.anchor = e.snapped(position)
+		if position.X() >= float32(e.game.width/2) {
+			e.drag.end = 1
+		}
+	case e.tool == toolWall:
+		e.drag.kind = dragCreate
+		e.drag.anchor = e.snapped(position)
+		e.drag.shape = editorShape{shapeWall, len(e.level.Walls)}
+		e.level.Walls = append(e.level.Walls, level.Rect{X: e.drag.anchor.X(), Y: e.drag.anchor.Y()})
+	case e.tool == toolObstacle:
+		e.drag.kind = dragCreate
+		e.drag.anchor = e.snapped(position)
+		e.drag.shape = editorShape{shapeObstacle, len(e.level.Obstacles)}
+		e.level.Obstacles = append(e.level.Obstacles, level.Circle{X: e.drag.anchor.X(), Y: e.drag.anchor.Y()})
+	}
+}
+
+// dragTo follows the pointer with the shape dragged, keeping it in the court
+func (e *LevelEditor) dragTo(position mgl.Vec2) {
+	width, height := float32(e.game.width), float32(e.game.height)
+	snapped := e.snapped(position)
+	switch e.drag.shape.kind {
+	case shapeWall:
+		wall := &e.level.Walls[e.drag.shape.index]
+		switch e.drag.kind {
+		case dragCreate:
+			min, max := e.drag.anchor, snapped
+			wall.X, wall.Y = float32(math.Min(float64(min.X()), float64(max.X()))), float32(math.Min(float64(min.Y()), float64(max.Y())))
+			wall.Width, wall.Height = abs(max.X()-min.X()), abs(max.Y()-min.Y())
+		case dragMove:
+			origin := e.snapped(position.Sub(e.drag.anchor))
+			wall.X = mgl.Clamp(origin.X(), 0, width-wall.Width)
+			wall.Y = mgl.Clamp(origin.Y(), 0, height-wall.Height)
+		case dragScale:
+			wall.Width = mgl.Clamp(snapped.X()-wall.X, editorMinSize, width-wall.X)
+			wall.Height = mgl.Clamp(snapped.Y()-wall.Y, editorMinSize, height-wall.Y)
+		}
+	case shapeObstacle:
+		obstacle := &e.level.Obstacles[e.drag.shape.index]
+		center := mgl.Vec2{obstacle.X, obstacle.Y}
+		switch e.drag.kind {
+		case dragCreate, dragScale:
+			obstacle.Radius = float32(math.Max(float64(e.snappedLength(position.Sub(center).Len())), float64(editorMinSize/2)))
+		case dragMove:
+			origin := e.snapped(position.Sub(e.drag.anchor))
+			obstacle.X = mgl.Clamp(origin.X(), 0, width)
+			obstacle.Y = mgl.Clamp(origin.Y(), 0, height)
+		}
+	default:
+		if e.drag.kind == dragGoal {
+			top, bottom := e.drag.anchor.Y(), snapped.Y()
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			e.level.Goals[e.drag.end] = level.Goal{Top: top, Bottom: bottom}
+		}
+	}
+}
+
+// endDrag drops the shapes placed too small, a goal too small opens the whole end, then records the change
+func (e *LevelEditor) endDrag() {
+	switch {
+	case e.drag.kind == dragCreate && e.drag.shape.kind == shapeWall:
+		if wall := e.level.Walls[e.drag.shape.index]; wall.Width < editorMinSize || wall.Height < editorMinSize {
+			e.remove(e.drag.shape)
+		}
+	case e.drag.kind == dragCreate && e.drag.shape.kind == shapeObstacle:
+		if e.level.Obstacles[e.drag.shape.index].Radius < editorMinSize/2+1 {
+			e.remove(e.drag.shape)
+		}
+	case e.drag.kind == dragGoal:
+		if goal := e.level.Goals[e.drag.end]; goal.Bottom-goal.Top < editorMinSize || goal.Bottom == 0 {
+			e.level.Goals[e.drag.end] = level.Goal{}
+		}
+	}
+	if !bytes.Equal(e.level.Encode(), e.drag.before.Encode()) {
+		e.record(e.drag.before)
+	}
+	e.drag = editorDrag{}
+}
+
+// drawGrid renders the grid the shapes snap to, under the level
+func (e *LevelEditor) drawGrid() {
+	if !e.open || !e.snap {
+		return
+	}
+	g := e.game
+	width, height := float32(g.width), float32(g.height)
+	color := mgl.Vec3{0.18, 0.18, 0.22}
+	for x := editorGrid; x < width; x += editorGrid {
+		g.renderer.Draw(mgl.Vec2{x - 1, 0}, mgl.Vec2{2, height}, 0, color)
+	}
+	for y := editorGrid; y < height; y += editorGrid {
+		g.renderer.Draw(mgl.Vec2{0, y - 1}, mgl.Vec2{width, 2}, 0, color)
+	}
+}
+
+// draw renders the goal zones, the shape under the pointer with its handle and the keys
+func (e *LevelEditor) draw() {
+	if !e.open {
+		return
+	}
+	g := e.game
+	width, height := float32(g.width), float32(g.height)
+	goalColor := mgl.Vec3{0.2, 0.9, 0.3}
+	for end, x := range []float32{0, width - editorGoal} {
+		top, bottom := e.level.Goals[end].Span(height)
+		g.renderer.DrawRectOutline(mgl.Vec2{x, top}, mgl.Vec2{editorGoal, bottom - top}, 4, goalColor)
+	}
+	hoverColor := mgl.Vec3{1.0, 0.8, 0.1}
+	switch e.hover.kind {
+	case shapeWall:
+		wall := e.level.Walls[e.hover.index]
+		g.renderer.DrawRectOutline(mgl.Vec2{wall.X, wall.Y}, mgl.Vec2{wall.Width, wall.Height}, 4, hoverColor)
+		corner := mgl.Vec2{wall.X + wall.Width, wall.Y + wall.Height}
+		g.renderer.Draw(corner.Sub(mgl.Vec2{editorHandle / 2, editorHandle / 2}), mgl.Vec2{editorHandle, editorHandle}, 0, hoverColor)
+	case shapeObstacle:
+		obstacle := e.level.Obstacles[e.hover.index]
+		g.renderer.DrawCircleOutline(mgl.Vec2{obstacle.X, obstacle.Y}, obstacle.Radius, 4, hoverColor)
+		handle := mgl.Vec2{obstacle.X + obstacle.Radius, obstacle.Y}
+		g.renderer.Draw(handle.Sub(mgl.Vec2{editorHandle / 2, editorHandle / 2}), mgl.Vec2{editorHandle, editorHandle}, 0, hoverColor)
+	}
+	snap := "off"
+	if e.snap {
+		snap = "on"
+	}
+	changed := ""
+	if e.Changed() {
+		changed = " *"
+	}
+	g.drawCentered(40, 0.4, mgl.Vec3{1.0, 1.0, 1.0}, "LEVEL EDITOR: "+e.level.Name+changed)
+	g.drawCentered(100, 0.3, hoverColor, "Tool: "+editorToolNames[e.tool]+" (TAB) - Snap: "+snap+" (G)")
+	g.drawCentered(height-60, 0.22, mgl.Vec3{0.6, 0.6, 0.6},
+		"Drag to place, move or scale - RIGHT click remove - CTRL+Z undo - CTRL+Y redo - P test - CTRL+S save - ESC back")
+}
@@ -0,0 +1,40 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	lightRadius   = float32(0.6)  // Reach of the light of the ball, as a fraction of the court height
+	lightAmbient  = float32(0.15) // Share of the light left out of the reach of the ball
+	markingWidth  = float32(6)
+	markingDash   = float32(30) // Length of the dashes of the center line, the gaps are as long
+	markingCircle = float32(90) // Radius of the center circle
+)
+
+// updateLight places the light of the theme on the ball as drawn, through the camera
+func (g *Game) updateLight(alpha float32) {
+	if g.spotlight() {
+		return
+	}
+	g.effects.Light.Enabled = g.theme.Lighting
+	if !g.theme.Lighting {
+		return
+	}
+	center := g.ball.RenderPosition(alpha).Add(mgl.Vec2{g.ball.radius, g.ball.radius})
+	position := g.camera.View().Mul4x1(mgl.Vec4{center.X(), center.Y(), 0, 1})
+	// The scene texture has the origin in the bottom left corner
+	g.effects.Light.Position = mgl.Vec2{position.X() / float32(g.width), 1 - position.Y()/float32(g.height)}
+	g.effects.Light.Radius = lightRadius
+	g.effects.Light.Ambient = lightAmbient
+}
+
+// drawMarkings renders the lines of the court of the theme: the dashed center line and the center circle
+func (g *Game) drawMarkings() {
+	if g.theme.Markings == (mgl.Vec3{}) {
+		return
+	}
+	width, height := float32(g.width), float32(g.height)
+	for y := float32(0); y < height; y += markingDash * 2 {
+		g.renderer.Draw(mgl.Vec2{(width - markingWidth) / 2, y}, mgl.Vec2{markingWidth, markingDash}, 0, g.theme.Markings)
+	}
+	g.renderer.DrawCircleOutline(mgl.Vec2{width / 2, height / 2}, markingCircle, markingWidth, g.theme.Markings)
+}
@@ -0,0 +1,21 @@
+package pong
+
+import "github.com/lucatironi/go-pong/pkg/tween"
+
+var (
+	menuFadeTime  = 0.5 // Seconds the menu texts take to fade in
+	menuSlideTime = 0.6 // Seconds the player names take to slide in
+	menuSlide     = float32(300)
+)
+
+// menuAnimation is the entrance of the menu texts
+type menuAnimation struct {
+	alpha  float32 // Opacity of the texts
+	offset float32 // Distance of the player names from their place, towards the edges
+}
+
+// animateMenu fades the menu texts in and slides the player names in from the edges
+func (g *Game) animateMenu() {
+	g.tweens.Add(tween.NewFloat(&g.menu.alpha, 0, 1, menuFadeTime, tween.InOutQuad))
+	g.tweens.Add(tween.NewFloat(&g.menu.offset, menuSlide, 0, menuSlideTime, tween.OutBack))
+}
@@ -0,0 +1,38 @@
+package pong
+
+// SetMirrored draws the court mirrored horizontally, the left player playing on the right side
+// of the screen: only the picture changes, the paddles and the input stay the same
+func (g *Game) SetMirrored(mirrored bool) {
+	g.mirrored = mirrored
+	g.applyMirror()
+}
+
+// Mirrored tells if the court is drawn mirrored horizontally
+func (g *Game) Mirrored() bool {
+	return g.mirrored
+}
+
+// applyMirror flips the camera as the sides and the court flip modifier ask
+func (g *Game) applyMirror() {
+	if !g.initialized {
+		return
+	}
+	flipped := g.flip.flipped
+	g.camera.SetMirror(g.mirrored != (flipped && g.flip.horizontal), flipped && !g.flip.horizontal)
+}
+
+// screenPlayers returns the players shown on the left and on the right side of the screen
+func (g *Game) screenPlayers() [2]int {
+	if g.mirrored {
+		return [2]int{2, 1}
+	}
+	return [2]int{1, 2}
+}
+
+// screenScores returns the scores of the players on the left and on the right side of the screen
+func (g *Game) screenScores() (int, int) {
+	if g.mirrored {
+		return g.paddle2Score, g.paddle1Score
+	}
+	return g.paddle1Score, g.paddle2Score
+}
@@ -0,0 +1,222 @@
+package pong
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/particles"
+)
+
+// ModsDir is where the user content is loaded from: every directory in it is a mod holding any of
+// the skins, themes, levels and particles directories, laid out like the ones of the game
+const ModsDir = "./mods"
+
+// Content directories of a mod
+const (
+	modSkins     = "skins"
+	modThemes    = "themes"
+	modLevels    = "levels"
+	modParticles = "particles"
+)
+
+// SkinTrail is the name of the trail following the skins, the particle presets replace it
+const SkinTrail = "skin"
+
+// Mod is a directory of user content
+type Mod struct {
+	Name string
+	Dir  string
+}
+
+// ModContent is the content of the mods read before creating a game, offered along with the one of the game
+type ModContent struct {
+	Themes []Theme                             // Added after the themes of the game
+	Trails map[string]particles.ParticlePreset // Particle presets the ball can leave as its trail, by name
+}
+
+// themeFile is the JSON description of a theme
+type themeFile struct {
+	Background [3]float32 `json:"background"`
+	Markings   [3]float32 `json:"markings"`
+	Lighting   bool       `json:"lighting"`
+}
+
+// particlesFile is the JSON description of a particle preset, of the mods or of the trail of a skin:
+// a zero life or fade keeps the default one
+type particlesFile struct {
+	Color       [4]float32 `json:"color"`
+	ColorJitter float32    `json:"color_jitter"`
+	Size        [2]float32 `json:"size"`
+	Life        float64    `json:"life"`
+	Fade        float32    `json:"fade"`
+}
+
+// preset checks the description and returns the particle preset it describes
+func (f particlesFile) preset() (particles.ParticlePreset, error) {
+	if !validColor(f.Color[:]) || f.ColorJitter < 0 || f.Size[0] < 0 || f.Size[1] < 0 || f.Life < 0 || f.Fade < 0 {
+		return particles.ParticlePreset{}, fmt.Errorf("color not from 0 to 1 or negative values")
+	}
+	if f.Life == 0 {
+		f.Life = defaultSkinTrail.Life
+	}
+	if f.Fade == 0 {
+		f.Fade = defaultSkinTrail.Fade
+	}
+	return particles.ParticlePreset{
+		Color:       mgl.Vec4(f.Color),
+		ColorJitter: f.ColorJitter,
+		Size:        mgl.Vec2(f.Size),
+		Life:        f.Life,
+		Fade:        f.Fade,
+	}, nil
+}
+
+// FindMods returns the mods found in the directory sorted by name, none when it's missing
+func FindMods(dir string) ([]Mod, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var mods []Mod
+	for _, file := range files {
+		if file.IsDir() {
+			mods = append(mods, Mod{Name: file.Name(), Dir: filepath.Join(dir, file.Name())})
+		}
+	}
+	sort.Slice(mods, func(i, j int) bool { return mods[i].Name < mods[j].Name })
+	return mods, nil
+}
+
+// contentDirs returns the directory of the game followed by the ones of the mods holding the content
+func contentDirs(base string, mods []Mod, content string) []string {
+	dirs := []string{base}
+	for _, mod := range mods {
+		dirs = append(dirs, filepath.Join(mod.Dir, content))
+	}
+	return dirs
+}
+
+// readModFiles calls read with the name and the contents of every <name>.json file of the content
+// of the mods, a name taken already is skipped. It returns the last error met
+func readModFiles(mods []Mod, content string, taken func(name string) bool, read func(name string, data []byte) error) error {
+	var err error
+	for _, mod := range mods {
+		dir := filepath.Join(mod.Dir, content)
+		files, dirErr := ioutil.ReadDir(dir)
+		if os.IsNotExist(dirErr) {
+			continue
+		} else if dirErr != nil {
+			err = fmt.Errorf("mod %v: %v", mod.Name, dirErr)
+			continue
+		}
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+				continue
+			}
+			name := strings.TrimSuffix(file.Name(), ".json")
+			if taken(name) {
+				err = fmt.Errorf("mod %v: %v %v already exists", mod.Name, content, name)
+				continue
+			}
+			data, readErr := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+			if readErr == nil {
+				readErr = read(name, data)
+			}
+			if readErr != nil {
+				err = fmt.Errorf("mod %v: failed to load %v %v: %v", mod.Name, content, name, readErr)
+			}
+		}
+	}
+	return err
+}
+
+// LoadModContent reads the themes and the particle presets of the mods, one <name>.json each,
+// it returns the content loaded and the last error met
+func LoadModContent(mods []Mod) (ModContent, error) {
+	content := ModContent{Trails: map[string]particles.ParticlePreset{}}
+	err := content.loadThemes(mods)
+	if trailsErr := content.loadTrails(mods); trailsErr != nil {
+		err = trailsErr
+	}
+	return content, err
+}
+
+// loadThemes adds the themes of the mods to the ones offered, it returns the last error met
+func (c *ModContent) loadThemes(mods []Mod) error {
+	taken := func(name string) bool {
+		_, err := c.ParseTheme(name)
+		return err == nil
+	}
+	return readModFiles(mods, modThemes, taken, func(name string, data []byte) error {
+		var file themeFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return err
+		}
+		if !validColor(file.Background[:]) || !validColor(file.Markings[:]) {
+			return fmt.Errorf("colors not from 0 to 1")
+		}
+		c.Themes = append(c.Themes, Theme{
+			Name:       name,
+			Background: mgl.Vec3(file.Background),
+			Markings:   mgl.Vec3(file.Markings),
+			Lighting:   file.Lighting,
+		})
+		return nil
+	})
+}
+
+// loadTrails reads the particle presets of the mods, it returns the last error met
+func (c *ModContent) loadTrails(mods []Mod) error {
+	taken := func(name string) bool {
+		_, ok := c.Trails[name]
+		return ok || name == SkinTrail
+	}
+	return readModFiles(mods, modParticles, taken, func(name string, data []byte) error {
+		var file particlesFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return err
+		}
+		preset, err := file.preset()
+		if err != nil {
+			return err
+		}
+		c.Trails[name] = preset
+		return nil
+	})
+}
+
+// TrailNames returns the trails the ball can leave: the one of the skins, then the particle presets by name
+func (c ModContent) TrailNames() []string {
+	var names []string
+	for name := range c.Trails {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{SkinTrail}, names...)
+}
+
+// SetTrail changes the trail left by the ball, the one of the skin of the last hitter when unknown
+func (g *Game) SetTrail(name string) {
+	g.trail = name
+	if g.initialized {
+		g.applySkins()
+	}
+}
+
+// validColor tells if the components of a color are from 0 to 1
+func validColor(components []float32) bool {
+	for _, c := range components {
+		if c < 0 || c > 1 {
+			return false
+		}
+	}
+	return true
+}
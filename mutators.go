@@ -0,0 +1,140 @@
+package pong
+
+import (
+	"fmt"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/level"
+)
+
+// Mutators are modifiers changing the rules of the matches, any combination of them can be on
+type Mutators uint16
+
+// The mutators, in the order they are listed
+const (
+	MutatorBigBall      Mutators = 1 << iota // The ball is twice as big
+	MutatorTinyPaddles                       // The paddles are half as tall
+	MutatorDoubleSpeed                       // The ball and the paddles move twice as fast
+	MutatorNoWalls                           // The ball leaving the top of the court comes back from the bottom, and the other way around
+	MutatorFogOfWar                          // Each player only sees their half of the court clearly
+	MutatorGravityWells                      // The players can place a gravity well on the half of the opponent now and then
+	MutatorPowerUps                          // Power-ups appear in the middle of the court now and then, for the ball to pick up
+)
+
+var mutatorNames = []string{"big ball", "tiny paddles", "double speed", "no walls", "fog of war", "gravity wells", "power-ups"}
+
+var (
+	bigBallScale     = float32(2)   // Radius of the ball with the big ball mutator
+	tinyPaddleScale  = float32(0.5) // Height of the paddles with the tiny paddles mutator
+	doubleSpeedScale = float32(2)   // Speed of the ball and the paddles with the double speed mutator
+)
+
+// MutatorNames returns the names of the mutators, in the order of their bits
+func MutatorNames() []string {
+	return append([]string(nil), mutatorNames...)
+}
+
+// ParseMutators returns the mutators with the given names
+func ParseMutators(names []string) (Mutators, error) {
+	var mutators Mutators
+	for _, name := range names {
+		found := false
+		for i, mutatorName := range mutatorNames {
+			if strings.EqualFold(name, mutatorName) {
+				mutators |= 1 << uint(i)
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown mutator %q, expected one of %v", name, strings.Join(mutatorNames, ", "))
+		}
+	}
+	return mutators, nil
+}
+
+// Names returns the names of the mutators that are on
+func (m Mutators) Names() []string {
+	var names []string
+	for i, name := range mutatorNames {
+		if m&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// String lists the mutators that are on, "none" when they are all off
+func (m Mutators) String() string {
+	if m == 0 {
+		return "none"
+	}
+	return strings.Join(m.Names(), ", ")
+}
+
+// rules are the tuning of the simulation the mutators change
+type rules struct {
+	paddleSize     mgl.Vec2
+	paddleVelocity float32
+	ballRadius     float32
+	ballVelocity   mgl.Vec2     // Velocity of the serves
+	ballMaxSpeed   float32      // Fastest the ball goes, so it can't go through the paddles
+	wrap           bool         // The ball goes through the top and the bottom of the court instead of bouncing
+	fog            bool         // The half of the court the ball is leaving is hidden
+	doubles        bool         // Two paddles per side, not a mutator but a mode
+	wells          bool         // The players can place gravity wells
+	powerUps       bool         // Power-ups appear on the court
+	level          *level.Level // Layout of the court, not a mutator either
+}
+
+// rules returns the tuning of the matches played with the mutators
+func (m Mutators) rules() rules {
+	r := rules{
+		paddleSize:     paddleSize,
+		paddleVelocity: paddleVelocity,
+		ballRadius:     ballRadius,
+		ballVelocity:   initialBallVelocity,
+		wrap:           m&MutatorNoWalls != 0,
+		fog:            m&MutatorFogOfWar != 0,
+		wells:          m&MutatorGravityWells != 0,
+		powerUps:       m&MutatorPowerUps != 0,
+	}
+	if m&MutatorBigBall != 0 {
+		r.ballRadius *= bigBallScale
+	}
+	if m&MutatorTinyPaddles != 0 {
+		r.paddleSize[1] *= tinyPaddleScale
+	}
+	if m&MutatorDoubleSpeed != 0 {
+		r.paddleVelocity *= doubleSpeedScale
+		r.ballVelocity = r.ballVelocity.Mul(doubleSpeedScale)
+	}
+	r.ballMaxSpeed = r.ballVelocity.Len() * ballMaxSpeed
+	return r
+}
+
+// SetMutators changes the mutators of the matches from the next one, the menu shows them at once
+func (g *Game) SetMutators(mutators Mutators) {
+	g.mutators = mutators
+	if g.state == GameMenu {
+		g.applyRules(mutators.rules())
+		g.resetObjects()
+	}
+}
+
+// Mutators returns the mutators of the matches
+func (g *Game) Mutators() Mutators {
+	return g.mutators
+}
+
+// applyRules sizes the paddles and the ball as the rules want them
+func (g *Game) applyRules(r rules) {
+	g.rules = r
+	g.paddle1.size = r.paddleSize
+	g.paddle2.size = r.paddleSize
+	g.paddle3.size = r.paddleSize
+	g.paddle4.size = r.paddleSize
+	g.ball.radius = r.ballRadius
+	g.ball.size = mgl.Vec2{r.ballRadius * 2, r.ballRadius * 2}
+	g.ball.wrap = r.wrap
+}
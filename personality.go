@@ -0,0 +1,295 @@
+package pong
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/bot"
+	"github.com/lucatironi/go-pong/pkg/level"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+// PersonalitiesDir is where the AI personalities are loaded from, one <name>.json each
+const PersonalitiesDir = "./personalities"
+
+var (
+	opponentDeadZone = float32(10) // Distance from the target the opponent stops moving at
+	adaptScoreRange  = 4.0         // Point lead of the opponent making it play the weakest it can
+	adaptRally       = 4.0         // Paddle hits per point of a close rally, the shorter ones push the adjustment further
+	adaptRate        = 0.5         // Fraction of the way to the target skill the adjustment moves per second
+	adaptReaction    = 0.75        // Fraction of the reaction time added at the weakest skill, removed at the strongest
+	adaptSpeed       = 0.3         // Fraction of the speed removed at the weakest skill, added at the strongest
+	adaptMinSpeed    = float32(0.3)
+	interceptBounces = 16 // Bounces off the top and the bottom followed to predict where the ball reaches a paddle
+)
+
+// Personality is how a computer opponent plays
+type Personality struct {
+	Name     string  `json:"-"`
+	AimError float32 `json:"aim_error"` // Maximum distance from the ball center it aims at
+	Reaction float64 `json:"reaction"`  // Seconds it takes to react to a shot
+	Speed    float32 `json:"speed"`     // Fraction of the updates it moves the paddle on
+	Edge     float32 `json:"edge"`      // Fraction of the half paddle it hits the ball off center with, angling the shots
+	Recenter bool    `json:"recenter"`  // Goes back to the middle while the ball moves away
+	Predict  bool    `json:"predict"`   // Aims where the ball will reach the paddle, bouncing off the walls, not at the ball
+}
+
+// LoadPersonalities reads the personalities found in the directory sorted by name, it returns
+// the ones read along with the last error met
+func LoadPersonalities(dir string) ([]Personality, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var personalities []Personality
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		personality := Personality{
+			Name:  strings.TrimSuffix(file.Name(), ".json"),
+			Speed: 1,
+		}
+		data, readErr := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if readErr == nil {
+			readErr = json.Unmarshal(data, &personality)
+		}
+		if readErr != nil {
+			err = fmt.Errorf("failed to load personality %v: %v", personality.Name, readErr)
+			continue
+		}
+		personalities = append(personalities, personality)
+	}
+	sort.Slice(personalities, func(i, j int) bool { return personalities[i].Name < personalities[j].Name })
+	return personalities, err
+}
+
+// Opponent plays a paddle through the input like a player would, so the replays record its moves
+type Opponent struct {
+	personality Personality
+	player      int
+	aim         float32 // Distance from the ball center it aims at for the current shot
+	approaching bool    // The ball is moving towards the paddle
+	reaction    float64 // Seconds left before it reacts to the current shot
+	moveBudget  float32 // Fraction of a move carried over to the next update
+	adaptive    bool    // Adjusts its skill to keep the match close
+	skill       float64 // Adjustment of the personality, from -1 the weakest to 1 the strongest
+	rallyHits   int     // Paddle hits in the current rally
+	rally       float64 // Average paddle hits per point, of the recent points
+	scores      [2]int  // Scores at the previous update, to spot the points
+	random      *rand.Rand
+	difficulty  Difficulty       // Difficulty of the single player mode it plays at, if single
+	single      bool             // Plays the single player mode rather than with a personality of its own
+	controller  bot.AIController // Plays instead of the personality, loaded from a plugin
+	tick        uint64           // Updates given to the controller
+	failed      bool             // The controller panicked, it isn't asked anymore
+}
+
+// NewOpponent returns an opponent with the personality playing the paddle of the player, aiming
+// with the random numbers, usually the ones of the game
+func NewOpponent(personality Personality, player int, random *rand.Rand) *Opponent {
+	return &Opponent{personality: personality, player: player, random: random}
+}
+
+// NewDifficultyOpponent returns the computer of the single player mode playing the paddle of the
+// player at the difficulty, reacting to the shots the seconds late
+func NewDifficultyOpponent(difficulty Difficulty, player int, reaction float64, random *rand.Rand) *Opponent {
+	o := NewOpponent(difficulty.Personality(reaction), player, random)
+	o.difficulty, o.single = difficulty, true
+	return o
+}
+
+// Difficulty returns the difficulty the opponent plays the single player mode at, false when it
+// plays with a personality of its own
+func (o *Opponent) Difficulty() (Difficulty, bool) {
+	return o.difficulty, o.single
+}
+
+// Name returns the name of the personality of the opponent
+func (o *Opponent) Name() string {
+	return o.personality.Name
+}
+
+// SetAdaptive makes the opponent play weaker while it leads and stronger while it trails, smoothly
+func (o *Opponent) SetAdaptive(adaptive bool) {
+	o.adaptive = adaptive
+	o.skill = 0
+	o.rally = adaptRally
+}
+
+// Adjustment describes the skill of the opponent and the parameters it plays with
+func (o *Opponent) Adjustment() string {
+	p := o.adjusted()
+	return fmt.Sprintf("skill %+.2f reaction %.3fs speed %.2f rally %.1f", o.skill, p.Reaction, p.Speed, o.rally)
+}
+
+// adjusted returns the personality with the reaction and the speed adjusted to the skill
+func (o *Opponent) adjusted() Personality {
+	p := o.personality
+	if !o.adaptive {
+		return p
+	}
+	p.Reaction *= 1 - adaptReaction*o.skill
+	p.Speed *= float32(1 + adaptSpeed*o.skill)
+	if p.Speed > 1 {
+		p.Speed = 1
+	} else if p.Speed < adaptMinSpeed {
+		p.Speed = adaptMinSpeed
+	}
+	return p
+}
+
+// adapt follows the score and the length of the rallies, moving the skill towards the one that
+// should bring the match back to a close one
+func (o *Opponent) adapt(state State, deltaTime float64) {
+	scores := [2]int{state.Score1, state.Score2}
+	if o.player == 2 {
+		scores = [2]int{state.Score2, state.Score1}
+	}
+	if scores != o.scores {
+		if scores[0]+scores[1] > o.scores[0]+o.scores[1] {
+			o.rally += (float64(o.rallyHits) - o.rally) * 0.3
+		}
+		o.rallyHits = 0
+		o.scores = scores
+	}
+	// Leading with short rallies is a one sided match
+	target := -float64(scores[0]-scores[1]) / adaptScoreRange
+	if o.rally < adaptRally {
+		target *= 2 - o.rally/adaptRally
+	}
+	target = math.Max(-1, math.Min(1, target))
+	o.skill += (target - o.skill) * math.Min(1, adaptRate*deltaTime)
+}
+
+// Control sets the input of the paddle of the opponent following the state of a match
+func (o *Opponent) Control(state State, input *Input, deltaTime float64) {
+	paddle := state.Paddle1
+	up, down := &input.Paddle1Up, &input.Paddle1Down
+	if o.player == 2 {
+		paddle = state.Paddle2
+		up, down = &input.Paddle2Up, &input.Paddle2Down
+	}
+	*up, *down = false, false
+	if o.controller != nil {
+		o.controlPlugin(state, input)
+		return
+	}
+	if state.Phase != GameActive {
+		return
+	}
+	if o.adaptive {
+		o.adapt(state, deltaTime)
+	}
+	p := o.adjusted()
+	approaching := (state.BallVelocity.X() < 0) == (o.player == 1)
+	if approaching != o.approaching {
+		o.rallyHits++
+	}
+	if approaching && !o.approaching {
+		// A new shot: take the time to react, then aim somewhere around the ball
+		o.reaction = p.Reaction
+		o.aim = (o.random.Float32()*2 - 1) * p.AimError
+		if edge := p.Edge * state.PaddleSizes[o.player-1].Y() / 2; o.random.Intn(2) == 0 {
+			o.aim += edge
+		} else {
+			o.aim -= edge
+		}
+	}
+	o.approaching = approaching
+	if o.reaction > 0 {
+		o.reaction -= deltaTime
+		return
+	}
+	var target float32
+	switch {
+	case approaching && p.Predict:
+		target = o.intercept(state, paddle) + o.aim
+	case approaching:
+		target = state.Ball.Y() + o.aim
+	case p.Recenter:
+		target = VirtualHeight / 2
+	default:
+		return
+	}
+	// Move on a share of the updates as set by the speed
+	o.moveBudget += p.Speed
+	if o.moveBudget < 1 {
+		return
+	}
+	o.moveBudget--
+	if target < paddle.Y()-opponentDeadZone {
+		*up = true
+	} else if target > paddle.Y()+opponentDeadZone {
+		*down = true
+	}
+}
+
+// intercept returns the height the ball will cross the front of the paddle at, bouncing off the top
+// and the bottom of the court, the middle of the court while the ball moves away. A wall or an
+// obstacle of the level on the way would change the path, so it returns the height of the ball then
+func (o *Opponent) intercept(state State, paddle mgl.Vec2) float32 {
+	size, radius := state.PaddleSizes[o.player-1], state.BallRadius
+	ball, velocity := state.Ball, state.BallVelocity
+	front := paddle.X() - size.X()/2 - radius
+	if o.player == 1 {
+		front = paddle.X() + size.X()/2 + radius
+	}
+	if velocity.X() == 0 || (front-ball.X())*velocity.X() < 0 {
+		return VirtualHeight / 2
+	}
+	// Follow the path from a bounce off the top or the bottom to the next one
+	for bounce := 0; bounce < interceptBounces; bounce++ {
+		time := (front - ball.X()) / velocity.X()
+		wall := VirtualHeight - radius
+		if velocity.Y() < 0 {
+			wall = radius
+		}
+		bounces := velocity.Y() != 0 && (wall-ball.Y())/velocity.Y() < time
+		if bounces {
+			time = (wall - ball.Y()) / velocity.Y()
+		}
+		next := ball.Add(velocity.Mul(time))
+		if levelBlocks(state.Level, ball, next, radius) {
+			return state.Ball.Y()
+		}
+		if !bounces {
+			return next.Y()
+		}
+		ball, velocity[1] = next, -velocity.Y()
+	}
+	return ball.Y()
+}
+
+// levelBlocks tells if a ball of the radius meets a wall or an obstacle of the level moving in a
+// straight line between the points, the first one left out as the ball may lean on what it bounced off
+func levelBlocks(l *level.Level, from, to mgl.Vec2, radius float32) bool {
+	if l == nil {
+		return false
+	}
+	steps := int(to.Sub(from).Len()/radius) + 1
+	for step := 1; step <= steps; step++ {
+		ball := physics.Circle{Center: from.Add(to.Sub(from).Mul(float32(step) / float32(steps))), Radius: radius}
+		for _, wall := range l.Walls {
+			if _, ok := physics.CircleAABB(ball, wall.AABB()); ok {
+				return true
+			}
+		}
+		for _, obstacle := range l.Obstacles {
+			if _, ok := physics.CircleCircle(ball, obstacle.Circle()); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+// Package bot lets an external program play a paddle over a socket: the game sends its state on
+// every update and the program answers with the moves, one JSON object per line each way
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+)
+
+// stateBuffer is how many states are kept waiting to be sent, newer ones are dropped when full
+const stateBuffer = 256
+
+// Moves of the paddle a bot can ask for
+const (
+	MoveUp   = "up"
+	MoveDown = "down"
+	MoveStay = "stay"
+)
+
+var errUnknownMove = errors.New("unknown move, expected up, down or stay")
+
+// State is the game as seen by the bot, positions are centers in virtual resolution coordinates
+type State struct {
+	Tick         uint64     `json:"tick"`
+	Phase        string     `json:"phase"`
+	Player       int        `json:"player"` // 1 plays the left paddle, 2 the right one
+	Ball         [2]float32 `json:"ball"`
+	BallVelocity [2]float32 `json:"ball_velocity"` // Per second
+	Paddle       [2]float32 `json:"paddle"`
+	Opponent     [2]float32 `json:"opponent"` // Paddle of the other player
+	Score        [2]int     `json:"score"`    // Points of the bot, then of the other player
+	Court        [2]float32 `json:"court"`    // Width and height
+}
+
+// Command is a line sent by the bot, the move lasts until the next command
+type Command struct {
+	Move string `json:"move"`
+}
+
+// reply is sent back to the bot when its command can't be understood
+type reply struct {
+	Error string `json:"error"`
+}
+
+// Server accepts a bot at a time, a new connection replaces the previous one
+type Server struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	conn     net.Conn
+	states   chan State
+	move     string
+}
+
+// Listen opens the socket on the network, "tcp" or "unix", and starts accepting the bots
+func Listen(network, address string) (*Server, error) {
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{listener: listener, move: MoveStay}
+	go s.accept()
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Close stops accepting the bots and disconnects the current one
+func (s *Server) Close() error {
+	s.mutex.Lock()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.mutex.Unlock()
+	return s.listener.Close()
+}
+
+// Send queues the state for the connected bot, it's dropped when there's none or the bot doesn't
+// keep up
+func (s *Server) Send(state State) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.states == nil {
+		return
+	}
+	select {
+	case s.states <- state:
+	default:
+	}
+}
+
+// Move returns the last move asked by the bot, MoveStay without a bot
+func (s *Server) Move() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.move
+}
+
+// accept serves the connections until the listener is closed
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		states := make(chan State, stateBuffer)
+		s.mutex.Lock()
+		if s.conn != nil {
+			s.conn.Close()
+		}
+		s.conn, s.states, s.move = conn, states, MoveStay
+		s.mutex.Unlock()
+		go s.write(conn, states)
+		go s.read(conn, states)
+	}
+}
+
+// write sends the states to the bot until the connection is replaced or lost
+func (s *Server) write(conn net.Conn, states chan State) {
+	enc := json.NewEncoder(conn)
+	for state := range states {
+		if err := enc.Encode(state); err != nil {
+			conn.Close()
+			return
+		}
+	}
+}
+
+// read applies the commands of the bot until the connection is closed, then forgets the bot
+func (s *Server) read(conn net.Conn, states chan State) {
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var command Command
+		err := json.Unmarshal(scanner.Bytes(), &command)
+		if err == nil && command.Move != MoveUp && command.Move != MoveDown && command.Move != MoveStay {
+			err = errUnknownMove
+		}
+		if err != nil {
+			json.NewEncoder(conn).Encode(reply{Error: err.Error()})
+			continue
+		}
+		s.mutex.Lock()
+		if s.conn == conn {
+			s.move = command.Move
+		}
+		s.mutex.Unlock()
+	}
+	conn.Close()
+	s.mutex.Lock()
+	if s.conn == conn {
+		s.conn, s.states, s.move = nil, nil, MoveStay
+	}
+	// Nothing sends to the states of a replaced connection
+	close(states)
+	s.mutex.Unlock()
+}
@@ -0,0 +1,38 @@
+package bot
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// PluginSymbol is the function a Go plugin exports to provide its controller, of type func() AIController
+const PluginSymbol = "NewAIController"
+
+// AIController plays a paddle from inside the game, like the bots over the socket but without a
+// connection: it's given the state on every update and returns the move, one of the moves
+type AIController interface {
+	Move(state State) string
+}
+
+// LoadPlugin opens a Go plugin, built with -buildmode=plugin against the same version of this
+// package, and returns a new controller of the plugin. A plugin is only loaded once, opening it
+// again returns a new controller of the same code
+func LoadPlugin(path string) (AIController, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	symbol, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	newController, ok := symbol.(func() AIController)
+	if !ok {
+		return nil, fmt.Errorf("%v is %T, expected func() bot.AIController", PluginSymbol, symbol)
+	}
+	controller := newController()
+	if controller == nil {
+		return nil, fmt.Errorf("%v returned no controller", PluginSymbol)
+	}
+	return controller, nil
+}
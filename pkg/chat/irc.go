@@ -0,0 +1,105 @@
+// Package chat is a minimal IRC client, enough to read the chat of a Twitch channel
+package chat
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// TwitchAddress is the IRC server of the Twitch chat
+const TwitchAddress = "irc.chat.twitch.tv:6667"
+
+// AnonymousNick reads the Twitch chat without logging in
+const AnonymousNick = "justinfan12345"
+
+// messageBuffer is how many messages are kept waiting to be read, newer ones are dropped when full
+const messageBuffer = 256
+
+// Message is a message sent to the channel
+type Message struct {
+	User string
+	Text string
+}
+
+// Client is a connection to an IRC server joined to a channel
+type Client struct {
+	conn     net.Conn
+	messages chan Message
+}
+
+// Dial connects to the server and joins the channel, the password is only sent when not empty
+func Dial(address, nick, password, channel string) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", address, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{
+		conn:     conn,
+		messages: make(chan Message, messageBuffer),
+	}
+	if password != "" {
+		client.send("PASS %v", password)
+	}
+	client.send("NICK %v", nick)
+	client.send("JOIN #%v", strings.TrimPrefix(strings.ToLower(channel), "#"))
+	go client.read()
+	return client, nil
+}
+
+// Messages returns the messages of the channel, it's closed when the connection is lost
+func (c *Client) Messages() <-chan Message {
+	return c.messages
+}
+
+// Close disconnects from the server
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) send(format string, argv ...interface{}) {
+	fmt.Fprintf(c.conn, format+"\r\n", argv...)
+}
+
+// read parses the lines sent by the server until the connection is closed
+func (c *Client) read() {
+	defer close(c.messages)
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "PING") {
+			c.send("PONG%v", strings.TrimPrefix(line, "PING"))
+			continue
+		}
+		message, ok := parsePrivmsg(line)
+		if !ok {
+			continue
+		}
+		select {
+		case c.messages <- message:
+		default:
+			// Nobody is keeping up with the chat, drop the message
+		}
+	}
+}
+
+// parsePrivmsg parses a ":nick!user@host PRIVMSG #channel :text" line
+func parsePrivmsg(line string) (Message, bool) {
+	// Skip the IRCv3 tags
+	if strings.HasPrefix(line, "@") {
+		if i := strings.Index(line, " "); i >= 0 {
+			line = line[i+1:]
+		}
+	}
+	parts := strings.SplitN(line, " ", 4)
+	if len(parts) < 4 || parts[1] != "PRIVMSG" || !strings.HasPrefix(parts[0], ":") {
+		return Message{}, false
+	}
+	user := strings.TrimPrefix(parts[0], ":")
+	if i := strings.Index(user, "!"); i >= 0 {
+		user = user[:i]
+	}
+	return Message{User: user, Text: strings.TrimPrefix(parts[3], ":")}, true
+}
@@ -0,0 +1,71 @@
+// Package input tracks the state of the input devices fed by the window events
+package input
+
+import "github.com/go-gl/glfw/v3.2/glfw"
+
+// Keyboard holds the state of the keys, updated by the window key events
+type Keyboard struct {
+	keys      map[glfw.Key]bool
+	processed map[glfw.Key]bool // Keys already handled since they were pressed
+	last      glfw.Key          // Last key pressed, for LastPressed
+	hasLast   bool
+	typed     []rune // Characters typed since the previous call to Typed
+}
+
+// NewKeyboard returns a keyboard with all the keys released
+func NewKeyboard() *Keyboard {
+	return &Keyboard{
+		keys:      make(map[glfw.Key]bool),
+		processed: make(map[glfw.Key]bool),
+	}
+}
+
+// HandleKey updates the state of a key from a window key event
+func (k *Keyboard) HandleKey(key glfw.Key, action glfw.Action) {
+	if action == glfw.Press {
+		k.keys[key] = true
+		k.last = key
+		k.hasLast = true
+	} else if action == glfw.Release {
+		k.keys[key] = false
+		k.processed[key] = false
+	}
+}
+
+// HandleChar records a character typed, from a window char event
+func (k *Keyboard) HandleChar(char rune) {
+	k.typed = append(k.typed, char)
+}
+
+// Typed returns the text typed since the previous call
+func (k *Keyboard) Typed() string {
+	text := string(k.typed)
+	k.typed = k.typed[:0]
+	return text
+}
+
+// Down tells if the key is held down
+func (k *Keyboard) Down(key glfw.Key) bool {
+	return k.keys[key]
+}
+
+// Pressed tells if the key is down and hasn't been handled since it was pressed,
+// it marks the key as handled so a single press triggers a single action
+func (k *Keyboard) Pressed(key glfw.Key) bool {
+	if !k.keys[key] || k.processed[key] {
+		return false
+	}
+	k.processed[key] = true
+	return true
+}
+
+// LastPressed returns the last key pressed since the previous call, if any: it marks the key
+// as handled, so it's meant to capture any key, like when rebinding the controls
+func (k *Keyboard) LastPressed() (glfw.Key, bool) {
+	if !k.hasLast {
+		return 0, false
+	}
+	k.hasLast = false
+	k.processed[k.last] = true
+	return k.last, true
+}
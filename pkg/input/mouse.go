@@ -0,0 +1,62 @@
+package input
+
+import "github.com/go-gl/glfw/v3.2/glfw"
+
+// Mouse holds the state of the cursor and of the buttons, updated by the window events
+type Mouse struct {
+	x, y      float64 // Cursor position in window coordinates, from the top left corner
+	moved     bool    // The cursor moved since Moved was last called
+	buttons   map[glfw.MouseButton]bool
+	processed map[glfw.MouseButton]bool // Buttons already handled since they were pressed
+}
+
+// NewMouse returns a mouse with all the buttons released
+func NewMouse() *Mouse {
+	return &Mouse{
+		buttons:   make(map[glfw.MouseButton]bool),
+		processed: make(map[glfw.MouseButton]bool),
+	}
+}
+
+// HandleCursor updates the position of the cursor from a window cursor event
+func (m *Mouse) HandleCursor(x, y float64) {
+	m.x, m.y = x, y
+	m.moved = true
+}
+
+// HandleButton updates the state of a button from a window mouse button event
+func (m *Mouse) HandleButton(button glfw.MouseButton, action glfw.Action) {
+	if action == glfw.Press {
+		m.buttons[button] = true
+	} else if action == glfw.Release {
+		m.buttons[button] = false
+		m.processed[button] = false
+	}
+}
+
+// Position returns the cursor position in window coordinates
+func (m *Mouse) Position() (float64, float64) {
+	return m.x, m.y
+}
+
+// Moved tells if the cursor moved since the previous call
+func (m *Mouse) Moved() bool {
+	moved := m.moved
+	m.moved = false
+	return moved
+}
+
+// Down tells if the button is held down
+func (m *Mouse) Down(button glfw.MouseButton) bool {
+	return m.buttons[button]
+}
+
+// Pressed tells if the button is down and hasn't been handled since it was pressed,
+// it marks the button as handled so a single click triggers a single action
+func (m *Mouse) Pressed(button glfw.MouseButton) bool {
+	if !m.buttons[button] || m.processed[button] {
+		return false
+	}
+	m.processed[button] = true
+	return true
+}
@@ -0,0 +1,152 @@
+// Package level describes the layouts of the court: walls and obstacles the ball bounces off,
+// the size of the goals and where the ball and the paddles start. It has no OpenGL nor GLFW
+// dependencies so the levels can be played headless
+package level
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+// Level is a layout of the court, coordinates are in virtual resolution pixels from the top left corner
+type Level struct {
+	Name      string   `json:"name,omitempty"`      // Name of the file the level is loaded from
+	Walls     []Rect   `json:"walls,omitempty"`     // Blocks the ball bounces off
+	Obstacles []Circle `json:"obstacles,omitempty"` // Round bumpers the ball bounces off
+	Goals     [2]Goal  `json:"goals"`               // Openings of the left and the right end of the court
+	Spawns    Spawns   `json:"spawns"`
+}
+
+// Rect is a block, from its top left corner
+type Rect struct {
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+// AABB returns the box of the block
+func (r Rect) AABB() physics.AABB {
+	return physics.AABB{Position: mgl.Vec2{r.X, r.Y}, Size: mgl.Vec2{r.Width, r.Height}}
+}
+
+// Circle is a round bumper, from its center
+type Circle struct {
+	X      float32 `json:"x"`
+	Y      float32 `json:"y"`
+	Radius float32 `json:"radius"`
+}
+
+// Circle returns the shape of the bumper
+func (c Circle) Circle() physics.Circle {
+	return physics.Circle{Center: mgl.Vec2{c.X, c.Y}, Radius: c.Radius}
+}
+
+// Goal is the opening of an end of the court the ball scores through, the rest of the end
+// is a wall. A goal without a bottom is as tall as the court
+type Goal struct {
+	Top    float32 `json:"top"`
+	Bottom float32 `json:"bottom"`
+}
+
+// Span returns the top and the bottom of the goal in a court of the height
+func (g Goal) Span(height float32) (float32, float32) {
+	if g.Bottom == 0 {
+		return 0, height
+	}
+	return g.Top, g.Bottom
+}
+
+// Spawns are where the ball and the paddles start, the classic places when missing
+type Spawns struct {
+	Ball    *Point    `json:"ball,omitempty"`    // Center of the ball at the serves
+	Paddles [2]*Point `json:"paddles,omitempty"` // Centers of the left and the right paddle
+}
+
+// Point is a position in the court
+type Point struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// Vec2 returns the position as a vector
+func (p Point) Vec2() mgl.Vec2 {
+	return mgl.Vec2{p.X, p.Y}
+}
+
+// Parse reads the JSON description of a level, the name replaces the one in the description unless empty
+func Parse(name string, data []byte) (*Level, error) {
+	l := &Level{}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, err
+	}
+	if name != "" {
+		l.Name = name
+	}
+	return l, nil
+}
+
+// Validate checks the level fits a court of the size
+func (l *Level) Validate(width, height float32) error {
+	for i, wall := range l.Walls {
+		if wall.Width <= 0 || wall.Height <= 0 || wall.X < 0 || wall.Y < 0 || wall.X+wall.Width > width || wall.Y+wall.Height > height {
+			return fmt.Errorf("wall %v outside of the court or empty", i+1)
+		}
+	}
+	for i, obstacle := range l.Obstacles {
+		if obstacle.Radius <= 0 || obstacle.X < 0 || obstacle.Y < 0 || obstacle.X > width || obstacle.Y > height {
+			return fmt.Errorf("obstacle %v outside of the court or empty", i+1)
+		}
+	}
+	for i, goal := range l.Goals {
+		top, bottom := goal.Span(height)
+		if top < 0 || bottom > height || top >= bottom {
+			return fmt.Errorf("goal %v: top %v and bottom %v not from 0 to %v", i+1, top, bottom, height)
+		}
+	}
+	for _, spawn := range append([]*Point{l.Spawns.Ball}, l.Spawns.Paddles[:]...) {
+		if spawn != nil && (spawn.X < 0 || spawn.Y < 0 || spawn.X > width || spawn.Y > height) {
+			return fmt.Errorf("spawn point %v, %v outside of the court", spawn.X, spawn.Y)
+		}
+	}
+	return nil
+}
+
+// Encode returns the JSON description of the level, parsing it gives back the same level
+func (l *Level) Encode() []byte {
+	data, _ := json.Marshal(l)
+	return data
+}
+
+// Copy returns a copy of the level sharing nothing with it
+func (l *Level) Copy() *Level {
+	c := *l
+	c.Walls = append([]Rect(nil), l.Walls...)
+	c.Obstacles = append([]Circle(nil), l.Obstacles...)
+	if l.Spawns.Ball != nil {
+		ball := *l.Spawns.Ball
+		c.Spawns.Ball = &ball
+	}
+	for i, paddle := range l.Spawns.Paddles {
+		if paddle != nil {
+			spawn := *paddle
+			c.Spawns.Paddles[i] = &spawn
+		}
+	}
+	return &c
+}
+
+// Save writes the JSON description of the level to the file, without the name given by the file
+func (l *Level) Save(file string) error {
+	c := *l
+	c.Name = ""
+	data, err := json.MarshalIndent(&c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(data, '\n'), 0644)
+}
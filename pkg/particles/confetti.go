@@ -0,0 +1,87 @@
+package particles
+
+import (
+	"math"
+	"math/rand"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+var (
+	confettiGravity = mgl.Vec2{0, 120}
+	confettiColors  = []mgl.Vec4{
+		{1.0, 0.3, 0.3, 1.0},
+		{0.3, 0.9, 0.4, 1.0},
+		{0.3, 0.5, 1.0, 1.0},
+		{1.0, 0.9, 0.3, 1.0},
+		{1.0, 0.5, 0.9, 1.0},
+		{0.4, 0.9, 1.0, 1.0},
+	}
+	// confettiPiece is a single rectangle of paper falling from above the top edge, its color is picked for each piece
+	confettiPiece = ParticlePreset{
+		Count: 1, SpeedMin: 60, SpeedMax: 180, Angle: math.Pi / 2, Spread: 0.8,
+		Life: 8.0, Fade: 0.1, ColorJitter: 0.05,
+		Size: mgl.Vec2{16, 8}, Spin: 2 * math.Pi, Flutter: 120,
+	}
+)
+
+// Confetti rains spinning and fluttering pieces of colored paper over the screen
+type Confetti struct {
+	particles     *ParticleGenerator
+	active        bool
+	spawnTimer    float64 // Time accumulated since the last piece was spawned
+	width, height float32
+}
+
+// NewConfetti returns a confetti rain using a generator of the given amount of particles
+func NewConfetti(shader *render.Shader, amount int, width, height float32, random *rand.Rand) *Confetti {
+	particles := NewParticleGenerator(shader, amount, random)
+	particles.Gravity = confettiGravity
+	// Paper doesn't glow
+	particles.Additive = false
+
+	return &Confetti{
+		particles: particles,
+		width:     width,
+		height:    height,
+	}
+}
+
+// Start starts spawning confetti
+func (c *Confetti) Start() {
+	c.active = true
+	c.spawnTimer = 0.0
+}
+
+// Stop stops spawning confetti and removes all the pieces
+func (c *Confetti) Stop() {
+	c.active = false
+	c.particles.Reset()
+}
+
+// Update spawns new pieces while active and moves the falling ones
+func (c *Confetti) Update(deltaTime float64) {
+	if c.active && c.particles.amount > 0 {
+		// Spawn as many pieces as the generator can keep alive at the same time
+		interval := confettiPiece.Life / float64(c.particles.amount)
+		c.spawnTimer += deltaTime
+		for c.spawnTimer >= interval {
+			c.spawnTimer -= interval
+			piece := confettiPiece
+			piece.Color = confettiColors[c.particles.random.Intn(len(confettiColors))]
+			c.particles.EmitPreset(piece, mgl.Vec2{c.particles.random.Float32() * c.width, -piece.Size.Y() * 2})
+		}
+	}
+	c.particles.UpdateParticles(deltaTime)
+}
+
+// Draw draws the confetti particles
+func (c *Confetti) Draw() {
+	c.particles.Draw()
+}
+
+// Delete releases the particles buffers
+func (c *Confetti) Delete() {
+	c.particles.Delete()
+}
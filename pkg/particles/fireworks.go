@@ -0,0 +1,120 @@
+package particles
+
+import (
+	"math"
+	"math/rand"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+const (
+	fireworksPerSequence = 5   // Fireworks launched before the sequence restarts
+	fireworksInterval    = 0.6 // Time between two launches, in seconds
+	fireworksFlight      = 0.9 // Time from the launch to the explosion, in seconds
+	fireworksTrailSteps  = 10  // Number of trail bursts left by a rising firework
+)
+
+var (
+	fireworksGravity = mgl.Vec2{0, 300}
+	fireworksColors  = []mgl.Vec4{
+		{1.0, 0.3, 0.3, 1.0},
+		{0.3, 1.0, 0.4, 1.0},
+		{0.3, 0.5, 1.0, 1.0},
+		{1.0, 0.9, 0.3, 1.0},
+		{1.0, 0.4, 1.0, 1.0},
+	}
+	// fireworksLaunch is the trail of sparks left by a rising firework
+	fireworksLaunch = ParticlePreset{
+		Count: 3, SpeedMin: 50, SpeedMax: 150, Angle: math.Pi / 2, Spread: 1.0,
+		Life: 0.4, Fade: 2.5, Color: mgl.Vec4{1.0, 0.8, 0.5, 1.0}, ColorJitter: 0.1,
+	}
+	// fireworksExplode is the burst at the top of the flight, its color is picked for each firework
+	fireworksExplode = ParticlePreset{
+		Count: 80, SpeedMin: 150, SpeedMax: 450, Angle: 0, Spread: 2 * math.Pi,
+		Life: 1.5, Fade: 0.8, ColorJitter: 0.15,
+	}
+	// fireworksFade is the glitter lingering after the explosion
+	fireworksFade = ParticlePreset{
+		Count: 40, SpeedMin: 20, SpeedMax: 120, Angle: 0, Spread: 2 * math.Pi,
+		Life: 2.0, Fade: 0.6, Color: mgl.Vec4{0.9, 0.9, 0.9, 1.0}, ColorJitter: 0.1,
+	}
+)
+
+// Fireworks plays a looping sequence of fireworks, each made of the launch,
+// explode and fade particle presets chained by an effect timeline
+type Fireworks struct {
+	particles     *ParticleGenerator
+	timeline      *EffectTimeline
+	width, height float32
+}
+
+// NewFireworks returns a fireworks sequence launched from the bottom of an area of the given size
+func NewFireworks(shader *render.Shader, amount int, width, height float32, random *rand.Rand) *Fireworks {
+	particles := NewParticleGenerator(shader, amount, random)
+	particles.Gravity = fireworksGravity
+
+	return &Fireworks{
+		particles: particles,
+		timeline:  NewEffectTimeline(),
+		width:     width,
+		height:    height,
+	}
+}
+
+// Start schedules a sequence of fireworks, the sequence restarts when it ends
+func (f *Fireworks) Start() {
+	f.timeline.Reset()
+	for i := 0; i < fireworksPerSequence; i++ {
+		f.schedule(float64(i) * fireworksInterval)
+	}
+	f.timeline.At(fireworksPerSequence*fireworksInterval+fireworksFlight, f.Start)
+}
+
+// Stop removes the scheduled fireworks and all the particles
+func (f *Fireworks) Stop() {
+	f.timeline.Reset()
+	f.particles.Reset()
+}
+
+// Update advances the sequence and the particles
+func (f *Fireworks) Update(deltaTime float64) {
+	f.timeline.Update(deltaTime)
+	f.particles.UpdateParticles(deltaTime)
+}
+
+// Draw draws the fireworks particles
+func (f *Fireworks) Draw() {
+	f.particles.Draw()
+}
+
+// Delete releases the particles buffers
+func (f *Fireworks) Delete() {
+	f.particles.Delete()
+}
+
+// schedule adds a single firework launched at the given time
+func (f *Fireworks) schedule(start float64) {
+	origin := mgl.Vec2{f.width * (0.2 + 0.6*f.particles.random.Float32()), f.height}
+	velocity := mgl.Vec2{(f.particles.random.Float32() - 0.5) * 200, -(800 + f.particles.random.Float32()*300)}
+	explode := fireworksExplode
+	explode.Color = fireworksColors[f.particles.random.Intn(len(fireworksColors))]
+
+	// Launch: the firework rises leaving a trail of sparks
+	for step := 0; step < fireworksTrailSteps; step++ {
+		t := fireworksFlight * float64(step) / fireworksTrailSteps
+		position := fireworkPosition(origin, velocity, t)
+		f.timeline.At(start+t, func() { f.particles.EmitPreset(fireworksLaunch, position) })
+	}
+	// Explode at the top of the flight
+	top := fireworkPosition(origin, velocity, fireworksFlight)
+	f.timeline.At(start+fireworksFlight, func() { f.particles.EmitPreset(explode, top) })
+	// Fade out with some lingering glitter
+	f.timeline.At(start+fireworksFlight+0.4, func() { f.particles.EmitPreset(fireworksFade, top) })
+}
+
+// fireworkPosition returns where a firework launched from origin is after t seconds
+func fireworkPosition(origin, velocity mgl.Vec2, t float64) mgl.Vec2 {
+	time := float32(t)
+	return origin.Add(velocity.Mul(time)).Add(fireworksGravity.Mul(0.5 * time * time))
+}
@@ -0,0 +1,314 @@
+// Package particles holds the particle generator, its presets and the effects built on it
+package particles
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// defaultParticleFade is the alpha lost per second by the particles
+const defaultParticleFade = 2.5
+
+// particleInstanceSize is the number of floats of the per instance vertex data:
+// offset (vec2), size (vec2), rotation (float) and color (vec4)
+const particleInstanceSize = 9
+
+var defaultParticleSize = mgl.Vec2{20, 20}
+
+// Particle handles a particle with a position, velocity, color and life
+type Particle struct {
+	Position        mgl.Vec2
+	Velocity        mgl.Vec2
+	Color           mgl.Vec4
+	Life            float64
+	Fade            float32  // Alpha lost per second
+	Size            mgl.Vec2 // Width and height of the particle quad
+	Rotation        float32  // Rotation around the center of the quad, in radians
+	AngularVelocity float32  // Rotation speed, in radians per second
+	Flutter         float32  // Horizontal sway speed, following the rotation
+}
+
+func newParticle(position, velocity mgl.Vec2, color mgl.Vec4, life float64) *Particle {
+	return &Particle{
+		Position: position,
+		Velocity: velocity,
+		Color:    color,
+		Life:     life,
+		Fade:     defaultParticleFade,
+		Size:     defaultParticleSize,
+	}
+}
+
+// EmitterSource is anything a particle generator can follow while spawning particles
+type EmitterSource interface {
+	Position() mgl.Vec2
+	Velocity() mgl.Vec2
+}
+
+// ParticlePreset describes a burst of particles emitted at once from the same position
+type ParticlePreset struct {
+	Count              int      // Number of particles emitted
+	SpeedMin, SpeedMax float32  // Range of the initial speed of the particles
+	Angle, Spread      float32  // Direction of the burst and its angular spread, in radians
+	Life               float64  // Life of the particles, in seconds
+	Fade               float32  // Alpha lost per second by the particles
+	Color              mgl.Vec4 // Color of the particles
+	ColorJitter        float32  // Maximum random variation of each color component
+	Size               mgl.Vec2 // Size of the particles, the default size is used when zero
+	Spin               float32  // Maximum angular velocity, in either direction
+	Flutter            float32  // Horizontal sway speed of the particles
+}
+
+// ParticleGenerator handles the generation and life cycle of particles, a generator
+// with an amount of zero is disabled and never spawns nor draws particles
+type ParticleGenerator struct {
+	particles        []*Particle
+	amount           int
+	lastUsedParticle int             // Index where the search for an unused particle starts
+	Gravity          mgl.Vec2        // Acceleration applied to all the particles
+	Additive         bool            // Use additive blending to give a 'glow' effect
+	Trail            *ParticlePreset // Look of the particles spawned by Update, a grey trail when nil
+	shader           *render.Shader
+	quadVao          uint32
+	quadVbo          uint32
+	instanceVbo      uint32    // Per particle data
+	instanceData     []float32 // Per particle data of the alive particles, reused every frame
+	random           *rand.Rand
+}
+
+// NewParticleGenerator returns a generator of the given amount of particles drawn with the shader,
+// randomly spread by the random numbers
+func NewParticleGenerator(shader *render.Shader, amount int, random *rand.Rand) *ParticleGenerator {
+	generator := &ParticleGenerator{
+		amount:   amount,
+		shader:   shader,
+		Additive: true,
+		random:   random,
+	}
+	generator.Init()
+
+	return generator
+}
+
+// Init initializes the generator
+func (pg *ParticleGenerator) Init() {
+	// Configure VAO/VBO
+	vertices := []float32{
+		0.0, 1.0,
+		1.0, 0.0,
+		0.0, 0.0,
+
+		0.0, 1.0,
+		1.0, 1.0,
+		1.0, 0.0,
+	}
+
+	gl.GenVertexArrays(1, &pg.quadVao)
+	render.Objects.Track(render.VertexArrayObject, pg.quadVao)
+	gl.GenBuffers(1, &pg.quadVbo)
+	render.Objects.Track(render.BufferObject, pg.quadVbo)
+	gl.BindVertexArray(pg.quadVao)
+	// Fill mesh buffer
+	gl.BindBuffer(gl.ARRAY_BUFFER, pg.quadVbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 4*len(vertices), gl.Ptr(vertices), gl.STATIC_DRAW)
+	// Set mesh attributes
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 2, gl.FLOAT, false, 0, nil)
+
+	// Configure the per instance buffer, filled with the alive particles when drawing
+	gl.GenBuffers(1, &pg.instanceVbo)
+	render.Objects.Track(render.BufferObject, pg.instanceVbo)
+	gl.BindBuffer(gl.ARRAY_BUFFER, pg.instanceVbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 4*particleInstanceSize*pg.amount, nil, gl.STREAM_DRAW)
+	stride := int32(4 * particleInstanceSize)
+	gl.EnableVertexAttribArray(1) // offset
+	gl.VertexAttribPointer(1, 2, gl.FLOAT, false, stride, gl.PtrOffset(0))
+	gl.VertexAttribDivisor(1, 1)
+	gl.EnableVertexAttribArray(2) // size
+	gl.VertexAttribPointer(2, 2, gl.FLOAT, false, stride, gl.PtrOffset(2*4))
+	gl.VertexAttribDivisor(2, 1)
+	gl.EnableVertexAttribArray(3) // rotation
+	gl.VertexAttribPointer(3, 1, gl.FLOAT, false, stride, gl.PtrOffset(4*4))
+	gl.VertexAttribDivisor(3, 1)
+	gl.EnableVertexAttribArray(4) // color
+	gl.VertexAttribPointer(4, 4, gl.FLOAT, false, stride, gl.PtrOffset(5*4))
+	gl.VertexAttribDivisor(4, 1)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+
+	pg.instanceData = make([]float32, 0, particleInstanceSize*pg.amount)
+	// Create pg.amount default particle instances
+	for i := 0; i < pg.amount; i++ {
+		pg.particles = append(pg.particles, newParticle(mgl.Vec2{0, 0}, mgl.Vec2{0, 0}, mgl.Vec4{1, 1, 1, 1}, 0.0))
+	}
+	render.CheckError("ParticleGenerator.Init")
+}
+
+// Update updates the particles managed by the generator, spawning new ones at the position of the source
+func (pg *ParticleGenerator) Update(deltaTime float64, source EmitterSource, newParticles int, offset mgl.Vec2) {
+	if pg.amount == 0 {
+		return
+	}
+	// Add new particles
+	for i := 0; i < newParticles; i++ {
+		unusedParticle := pg.firstUnusedParticle()
+		pg.respawnParticle(pg.particles[unusedParticle], source, offset)
+	}
+	pg.UpdateParticles(deltaTime)
+}
+
+// UpdateParticles moves and fades the alive particles without spawning new ones
+func (pg *ParticleGenerator) UpdateParticles(deltaTime float64) {
+	for i := 0; i < pg.amount; i++ {
+		p := pg.particles[i]
+		p.Life -= deltaTime // reduce life
+		if p.Life > 0.0 {   // particle is alive, thus update
+			p.Velocity = p.Velocity.Add(pg.Gravity.Mul(float32(deltaTime)))
+			p.Position = p.Position.Add(p.Velocity.Mul(float32(deltaTime)))
+			p.Color[3] -= float32(deltaTime) * p.Fade
+			p.Rotation += p.AngularVelocity * float32(deltaTime)
+			// Sway back and forth as the particle spins, like a falling piece of paper
+			p.Position[0] += p.Flutter * float32(math.Cos(float64(p.Rotation))) * float32(deltaTime)
+		}
+	}
+}
+
+// Emit spawns a single particle, copying the given one
+func (pg *ParticleGenerator) Emit(particle Particle) {
+	if pg.amount == 0 {
+		return
+	}
+	*pg.particles[pg.firstUnusedParticle()] = particle
+}
+
+// EmitPreset spawns a burst of particles described by the preset
+func (pg *ParticleGenerator) EmitPreset(preset ParticlePreset, position mgl.Vec2) {
+	for i := 0; i < preset.Count; i++ {
+		angle := float64(preset.Angle + (pg.random.Float32()-0.5)*preset.Spread)
+		speed := preset.SpeedMin + pg.random.Float32()*(preset.SpeedMax-preset.SpeedMin)
+		velocity := mgl.Vec2{float32(math.Cos(angle)) * speed, float32(math.Sin(angle)) * speed}
+		color := preset.Color
+		for c := 0; c < 3; c++ {
+			color[c] += (pg.random.Float32()*2 - 1) * preset.ColorJitter
+		}
+		size := preset.Size
+		if size.X() == 0 || size.Y() == 0 {
+			size = defaultParticleSize
+		}
+		pg.Emit(Particle{
+			Position:        position,
+			Velocity:        velocity,
+			Color:           color,
+			Life:            preset.Life,
+			Fade:            preset.Fade,
+			Size:            size,
+			Rotation:        pg.random.Float32() * 2 * math.Pi,
+			AngularVelocity: (pg.random.Float32()*2 - 1) * preset.Spin,
+			Flutter:         preset.Flutter,
+		})
+	}
+}
+
+// Draw draws the particles managed by the generator, all at once using instancing
+func (pg *ParticleGenerator) Draw() {
+	// Collect the per instance data of the alive particles
+	pg.instanceData = pg.instanceData[:0]
+	for _, p := range pg.particles {
+		if p.Life > 0.0 {
+			pg.instanceData = append(pg.instanceData,
+				p.Position.X(), p.Position.Y(),
+				p.Size.X(), p.Size.Y(),
+				p.Rotation,
+				p.Color.X(), p.Color.Y(), p.Color.Z(), p.Color.W())
+		}
+	}
+	count := len(pg.instanceData) / particleInstanceSize
+	if count == 0 {
+		return
+	}
+	gl.BindBuffer(gl.ARRAY_BUFFER, pg.instanceVbo)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, 4*len(pg.instanceData), gl.Ptr(pg.instanceData))
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+
+	if pg.Additive {
+		// Use additive blending to give it a 'glow' effect
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE)
+	}
+	pg.shader.Use()
+	gl.BindVertexArray(pg.quadVao)
+	gl.DrawArraysInstanced(gl.TRIANGLES, 0, 6, int32(count))
+	gl.BindVertexArray(0)
+	// Don't forget to reset to default blending mode
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	render.CheckError("ParticleGenerator.Draw")
+}
+
+// Reset kills all the particles
+func (pg *ParticleGenerator) Reset() {
+	for _, particle := range pg.particles {
+		particle.Life = 0.0
+	}
+	pg.lastUsedParticle = 0
+}
+
+// Delete releases the quad buffers
+func (pg *ParticleGenerator) Delete() {
+	gl.DeleteVertexArrays(1, &pg.quadVao)
+	render.Objects.Untrack(render.VertexArrayObject, pg.quadVao)
+	gl.DeleteBuffers(1, &pg.quadVbo)
+	render.Objects.Untrack(render.BufferObject, pg.quadVbo)
+	gl.DeleteBuffers(1, &pg.instanceVbo)
+	render.Objects.Untrack(render.BufferObject, pg.instanceVbo)
+}
+
+func (pg *ParticleGenerator) firstUnusedParticle() int {
+	// First search from last used particle, this will usually return almost instantly
+	for i := pg.lastUsedParticle; i < pg.amount; i++ {
+		if pg.particles[i].Life <= 0.0 {
+			pg.lastUsedParticle = i
+			return i
+		}
+	}
+	// Otherwise, do a linear search
+	for i := 0; i < pg.lastUsedParticle; i++ {
+		if pg.particles[i].Life <= 0.0 {
+			pg.lastUsedParticle = i
+			return i
+		}
+	}
+	// All particles are taken, override the first one (note that if it repeatedly hits this case, more particles should be reserved)
+	pg.lastUsedParticle = 0
+
+	return 0
+}
+
+func (pg *ParticleGenerator) respawnParticle(particle *Particle, source EmitterSource, offset mgl.Vec2) {
+	random := float32(pg.random.Int31n(50)) / 100.0 / 10.0
+	randomColor := float32(pg.random.Int31n(50)) / 100.0
+	particle.Position = source.Position().Add(mgl.Vec2{random, random}).Add(offset)
+	particle.Color = mgl.Vec4{randomColor, randomColor, randomColor, 1.0}
+	particle.Life = 1.0
+	particle.Fade = defaultParticleFade
+	particle.Size = defaultParticleSize
+	particle.Rotation = 0.0
+	particle.AngularVelocity = 0.0
+	particle.Flutter = 0.0
+	if trail := pg.Trail; trail != nil {
+		particle.Color = trail.Color
+		for c := 0; c < 3; c++ {
+			particle.Color[c] += (pg.random.Float32()*2 - 1) * trail.ColorJitter
+		}
+		particle.Life = trail.Life
+		particle.Fade = trail.Fade
+		if trail.Size.X() != 0 && trail.Size.Y() != 0 {
+			particle.Size = trail.Size
+		}
+	}
+	// Leave the particle behind the source
+	particle.Velocity = source.Velocity().Mul(-0.1)
+}
@@ -0,0 +1,86 @@
+package particles
+
+import (
+	"math"
+	"math/rand"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+var (
+	swirlRadius = float32(160) // Distance from the center the particles are spawned at
+	swirlRate   = 80.0         // Particles spawned per second around each center
+	swirlSpeed  = float32(260) // Speed of the particles around the center
+	swirlPull   = float32(700) // Acceleration of the particles towards the center
+	// swirlParticle is a single spark of a vortex, its velocity is set when spawned
+	swirlParticle = Particle{
+		Color: mgl.Vec4{0.6, 0.3, 1.0, 1.0}, Life: 0.8, Fade: 1.2, Size: mgl.Vec2{12, 12},
+	}
+)
+
+// Swirl spins sparks around some vortices, falling towards their centers
+type Swirl struct {
+	particles  *ParticleGenerator
+	spawnTimer float64 // Time accumulated since the last sparks were spawned
+}
+
+// NewSwirl returns a swirl using a generator of the given amount of particles
+func NewSwirl(shader *render.Shader, amount int, random *rand.Rand) *Swirl {
+	return &Swirl{particles: NewParticleGenerator(shader, amount, random)}
+}
+
+// Update spawns sparks around the centers and pulls the alive ones towards the nearest center,
+// the sparks left without a center fade out where they are
+func (s *Swirl) Update(deltaTime float64, centers []mgl.Vec2) {
+	if len(centers) > 0 {
+		s.spawnTimer += deltaTime
+		for s.spawnTimer >= 1/swirlRate {
+			s.spawnTimer -= 1 / swirlRate
+			for _, center := range centers {
+				s.spawn(center)
+			}
+		}
+	}
+	for _, p := range s.particles.particles {
+		if p.Life <= 0 || len(centers) == 0 {
+			continue
+		}
+		nearest := centers[0]
+		for _, center := range centers[1:] {
+			if center.Sub(p.Position).Len() < nearest.Sub(p.Position).Len() {
+				nearest = center
+			}
+		}
+		if offset := nearest.Sub(p.Position); offset.Len() > 1 {
+			p.Velocity = p.Velocity.Add(offset.Normalize().Mul(swirlPull * float32(deltaTime)))
+		}
+	}
+	s.particles.UpdateParticles(deltaTime)
+}
+
+// spawn emits a spark on the circle around the center, moving around it
+func (s *Swirl) spawn(center mgl.Vec2) {
+	angle := s.particles.random.Float64() * 2 * math.Pi
+	direction := mgl.Vec2{float32(math.Cos(angle)), float32(math.Sin(angle))}
+	spark := swirlParticle
+	spark.Position = center.Add(direction.Mul(swirlRadius))
+	// Counterclockwise, a little towards the center
+	spark.Velocity = mgl.Vec2{-direction.Y(), direction.X()}.Mul(swirlSpeed).Sub(direction.Mul(swirlSpeed * 0.2))
+	s.particles.Emit(spark)
+}
+
+// Stop removes all the sparks
+func (s *Swirl) Stop() {
+	s.particles.Reset()
+}
+
+// Draw draws the sparks
+func (s *Swirl) Draw() {
+	s.particles.Draw()
+}
+
+// Delete releases the particles buffers
+func (s *Swirl) Delete() {
+	s.particles.Delete()
+}
@@ -0,0 +1,52 @@
+package particles
+
+import "sort"
+
+// timelineEvent is an action run at a given time of the timeline
+type timelineEvent struct {
+	at     float64
+	action func()
+}
+
+// EffectTimeline runs a sequence of timed actions, it is used to chain
+// particle presets and other timed steps into a single effect
+type EffectTimeline struct {
+	events  []timelineEvent
+	elapsed float64
+	next    int // Index of the next event to run
+}
+
+// NewEffectTimeline returns an empty timeline
+func NewEffectTimeline() *EffectTimeline {
+	return &EffectTimeline{}
+}
+
+// At schedules an action at the given time, in seconds from the start of the timeline
+func (t *EffectTimeline) At(at float64, action func()) {
+	t.events = append(t.events, timelineEvent{at: at, action: action})
+	// Keep the events not yet run sorted by time, events at the same time run in insertion order
+	pending := t.events[t.next:]
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].at < pending[j].at })
+}
+
+// Update advances the timeline running the actions that are due
+func (t *EffectTimeline) Update(deltaTime float64) {
+	t.elapsed += deltaTime
+	for t.next < len(t.events) && t.events[t.next].at <= t.elapsed {
+		event := t.events[t.next]
+		t.next++
+		event.action()
+	}
+}
+
+// Reset removes all the scheduled actions and rewinds the timeline
+func (t *EffectTimeline) Reset() {
+	t.events = nil
+	t.elapsed = 0.0
+	t.next = 0
+}
+
+// Done tells if all the scheduled actions have run
+func (t *EffectTimeline) Done() bool {
+	return t.next >= len(t.events)
+}
@@ -0,0 +1,163 @@
+// Package physics holds the movement and collision math of the game,
+// it has no OpenGL nor GLFW dependencies so it can be used and tested without a window
+package physics
+
+import (
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// AABB is an axis aligned bounding box
+type AABB struct {
+	Position mgl.Vec2 // Top-left corner
+	Size     mgl.Vec2
+}
+
+// Center returns the center of the box
+func (b AABB) Center() mgl.Vec2 {
+	return b.Position.Add(b.Size.Mul(0.5))
+}
+
+// Overlaps checks if two boxes overlap, touching boxes overlap too
+func (b AABB) Overlaps(other AABB) bool {
+	// Collision x-axis?
+	collisionX := b.Position.X()+b.Size.X() >= other.Position.X() &&
+		other.Position.X()+other.Size.X() >= b.Position.X()
+	// Collision y-axis?
+	collisionY := b.Position.Y()+b.Size.Y() >= other.Position.Y() &&
+		other.Position.Y()+other.Size.Y() >= b.Position.Y()
+	// Collision only if on both axes
+	return collisionX && collisionY
+}
+
+// Circle is a circle
+type Circle struct {
+	Center mgl.Vec2
+	Radius float32
+}
+
+// Collision describes the contact between two shapes
+type Collision struct {
+	Normal      mgl.Vec2 // Unit vector pushing the first shape out of the second one
+	Penetration float32  // Depth of the first shape inside the second one along the normal
+}
+
+// CircleAABB checks if a circle collides with a box and returns the contact
+func CircleAABB(circle Circle, box AABB) (Collision, bool) {
+	// Closest point of the box to the center of the circle
+	min := box.Position
+	max := box.Position.Add(box.Size)
+	closest := mgl.Vec2{
+		mgl.Clamp(circle.Center.X(), min.X(), max.X()),
+		mgl.Clamp(circle.Center.Y(), min.Y(), max.Y()),
+	}
+	difference := circle.Center.Sub(closest)
+	distance := difference.Len()
+	if distance > circle.Radius {
+		return Collision{}, false
+	}
+	if distance > 0 {
+		return Collision{Normal: difference.Mul(1 / distance), Penetration: circle.Radius - distance}, true
+	}
+	// The center is inside the box, push it out from the closest side
+	center := box.Center()
+	offset := circle.Center.Sub(center)
+	overlapX := box.Size.X()/2 - float32(math.Abs(float64(offset.X())))
+	overlapY := box.Size.Y()/2 - float32(math.Abs(float64(offset.Y())))
+	if overlapX < overlapY {
+		return Collision{Normal: mgl.Vec2{sign(offset.X()), 0}, Penetration: overlapX + circle.Radius}, true
+	}
+	return Collision{Normal: mgl.Vec2{0, sign(offset.Y())}, Penetration: overlapY + circle.Radius}, true
+}
+
+// CircleCircle checks if a circle collides with another one and returns the contact
+func CircleCircle(circle, other Circle) (Collision, bool) {
+	difference := circle.Center.Sub(other.Center)
+	distance := difference.Len()
+	if distance > circle.Radius+other.Radius {
+		return Collision{}, false
+	}
+	if distance == 0 {
+		// Same center, push it out upwards
+		return Collision{Normal: mgl.Vec2{0, -1}, Penetration: circle.Radius + other.Radius}, true
+	}
+	return Collision{Normal: difference.Mul(1 / distance), Penetration: circle.Radius + other.Radius - distance}, true
+}
+
+// Reflect reflects the velocity on a surface with the given unit normal,
+// a velocity already moving away from the surface is returned unchanged
+func Reflect(velocity, normal mgl.Vec2) mgl.Vec2 {
+	dot := velocity.Dot(normal)
+	if dot >= 0 {
+		return velocity
+	}
+	return velocity.Sub(normal.Mul(2 * dot))
+}
+
+// Spin adds a fraction of the velocity of a moving surface to the velocity bouncing on it,
+// along the surface only, keeping the speed it had
+func Spin(velocity, normal, surfaceVelocity mgl.Vec2, friction float32) mgl.Vec2 {
+	speed := velocity.Len()
+	tangent := mgl.Vec2{-normal.Y(), normal.X()}
+	spun := velocity.Add(tangent.Mul(surfaceVelocity.Dot(tangent) * friction))
+	if spun.Len() == 0 {
+		return velocity
+	}
+	return spun.Normalize().Mul(speed)
+}
+
+// LimitAngle turns the velocity towards the axis so that the angle between them is at most maxAngle radians,
+// keeping the speed. The axis must be a unit vector.
+func LimitAngle(velocity, axis mgl.Vec2, maxAngle float32) mgl.Vec2 {
+	speed := velocity.Len()
+	if speed == 0 {
+		return velocity
+	}
+	direction := velocity.Mul(1 / speed)
+	maxCos := float32(math.Cos(float64(maxAngle)))
+	if direction.Dot(axis) >= maxCos {
+		return velocity
+	}
+	tangent := mgl.Vec2{-axis.Y(), axis.X()}
+	side := sign(direction.Dot(tangent))
+	limited := axis.Mul(maxCos).Add(tangent.Mul(side * float32(math.Sin(float64(maxAngle)))))
+	return limited.Mul(speed)
+}
+
+// Move advances a box by its velocity, bouncing it on the top and bottom bounds of the court.
+// It returns the new position and velocity.
+func Move(box AABB, velocity mgl.Vec2, deltaTime float64, height float32) (mgl.Vec2, mgl.Vec2) {
+	position := box.Position.Add(velocity.Mul(float32(deltaTime)))
+	// Check if outside bounds; if so, reverse velocity and restore at correct position
+	if position.Y() <= 0.0 {
+		velocity[1] = -velocity.Y()
+		position[1] = 0.0
+	} else if position.Y()+box.Size.Y() >= height {
+		velocity[1] = -velocity.Y()
+		position[1] = height - box.Size.Y()
+	}
+	return position, velocity
+}
+
+// Wrap advances a box by its velocity, bringing it back from the bottom of the court when its
+// center leaves the top and the other way around. It returns the new position and whether it wrapped.
+func Wrap(box AABB, velocity mgl.Vec2, deltaTime float64, height float32) (mgl.Vec2, bool) {
+	position := box.Position.Add(velocity.Mul(float32(deltaTime)))
+	center := position.Y() + box.Size.Y()/2
+	if center < 0.0 {
+		position[1] += height
+		return position, true
+	} else if center >= height {
+		position[1] -= height
+		return position, true
+	}
+	return position, false
+}
+
+func sign(value float32) float32 {
+	if value < 0 {
+		return -1
+	}
+	return 1
+}
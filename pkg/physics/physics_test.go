@@ -0,0 +1,135 @@
+package physics
+
+import (
+	"math"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+const epsilon = 1e-4
+
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) < epsilon
+}
+
+func nearVec(a, b mgl.Vec2) bool {
+	return near(a.X(), b.X()) && near(a.Y(), b.Y())
+}
+
+func TestCircleAABB(t *testing.T) {
+	box := AABB{Position: mgl.Vec2{0, 0}, Size: mgl.Vec2{10, 20}}
+	tests := []struct {
+		name        string
+		circle      Circle
+		collides    bool
+		normal      mgl.Vec2
+		penetration float32
+	}{
+		{"apart", Circle{mgl.Vec2{15, 10}, 2}, false, mgl.Vec2{}, 0},
+		{"right edge", Circle{mgl.Vec2{11, 10}, 2}, true, mgl.Vec2{1, 0}, 1},
+		{"left edge", Circle{mgl.Vec2{-1.5, 5}, 2}, true, mgl.Vec2{-1, 0}, 0.5},
+		{"top edge", Circle{mgl.Vec2{5, -1}, 2}, true, mgl.Vec2{0, -1}, 1},
+		{"bottom edge", Circle{mgl.Vec2{5, 21}, 2}, true, mgl.Vec2{0, 1}, 1},
+		{"touching edge", Circle{mgl.Vec2{12, 10}, 2}, true, mgl.Vec2{1, 0}, 0},
+		{"corner", Circle{mgl.Vec2{11, 21}, 2}, true, mgl.Vec2{1, 1}.Normalize(), 2 - float32(math.Sqrt2)},
+		{"past the corner", Circle{mgl.Vec2{12, 22}, 2}, false, mgl.Vec2{}, 0},
+		{"inside near the right side", Circle{mgl.Vec2{9, 10}, 2}, true, mgl.Vec2{1, 0}, 3},
+		{"inside near the top side", Circle{mgl.Vec2{5, 1}, 2}, true, mgl.Vec2{0, -1}, 3},
+		{"inside at the center", Circle{mgl.Vec2{5, 10}, 2}, true, mgl.Vec2{1, 0}, 7},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collision, collides := CircleAABB(test.circle, box)
+			if collides != test.collides {
+				t.Fatalf("collides = %v, want %v", collides, test.collides)
+			}
+			if !collides {
+				return
+			}
+			if !nearVec(collision.Normal, test.normal) {
+				t.Errorf("normal = %v, want %v", collision.Normal, test.normal)
+			}
+			if !near(collision.Penetration, test.penetration) {
+				t.Errorf("penetration = %v, want %v", collision.Penetration, test.penetration)
+			}
+		})
+	}
+}
+
+func TestReflect(t *testing.T) {
+	tests := []struct {
+		name     string
+		velocity mgl.Vec2
+		normal   mgl.Vec2
+		want     mgl.Vec2
+	}{
+		{"head on", mgl.Vec2{-3, 0}, mgl.Vec2{1, 0}, mgl.Vec2{3, 0}},
+		{"diagonal", mgl.Vec2{-3, 4}, mgl.Vec2{1, 0}, mgl.Vec2{3, 4}},
+		{"off the ceiling", mgl.Vec2{2, -5}, mgl.Vec2{0, 1}, mgl.Vec2{2, 5}},
+		{"already moving away", mgl.Vec2{3, 4}, mgl.Vec2{1, 0}, mgl.Vec2{3, 4}},
+		{"along the surface", mgl.Vec2{0, 4}, mgl.Vec2{1, 0}, mgl.Vec2{0, 4}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Reflect(test.velocity, test.normal); !nearVec(got, test.want) {
+				t.Errorf("Reflect(%v, %v) = %v, want %v", test.velocity, test.normal, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSpin(t *testing.T) {
+	tests := []struct {
+		name     string
+		velocity mgl.Vec2
+		surface  mgl.Vec2
+		friction float32
+		want     mgl.Vec2
+	}{
+		{"still surface", mgl.Vec2{5, 0}, mgl.Vec2{0, 0}, 0.5, mgl.Vec2{5, 0}},
+		{"no friction", mgl.Vec2{5, 0}, mgl.Vec2{0, 10}, 0, mgl.Vec2{5, 0}},
+		{"moving along", mgl.Vec2{5, 0}, mgl.Vec2{0, 10}, 0.5, mgl.Vec2{5, 5}.Normalize().Mul(5)},
+		{"moving across is ignored", mgl.Vec2{5, 0}, mgl.Vec2{10, 0}, 0.5, mgl.Vec2{5, 0}},
+		{"cancelled out", mgl.Vec2{0, -5}, mgl.Vec2{0, 10}, 0.5, mgl.Vec2{0, -5}},
+	}
+	normal := mgl.Vec2{1, 0}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Spin(test.velocity, normal, test.surface, test.friction)
+			if !nearVec(got, test.want) {
+				t.Errorf("Spin(%v, %v, %v, %v) = %v, want %v", test.velocity, normal, test.surface, test.friction, got, test.want)
+			}
+			if !near(got.Len(), test.velocity.Len()) {
+				t.Errorf("speed = %v, want %v", got.Len(), test.velocity.Len())
+			}
+		})
+	}
+}
+
+func TestLimitAngle(t *testing.T) {
+	quarter := float32(math.Pi / 4)
+	diagonal := float32(math.Sqrt2 / 2)
+	tests := []struct {
+		name     string
+		velocity mgl.Vec2
+		axis     mgl.Vec2
+		want     mgl.Vec2
+	}{
+		{"along the axis", mgl.Vec2{5, 0}, mgl.Vec2{1, 0}, mgl.Vec2{5, 0}},
+		{"within the limit", mgl.Vec2{4, 3}, mgl.Vec2{1, 0}, mgl.Vec2{4, 3}},
+		{"at the limit", mgl.Vec2{1, 1}, mgl.Vec2{1, 0}, mgl.Vec2{1, 1}},
+		{"steep downwards", mgl.Vec2{1, 10}, mgl.Vec2{1, 0}, mgl.Vec2{diagonal, diagonal}.Mul(mgl.Vec2{1, 10}.Len())},
+		{"steep upwards", mgl.Vec2{1, -10}, mgl.Vec2{1, 0}, mgl.Vec2{diagonal, -diagonal}.Mul(mgl.Vec2{1, -10}.Len())},
+		{"steep on the left axis", mgl.Vec2{-1, 10}, mgl.Vec2{-1, 0}, mgl.Vec2{-diagonal, diagonal}.Mul(mgl.Vec2{-1, 10}.Len())},
+		{"backwards", mgl.Vec2{-5, 0}, mgl.Vec2{1, 0}, mgl.Vec2{diagonal, diagonal}.Mul(5)},
+		{"still", mgl.Vec2{0, 0}, mgl.Vec2{1, 0}, mgl.Vec2{0, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := LimitAngle(test.velocity, test.axis, quarter); !nearVec(got, test.want) {
+				t.Errorf("LimitAngle(%v, %v) = %v, want %v", test.velocity, test.axis, got, test.want)
+			}
+		})
+	}
+}
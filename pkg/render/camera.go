@@ -0,0 +1,110 @@
+package render
+
+import (
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Camera2D eases the view of the world toward a position and a zoom
+type Camera2D struct {
+	width, height float32  // Size of the viewport in world units
+	position      mgl.Vec2 // Center of the view in world coordinates
+	target        mgl.Vec2 // Position the camera is easing toward
+	zoom          float32  // Current zoom factor (1 = no zoom)
+	targetZoom    float32  // Zoom factor the camera is easing toward
+	punch         float32  // Extra zoom added by ZoomPunch
+	punchTime     float64  // Remaining time of the zoom punch
+	punchDuration float64  // Total time of the zoom punch
+	smoothing     float32  // How fast position and zoom ease toward their targets
+	mirrorX       bool     // Flip the view horizontally around the center of the viewport
+	mirrorY       bool     // Flip the view vertically around the center of the viewport
+}
+
+// NewCamera2D returns a camera looking at the center of a view of the given size
+func NewCamera2D(width, height float32) *Camera2D {
+	camera := &Camera2D{
+		width:     width,
+		height:    height,
+		smoothing: 5.0,
+	}
+	camera.Reset()
+
+	return camera
+}
+
+// Reset centers the camera on the viewport with no zoom
+func (c *Camera2D) Reset() {
+	c.position = mgl.Vec2{c.width / 2, c.height / 2}
+	c.target = c.position
+	c.zoom = 1.0
+	c.targetZoom = 1.0
+	c.punch = 0.0
+	c.punchTime = 0.0
+	c.punchDuration = 0.0
+}
+
+// LookAt sets the position the camera eases toward
+func (c *Camera2D) LookAt(target mgl.Vec2) {
+	c.target = target
+}
+
+// SetZoom sets the zoom factor the camera eases toward
+func (c *Camera2D) SetZoom(zoom float32) {
+	c.targetZoom = zoom
+}
+
+// ZoomPunch adds a quick zoom in that fades out in the given duration
+func (c *Camera2D) ZoomPunch(amount float32, duration float64) {
+	c.punch = amount
+	c.punchTime = duration
+	c.punchDuration = duration
+}
+
+// SetMirror flips the view around the center, Reset keeps it
+func (c *Camera2D) SetMirror(x, y bool) {
+	c.mirrorX = x
+	c.mirrorY = y
+}
+
+// Update eases the camera toward its targets
+func (c *Camera2D) Update(deltaTime float64) {
+	t := float32(1.0 - math.Exp(-float64(c.smoothing)*deltaTime))
+	c.position = c.position.Add(c.target.Sub(c.position).Mul(t))
+	c.zoom += (c.targetZoom - c.zoom) * t
+	if c.punchTime > 0.0 {
+		c.punchTime -= deltaTime
+		if c.punchTime < 0.0 {
+			c.punchTime = 0.0
+		}
+	}
+}
+
+// View returns the view matrix for the current camera state
+func (c *Camera2D) View() mgl.Mat4 {
+	zoom := c.zoom
+	if c.punchDuration > 0.0 {
+		zoom += c.punch * float32(c.punchTime/c.punchDuration)
+	}
+	// Scale around the center of the viewport, keeping the camera position in the middle
+	center := mgl.Translate3D(c.width/2, c.height/2, 0.0)
+	scaleX, scaleY := zoom, zoom
+	if c.mirrorX {
+		scaleX = -scaleX
+	}
+	if c.mirrorY {
+		scaleY = -scaleY
+	}
+	scale := mgl.Scale3D(scaleX, scaleY, 1.0)
+	position := mgl.Translate3D(-c.position.X(), -c.position.Y(), 0.0)
+
+	return center.Mul4(scale).Mul4(position)
+}
+
+// Apply sets the camera view matrix into the given shaders
+func (c *Camera2D) Apply(shaders ...*Shader) {
+	view := c.View()
+	for _, shader := range shaders {
+		shader.Use().SetMatrix4("view", view, false)
+	}
+}
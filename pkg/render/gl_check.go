@@ -0,0 +1,46 @@
+package render
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// CheckErrors makes the renderers call gl.GetError after each group of OpenGL calls and log the errors
+// with their call site. It stalls the pipeline, it's meant for the drivers without debug output:
+// debug builds (-tags gldebug) enable it by default, EnableDebugOutput when the driver lacks KHR_debug.
+var CheckErrors = checkErrorsDefault
+
+// CheckError logs the OpenGL errors raised by the group of calls just run, along with the caller of
+// the function running them
+func CheckError(group string) {
+	if !CheckErrors {
+		return
+	}
+	for code := gl.GetError(); code != gl.NO_ERROR; code = gl.GetError() {
+		site := "unknown"
+		if _, file, line, ok := runtime.Caller(2); ok {
+			site = fmt.Sprintf("%v:%v", file, line)
+		}
+		log.Printf("GLERROR: %v in %v called from %v", glErrorName(code), group, site)
+	}
+}
+
+func glErrorName(code uint32) string {
+	switch code {
+	case gl.INVALID_ENUM:
+		return "INVALID_ENUM"
+	case gl.INVALID_VALUE:
+		return "INVALID_VALUE"
+	case gl.INVALID_OPERATION:
+		return "INVALID_OPERATION"
+	case gl.INVALID_FRAMEBUFFER_OPERATION:
+		return "INVALID_FRAMEBUFFER_OPERATION"
+	case gl.OUT_OF_MEMORY:
+		return "OUT_OF_MEMORY"
+	default:
+		return fmt.Sprintf("0x%x", code)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build gldebug
+// +build gldebug
+
+package render
+
+const checkErrorsDefault = true
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"log"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.2/glfw"
+)
+
+// EnableDebugOutput routes the OpenGL debug messages to the log, when the driver supports it,
+// otherwise it enables CheckErrors. It requires a current context, created with the debug
+// context hint to get all the messages.
+func EnableDebugOutput() {
+	switch {
+	case glfw.ExtensionSupported("GL_KHR_debug"):
+		gl.Enable(gl.DEBUG_OUTPUT)
+		gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+		gl.DebugMessageCallback(logGLDebugMessage, nil)
+	case glfw.ExtensionSupported("GL_ARB_debug_output"):
+		gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+		gl.DebugMessageCallbackARB(logGLDebugMessage, nil)
+	default:
+		// Fall back to checking the errors after every group of calls
+		log.Println("GLDEBUG: debug output is not supported by the driver, checking glGetError instead")
+		CheckErrors = true
+		return
+	}
+	log.Println("GLDEBUG: debug output enabled")
+}
+
+// logGLDebugMessage logs a message received from the OpenGL debug output
+func logGLDebugMessage(source, gltype, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+	// Notifications are mostly informative messages about buffer usage
+	if severity == gl.DEBUG_SEVERITY_NOTIFICATION {
+		return
+	}
+	log.Printf("GLDEBUG: [%v] %v %v (%v): %v", glDebugSeverityName(severity), glDebugSourceName(source), glDebugTypeName(gltype), id, message)
+}
+
+func glDebugSourceName(source uint32) string {
+	switch source {
+	case gl.DEBUG_SOURCE_API:
+		return "api"
+	case gl.DEBUG_SOURCE_WINDOW_SYSTEM:
+		return "window-system"
+	case gl.DEBUG_SOURCE_SHADER_COMPILER:
+		return "shader-compiler"
+	case gl.DEBUG_SOURCE_THIRD_PARTY:
+		return "third-party"
+	case gl.DEBUG_SOURCE_APPLICATION:
+		return "application"
+	}
+	return "other"
+}
+
+func glDebugTypeName(gltype uint32) string {
+	switch gltype {
+	case gl.DEBUG_TYPE_ERROR:
+		return "error"
+	case gl.DEBUG_TYPE_DEPRECATED_BEHAVIOR:
+		return "deprecated-behavior"
+	case gl.DEBUG_TYPE_UNDEFINED_BEHAVIOR:
+		return "undefined-behavior"
+	case gl.DEBUG_TYPE_PORTABILITY:
+		return "portability"
+	case gl.DEBUG_TYPE_PERFORMANCE:
+		return "performance"
+	}
+	return "other"
+}
+
+func glDebugSeverityName(severity uint32) string {
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		return "high"
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		return "medium"
+	case gl.DEBUG_SEVERITY_LOW:
+		return "low"
+	}
+	return "notification"
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+)
+
+// ObjectKind identifies the type of an OpenGL object
+type ObjectKind string
+
+const (
+	TextureObject      ObjectKind = "texture"
+	BufferObject       ObjectKind = "buffer"
+	VertexArrayObject  ObjectKind = "vertex array"
+	FramebufferObject  ObjectKind = "framebuffer"
+	RenderbufferObject ObjectKind = "renderbuffer"
+	ProgramObject      ObjectKind = "program"
+)
+
+// ObjectTracker keeps track of the live OpenGL objects and of where they were created,
+// so the ones never deleted can be reported at shutdown
+type ObjectTracker struct {
+	objects map[ObjectKind]map[uint32]string
+}
+
+// Objects tracks all the OpenGL objects created through this package and the ones built on it
+var Objects = newObjectTracker()
+
+func newObjectTracker() *ObjectTracker {
+	return &ObjectTracker{
+		objects: make(map[ObjectKind]map[uint32]string),
+	}
+}
+
+// Track records a newly created object
+func (t *ObjectTracker) Track(kind ObjectKind, id uint32) {
+	if t.objects[kind] == nil {
+		t.objects[kind] = make(map[uint32]string)
+	}
+	// Remember the caller of the function creating the object
+	site := "unknown"
+	if _, file, line, ok := runtime.Caller(2); ok {
+		site = fmt.Sprintf("%v:%v", file, line)
+	}
+	t.objects[kind][id] = site
+}
+
+// Untrack forgets a deleted object
+func (t *ObjectTracker) Untrack(kind ObjectKind, id uint32) {
+	delete(t.objects[kind], id)
+}
+
+// ReportLeaks logs the objects that have not been deleted
+func (t *ObjectTracker) ReportLeaks() {
+	for kind, objects := range t.objects {
+		for id, site := range objects {
+			log.Printf("GLOBJECTS: leaked %v %v created by %v", kind, id, site)
+		}
+	}
+}
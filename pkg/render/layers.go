@@ -0,0 +1,52 @@
+package render
+
+// Layer identifies a draw layer, layers are composed in ascending order
+type Layer int
+
+// Drawable is anything that can be drawn as part of a layer, alpha is how far the
+// frame is between the previous and the current fixed update, to interpolate movements
+type Drawable interface {
+	Draw(alpha float32)
+}
+
+// DrawFunc adapts a plain function to the Drawable interface
+type DrawFunc func(alpha float32)
+
+// Draw calls f(alpha)
+func (f DrawFunc) Draw(alpha float32) {
+	f(alpha)
+}
+
+// LayerStack holds the drawables registered with each layer and composes the frame in order
+type LayerStack struct {
+	drawables [][]Drawable // Drawables of each layer, indexed by layer
+}
+
+// NewLayerStack returns a stack without drawables
+func NewLayerStack() *LayerStack {
+	return &LayerStack{}
+}
+
+// Register adds a drawable to the given layer, drawables in the same layer are drawn in registration order
+func (ls *LayerStack) Register(layer Layer, drawable Drawable) {
+	for int(layer) >= len(ls.drawables) {
+		ls.drawables = append(ls.drawables, nil)
+	}
+	ls.drawables[layer] = append(ls.drawables[layer], drawable)
+}
+
+// Clear removes all the drawables registered with the given layer
+func (ls *LayerStack) Clear(layer Layer) {
+	if int(layer) < len(ls.drawables) {
+		ls.drawables[layer] = nil
+	}
+}
+
+// DrawRange draws the layers from first to last (both included)
+func (ls *LayerStack) DrawRange(first, last Layer, alpha float32) {
+	for layer := first; layer <= last && int(layer) < len(ls.drawables); layer++ {
+		for _, drawable := range ls.drawables[layer] {
+			drawable.Draw(alpha)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Transition is how the postprocessor reveals a scene just switched to
+type Transition int32
+
+// Transitions of the scenes, the progress goes from 0 when the scene switches to 1 when it's fully shown
+const (
+	TransitionNone Transition = iota
+	TransitionFade            // Fade in from black
+	TransitionWipe            // Reveal from the left to the right edge
+	TransitionZoom            // Zoom out from the center while fading in
+)
+
+// Light is a point light over the scene, its position is in texture coordinates of the scene and
+// its radius a fraction of the height of the scene
+type Light struct {
+	Enabled  bool
+	Position mgl.Vec2
+	Radius   float32
+	Ambient  float32 // Share of the light reaching the scene out of the radius
+}
+
+// Fog darkens and blurs one side of the scene, split along a vertical line in texture coordinates
+// of the scene. Side goes from -1, the fog on the right of the split, to 1, the fog on the left:
+// in between the whole scene is partly fogged
+type Fog struct {
+	Enabled bool
+	Split   float32
+	Side    float32
+	Density float32 // Share of the light the fog takes away
+}
+
+// MaxTwists is the number of twists the postprocessor can apply at the same time
+const MaxTwists = 2
+
+// Twist swirls the scene around a point, its position is in texture coordinates of the scene and
+// its radius a fraction of the height of the scene. The twist is strongest at the center, no twist
+// is applied with a zero angle
+type Twist struct {
+	Position mgl.Vec2
+	Radius   float32
+	Angle    float32 // Rotation of the scene at the center, in radians
+}
+
+// PostProcessor hosts all PostProcessing effects for the game.
+// It renders the game on a textured quad after which one can
+// enable specific effects by enabling either the Confuse, Chaos or
+// Shake boolean, while Bloom adds a glow around the bright parts of the scene
+// and Dim darkens it behind the modal dialogs. Transition reveals the scene as its progress
+// goes from 0 to 1. Light darkens the scene away from a point light and Fog hides one side of it.
+// Twists distort the scene around some points.
+// It is required to call BeginRender() before rendering the game
+// and EndRender() after rendering the game for the class to work.
+type PostProcessor struct {
+	shader                *Shader
+	target                *RenderTarget
+	width, height         int32
+	Shake, Chaos, Confuse bool
+	Bloom                 bool
+	Dim                   bool
+	Transition            Transition
+	TransitionProgress    float32
+	Light                 Light
+	Fog                   Fog
+	Twists                [MaxTwists]Twist
+	quadVao               uint32
+	quadVbo               uint32
+}
+
+// NewPostProcessor returns a postprocessor rendering the game at the given size with the given MSAA samples
+func NewPostProcessor(shader *Shader, width, height, samples int32) *PostProcessor {
+	postProcessor := PostProcessor{
+		shader:  shader,
+		width:   width,
+		height:  height,
+		Shake:   false,
+		Chaos:   false,
+		Confuse: false}
+
+	// Initialize the render target the game is rendered to, multisampled unless samples is zero
+	postProcessor.target = NewRenderTarget(postProcessor.width, postProcessor.height, samples)
+
+	// Initialize render data and uniforms
+	postProcessor.initRenderData()
+	postProcessor.shader.SetInteger("scene", 0, true)
+	offset := float32(1.0 / 300.0)
+	offsets := [][]float32{
+		{-offset, offset},  // top-left
+		{0.0, offset},      // top-center
+		{offset, offset},   // top-right
+		{-offset, 0.0},     // center-left
+		{0.0, 0.0},         // center-center
+		{offset, 0.0},      // center - right
+		{-offset, -offset}, // bottom-left
+		{0.0, -offset},     // bottom-center
+		{offset, -offset},  // bottom-right
+	}
+	gl.Uniform2fv(postProcessor.shader.getUniformLocation("offsets"), 9, &offsets[0][0])
+	edgeKernel := []int32{
+		-1, -1, -1,
+		-1, 8, -1,
+		-1, -1, -1,
+	}
+	gl.Uniform1iv(postProcessor.shader.getUniformLocation("edge_kernel"), 9, &edgeKernel[0])
+	blurKernel := []float32{
+		1.0 / 16, 2.0 / 16, 1.0 / 16,
+		2.0 / 16, 4.0 / 16, 2.0 / 16,
+		1.0 / 16, 2.0 / 16, 1.0 / 16,
+	}
+	gl.Uniform1fv(postProcessor.shader.getUniformLocation("blur_kernel"), 9, &blurKernel[0])
+
+	return &postProcessor
+}
+
+// Resize re-allocates the render target storage with the new dimensions
+func (pp *PostProcessor) Resize(width, height int32) {
+	// A minimized window reports a zero sized framebuffer, keep the current storage
+	if width <= 0 || height <= 0 {
+		return
+	}
+	pp.width = width
+	pp.height = height
+	pp.target.Resize(width, height)
+}
+
+// BeginRender prepares the postprocessor's framebuffer operations before rendering the game
+func (pp *PostProcessor) BeginRender() {
+	pp.target.Bind()
+	gl.Clear(gl.COLOR_BUFFER_BIT)
+	CheckError("PostProcessor.BeginRender")
+}
+
+// EndRender should be called after rendering the game, so it stores all the rendered data into a texture object
+func (pp *PostProcessor) EndRender() {
+	pp.target.Resolve()
+}
+
+// Render renders the PostProcessor texture quad (as a screen-encompassing large sprite)
+func (pp *PostProcessor) Render(time float32) {
+	// Set uniforms/options
+	pp.shader.Use()
+	pp.shader.SetFloat("time", time, false)
+	pp.shader.SetInteger("confuse", boolToInt32(pp.Confuse), false)
+	pp.shader.SetInteger("chaos", boolToInt32(pp.Chaos), false)
+	pp.shader.SetInteger("shake", boolToInt32(pp.Shake), false)
+	pp.shader.SetInteger("bloom", boolToInt32(pp.Bloom), false)
+	pp.shader.SetInteger("dim", boolToInt32(pp.Dim), false)
+	pp.shader.SetInteger("transition_effect", int32(pp.Transition), false)
+	pp.shader.SetFloat("transition_progress", pp.TransitionProgress, false)
+	pp.shader.SetInteger("lighting", boolToInt32(pp.Light.Enabled), false)
+	pp.shader.SetVector2v("light_position", pp.Light.Position, false)
+	pp.shader.SetFloat("light_radius", pp.Light.Radius, false)
+	pp.shader.SetFloat("light_ambient", pp.Light.Ambient, false)
+	pp.shader.SetInteger("fog", boolToInt32(pp.Fog.Enabled), false)
+	pp.shader.SetFloat("fog_split", pp.Fog.Split, false)
+	pp.shader.SetFloat("fog_side", pp.Fog.Side, false)
+	pp.shader.SetFloat("fog_density", pp.Fog.Density, false)
+	for i, twist := range pp.Twists {
+		pp.shader.SetVector2v(fmt.Sprintf("twist_position[%v]", i), twist.Position, false)
+		pp.shader.SetFloat(fmt.Sprintf("twist_radius[%v]", i), twist.Radius, false)
+		pp.shader.SetFloat(fmt.Sprintf("twist_angle[%v]", i), twist.Angle, false)
+	}
+	pp.shader.SetFloat("aspect", float32(pp.width)/float32(pp.height), false)
+	// Render textured quad
+	gl.ActiveTexture(gl.TEXTURE0)
+	pp.target.texture.Bind()
+	gl.BindVertexArray(pp.quadVao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	CheckError("PostProcessor.Render")
+}
+
+// Delete releases the render target and the quad buffers
+func (pp *PostProcessor) Delete() {
+	pp.target.Delete()
+	gl.DeleteVertexArrays(1, &pp.quadVao)
+	Objects.Untrack(VertexArrayObject, pp.quadVao)
+	gl.DeleteBuffers(1, &pp.quadVbo)
+	Objects.Untrack(BufferObject, pp.quadVbo)
+}
+
+func (pp *PostProcessor) initRenderData() {
+	// Configure VAO/VBO
+	vertices := []float32{
+		// Pos      // Tex
+		-1.0, -1.0, 0.0, 0.0,
+		1.0, 1.0, 1.0, 1.0,
+		-1.0, 1.0, 0.0, 1.0,
+
+		-1.0, -1.0, 0.0, 0.0,
+		1.0, -1.0, 1.0, 0.0,
+		1.0, 1.0, 1.0, 1.0,
+	}
+
+	gl.GenVertexArrays(1, &pp.quadVao)
+	Objects.Track(VertexArrayObject, pp.quadVao)
+	gl.GenBuffers(1, &pp.quadVbo)
+	Objects.Track(BufferObject, pp.quadVbo)
+	gl.BindVertexArray(pp.quadVao)
+	// Fill mesh buffer
+	gl.BindBuffer(gl.ARRAY_BUFFER, pp.quadVbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 4*len(vertices), gl.Ptr(vertices), gl.STATIC_DRAW)
+	// Set mesh attributes
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 4, gl.FLOAT, false, 0, nil)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+	CheckError("PostProcessor.initRenderData")
+}
+
+func boolToInt32(b bool) int32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// RenderTarget is an offscreen framebuffer that renders into a texture.
+// When created with a number of samples greater than zero it renders into a
+// multisampled renderbuffer instead, that has to be resolved into the texture
+// calling Resolve() after rendering.
+type RenderTarget struct {
+	texture                    *Texture2D
+	width, height              int32
+	samples                    int32
+	msFrameBuffer, frameBuffer uint32
+	rbo                        uint32
+}
+
+// NewRenderTarget creates a render target of the given size, multisampled when samples is greater than zero
+func NewRenderTarget(width, height, samples int32) *RenderTarget {
+	target := RenderTarget{
+		width:   width,
+		height:  height,
+		samples: samples,
+	}
+
+	target.texture = NewTexture2D()
+
+	// Initialize renderbuffer/framebuffer object
+	gl.GenFramebuffers(1, &target.frameBuffer)
+	Objects.Track(FramebufferObject, target.frameBuffer)
+	if target.samples > 0 {
+		gl.GenFramebuffers(1, &target.msFrameBuffer)
+		Objects.Track(FramebufferObject, target.msFrameBuffer)
+		gl.GenRenderbuffers(1, &target.rbo)
+		Objects.Track(RenderbufferObject, target.rbo)
+	}
+	target.allocate()
+
+	return &target
+}
+
+// Resize re-allocates the storage of the target with the new dimensions
+func (rt *RenderTarget) Resize(width, height int32) {
+	rt.width = width
+	rt.height = height
+	rt.allocate()
+}
+
+// Texture returns the texture holding the resolved render
+func (rt *RenderTarget) Texture() *Texture2D {
+	return rt.texture
+}
+
+// Size returns the dimensions of the target
+func (rt *RenderTarget) Size() (width, height int32) {
+	return rt.width, rt.height
+}
+
+// Bind binds the target for drawing and sets the viewport to cover all of it
+func (rt *RenderTarget) Bind() {
+	if rt.samples > 0 {
+		gl.BindFramebuffer(gl.FRAMEBUFFER, rt.msFrameBuffer)
+	} else {
+		gl.BindFramebuffer(gl.FRAMEBUFFER, rt.frameBuffer)
+	}
+	gl.Viewport(0, 0, rt.width, rt.height)
+}
+
+// Resolve stores all the rendered data into the texture and binds back the default framebuffer
+func (rt *RenderTarget) Resolve() {
+	if rt.samples > 0 {
+		// Resolve multisampled color-buffer into intermediate FBO to store to texture
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, rt.msFrameBuffer)
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, rt.frameBuffer)
+		gl.BlitFramebuffer(0, 0, rt.width, rt.height, 0, 0, rt.width, rt.height, gl.COLOR_BUFFER_BIT, gl.NEAREST)
+	}
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0) // Binds both READ and WRITE framebuffer to default framebuffer
+	CheckError("RenderTarget.Resolve")
+}
+
+// Delete releases the framebuffers, the renderbuffer and the texture of the target
+func (rt *RenderTarget) Delete() {
+	gl.DeleteFramebuffers(1, &rt.frameBuffer)
+	Objects.Untrack(FramebufferObject, rt.frameBuffer)
+	if rt.samples > 0 {
+		gl.DeleteFramebuffers(1, &rt.msFrameBuffer)
+		Objects.Untrack(FramebufferObject, rt.msFrameBuffer)
+		gl.DeleteRenderbuffers(1, &rt.rbo)
+		Objects.Untrack(RenderbufferObject, rt.rbo)
+	}
+	rt.texture.Delete()
+}
+
+func (rt *RenderTarget) allocate() {
+	if rt.samples > 0 {
+		// Initialize renderbuffer storage with a multisampled color buffer (don't need a depth/stencil buffer)
+		gl.BindFramebuffer(gl.FRAMEBUFFER, rt.msFrameBuffer)
+		gl.BindRenderbuffer(gl.RENDERBUFFER, rt.rbo)
+		gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, rt.samples, gl.RGB, rt.width, rt.height) // Allocate storage for render buffer object
+		gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, rt.rbo)   // Attach MS render buffer object to framebuffer
+		if gl.CheckFramebufferStatus(gl.FRAMEBUFFER) != gl.FRAMEBUFFER_COMPLETE {
+			fmt.Println("ERROR::RENDERTARGET: Failed to initialize MSFBO")
+		}
+		gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+	}
+
+	// Also initialize the FBO/texture to render or blit multisampled color-buffer to
+	gl.BindFramebuffer(gl.FRAMEBUFFER, rt.frameBuffer)
+	rt.texture.Generate(rt.width, rt.height, nil)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, rt.texture.ID, 0) // Attach texture to framebuffer as its color attachment
+	if gl.CheckFramebufferStatus(gl.FRAMEBUFFER) != gl.FRAMEBUFFER_COMPLETE {
+		fmt.Println("ERROR::RENDERTARGET: Failed to initialize FBO")
+	}
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	CheckError("RenderTarget.allocate")
+}
+
+// ReadPixels copies the resolved render into the buffer as RGBA, its rows from the bottom of the
+// image up as OpenGL stores them
+func (rt *RenderTarget) ReadPixels(pixels []byte) {
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, rt.frameBuffer)
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.ReadPixels(0, 0, rt.width, rt.height, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(pixels))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, 0)
+	CheckError("RenderTarget.ReadPixels")
+}
@@ -1,4 +1,6 @@
-package main
+// Package render holds the OpenGL rendering building blocks: shaders, textures, sprites,
+// render targets, postprocessing, camera and draw layers
+package render
 
 import (
 	"fmt"
@@ -19,26 +21,80 @@ func (s *Shader) Use() *Shader {
 	return s
 }
 
-// Compile compiles the shader from given source code
-func (s *Shader) Compile(vertexSource, fragmentSource string) {
+// Compile compiles the shader from given source code, the geometry shader is optional and skipped when its source is empty.
+// It returns an error if any of the stages fails to compile or the program fails to link.
+func (s *Shader) Compile(vertexSource, fragmentSource, geometrySource string) error {
+	// Skip compilation when a linked binary of the same sources is cached
+	cacheProgram := programBinarySupported()
+	var cacheKey string
+	if cacheProgram {
+		cacheKey = programCacheKey(vertexSource, fragmentSource, geometrySource)
+		if program, ok := loadCachedProgram(cacheKey); ok {
+			s.ID = program
+			Objects.Track(ProgramObject, s.ID)
+			return nil
+		}
+	}
+
 	vertexShader, err := compileShader(vertexSource, gl.VERTEX_SHADER)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer gl.DeleteShader(vertexShader)
 
 	fragmentShader, err := compileShader(fragmentSource, gl.FRAGMENT_SHADER)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	defer gl.DeleteShader(fragmentShader)
+
+	var geometryShader uint32
+	if geometrySource != "" {
+		geometryShader, err = compileShader(geometrySource, gl.GEOMETRY_SHADER)
+		if err != nil {
+			return err
+		}
+		defer gl.DeleteShader(geometryShader)
+	}
+
+	// The shaders are deleted once linked into our program as they're no longer necessery
+	program := gl.CreateProgram()
+	gl.AttachShader(program, vertexShader)
+	gl.AttachShader(program, fragmentShader)
+	if geometrySource != "" {
+		gl.AttachShader(program, geometryShader)
+	}
+	if cacheProgram {
+		gl.ProgramParameteri(program, gl.PROGRAM_BINARY_RETRIEVABLE_HINT, gl.TRUE)
+	}
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(log))
+		gl.DeleteProgram(program)
+
+		return fmt.Errorf("failed to link program: %v", strings.TrimRight(log, "\x00"))
 	}
+	s.ID = program
+	Objects.Track(ProgramObject, s.ID)
 
-	s.ID = gl.CreateProgram()
-	gl.AttachShader(s.ID, vertexShader)
-	gl.AttachShader(s.ID, fragmentShader)
-	gl.LinkProgram(s.ID)
+	if cacheProgram {
+		saveCachedProgram(cacheKey, s.ID)
+	}
+	return nil
+}
 
-	// Delete the shaders as they're linked into our program now and no longer necessery
-	gl.DeleteShader(vertexShader)
-	gl.DeleteShader(fragmentShader)
+// Delete releases the shader program
+func (s *Shader) Delete() {
+	gl.DeleteProgram(s.ID)
+	Objects.Untrack(ProgramObject, s.ID)
+	s.ID = 0
 }
 
 // SetFloat utility function to pass a float to a shader
@@ -130,12 +186,26 @@ func compileShader(source string, shaderType uint32) (uint32, error) {
 		log := strings.Repeat("\x00", int(logLength+1))
 		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
 
-		return 0, fmt.Errorf("failed to compile %v: %v", source, log)
+		gl.DeleteShader(shader)
+
+		return 0, fmt.Errorf("failed to compile %v shader: %v", shaderTypeName(shaderType), strings.TrimRight(log, "\x00"))
 	}
 
 	return shader, nil
 }
 
+func shaderTypeName(shaderType uint32) string {
+	switch shaderType {
+	case gl.VERTEX_SHADER:
+		return "vertex"
+	case gl.FRAGMENT_SHADER:
+		return "fragment"
+	case gl.GEOMETRY_SHADER:
+		return "geometry"
+	}
+	return "unknown"
+}
+
 func (s *Shader) getUniformLocation(name string) int32 {
 	return gl.GetUniformLocation(s.ID, gl.Str(fmt.Sprintf("%v\x00", name)))
 }
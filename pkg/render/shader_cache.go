@@ -0,0 +1,88 @@
+package render
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// shaderCacheDir is where linked program binaries are cached, caching is disabled when empty
+var shaderCacheDir = defaultShaderCacheDir()
+
+func defaultShaderCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "go-pong", "shaders")
+}
+
+// programCacheKey identifies a program binary by the hash of its sources and of the driver that linked it,
+// so the cached binary is invalidated when any of the sources change or the driver is updated
+func programCacheKey(sources ...string) string {
+	hash := sha256.New()
+	// Each string is prefixed by its length, so moving text from one to the next changes the key
+	for _, s := range append([]string{gl.GoStr(gl.GetString(gl.RENDERER)), gl.GoStr(gl.GetString(gl.VERSION))}, sources...) {
+		binary.Write(hash, binary.LittleEndian, uint64(len(s)))
+		hash.Write([]byte(s))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// programBinarySupported tells if the driver can save and load program binaries
+func programBinarySupported() bool {
+	if shaderCacheDir == "" {
+		return false
+	}
+	var formats int32
+	gl.GetIntegerv(gl.NUM_PROGRAM_BINARY_FORMATS, &formats)
+	return formats > 0
+}
+
+// loadCachedProgram creates a program from a cached binary, it returns false if there is no valid binary for the key
+func loadCachedProgram(key string) (uint32, bool) {
+	data, err := ioutil.ReadFile(filepath.Join(shaderCacheDir, key))
+	if err != nil || len(data) <= 4 {
+		return 0, false
+	}
+	// The binary is stored after its format
+	format := binary.LittleEndian.Uint32(data[:4])
+	program := gl.CreateProgram()
+	gl.ProgramBinary(program, format, gl.Ptr(&data[4]), int32(len(data)-4))
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		// The driver rejected the binary, it will be compiled again from sources
+		gl.DeleteProgram(program)
+		return 0, false
+	}
+	return program, true
+}
+
+// saveCachedProgram writes the binary of a linked program to the cache
+func saveCachedProgram(key string, program uint32) {
+	var length int32
+	gl.GetProgramiv(program, gl.PROGRAM_BINARY_LENGTH, &length)
+	if length <= 0 {
+		return
+	}
+	data := make([]byte, 4+length)
+	var format uint32
+	gl.GetProgramBinary(program, length, nil, &format, gl.Ptr(&data[4]))
+	binary.LittleEndian.PutUint32(data[:4], format)
+
+	if err := os.MkdirAll(shaderCacheDir, 0755); err != nil {
+		log.Printf("ERROR::SHADER: failed to create cache directory: %v", err)
+		return
+	}
+	if err := ioutil.WriteFile(filepath.Join(shaderCacheDir, key), data, 0644); err != nil {
+		log.Printf("ERROR::SHADER: failed to cache program binary: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package render
+
+import (
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// circleSegments are the sides of the polygon outlining a circle
+const circleSegments = 32
+
+// DrawLine draws a line of the given width between two points, with quads
+func (r *SpriteRenderer) DrawLine(from, to mgl.Vec2, width float32, color mgl.Vec3) {
+	direction := to.Sub(from)
+	length := direction.Len()
+	if length == 0 {
+		return
+	}
+	// The quad rotates around its top-left corner, shift it to center the line on the points
+	normal := mgl.Vec2{-direction.Y(), direction.X()}.Mul(width / 2 / length)
+	angle := float32(math.Atan2(float64(direction.Y()), float64(direction.X())))
+	r.Draw(from.Sub(normal), mgl.Vec2{length, width}, angle, color)
+}
+
+// DrawRectOutline draws the outline of a rectangle, the lines inside its area
+func (r *SpriteRenderer) DrawRectOutline(position, size mgl.Vec2, width float32, color mgl.Vec3) {
+	r.Draw(position, mgl.Vec2{size.X(), width}, 0, color)
+	r.Draw(position.Add(mgl.Vec2{0, size.Y() - width}), mgl.Vec2{size.X(), width}, 0, color)
+	r.Draw(position, mgl.Vec2{width, size.Y()}, 0, color)
+	r.Draw(position.Add(mgl.Vec2{size.X() - width, 0}), mgl.Vec2{width, size.Y()}, 0, color)
+}
+
+// DrawCircleOutline draws the outline of a circle as a polygon
+func (r *SpriteRenderer) DrawCircleOutline(center mgl.Vec2, radius, width float32, color mgl.Vec3) {
+	point := func(i int) mgl.Vec2 {
+		angle := float64(i) * 2 * math.Pi / circleSegments
+		return center.Add(mgl.Vec2{float32(math.Cos(angle)), float32(math.Sin(angle))}.Mul(radius))
+	}
+	for i := 0; i < circleSegments; i++ {
+		r.DrawLine(point(i), point(i+1), width, color)
+	}
+}
@@ -0,0 +1,113 @@
+package render
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// BlendMode is how the sprites are composed with what's drawn below them
+type BlendMode int
+
+// Blend modes of the sprites
+const (
+	BlendAlpha    BlendMode = iota // Cover what's below by the opacity of the sprite
+	BlendAdditive                  // Add the color to what's below, to make it glow
+)
+
+// SpriteRenderer renders a gameOject
+type SpriteRenderer struct {
+	shader  *Shader
+	quadVao uint32
+	quadVbo uint32
+	Alpha   float32   // Opacity of the sprites, from 0 to 1
+	Blend   BlendMode // How the sprites are composed, the alpha blending is restored after each one
+}
+
+// NewSpriteRenderer returns a renderer drawing quads with the shader
+func NewSpriteRenderer(shader *Shader) *SpriteRenderer {
+	renderer := SpriteRenderer{
+		shader: shader,
+		Alpha:  1,
+	}
+	renderer.initRenderData()
+
+	return &renderer
+}
+
+func (r *SpriteRenderer) initRenderData() {
+	// Configure VAO/VBO
+	vertices := []float32{
+		0.0, 1.0,
+		1.0, 0.0,
+		0.0, 0.0,
+
+		0.0, 1.0,
+		1.0, 1.0,
+		1.0, 0.0,
+	}
+
+	gl.GenVertexArrays(1, &r.quadVao)
+	Objects.Track(VertexArrayObject, r.quadVao)
+	gl.GenBuffers(1, &r.quadVbo)
+	Objects.Track(BufferObject, r.quadVbo)
+	gl.BindVertexArray(r.quadVao)
+	// Fill mesh buffer
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.quadVbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 4*len(vertices), gl.Ptr(vertices), gl.STATIC_DRAW)
+	// Set mesh attributes
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 2, gl.FLOAT, false, 0, nil)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+	CheckError("SpriteRenderer.initRenderData")
+}
+
+// Delete releases the quad buffers
+func (r *SpriteRenderer) Delete() {
+	gl.DeleteVertexArrays(1, &r.quadVao)
+	Objects.Untrack(VertexArrayObject, r.quadVao)
+	gl.DeleteBuffers(1, &r.quadVbo)
+	Objects.Untrack(BufferObject, r.quadVbo)
+}
+
+// Draw draws a gameObject
+func (r *SpriteRenderer) Draw(position, size mgl.Vec2, rotation float32, color mgl.Vec3) {
+	r.shader.Use().SetInteger("useImage", 0, false)
+	r.draw(position, size, rotation, color)
+	CheckError("SpriteRenderer.Draw")
+}
+
+// DrawTexture draws a gameObject with the texture, tinted by the color
+func (r *SpriteRenderer) DrawTexture(texture *Texture2D, position, size mgl.Vec2, rotation float32, color mgl.Vec3) {
+	r.shader.Use().SetInteger("useImage", 1, false)
+	gl.ActiveTexture(gl.TEXTURE0)
+	texture.Bind()
+	r.draw(position, size, rotation, color)
+	CheckError("SpriteRenderer.DrawTexture")
+}
+
+func (r *SpriteRenderer) draw(position, size mgl.Vec2, rotation float32, color mgl.Vec3) {
+	// Prepare transformations
+	var model mgl.Mat4
+	tMat := mgl.Translate2D(position.X(), position.Y())
+	rMat := mgl.HomogRotate2D(rotation)
+	sMat := mgl.Scale2D(size.X(), size.Y())
+
+	model = tMat.Mul3(rMat.Mul3(sMat)).Mat4()
+
+	r.shader.Use()
+	r.shader.SetMatrix4("model", model, false)
+	r.shader.SetVector3v("spriteColor", color, false)
+	r.shader.SetFloat("spriteAlpha", r.Alpha, false)
+
+	if r.Blend == BlendAdditive {
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE)
+	}
+	gl.BindVertexArray(r.quadVao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+	gl.BindVertexArray(0)
+	if r.Blend == BlendAdditive {
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	}
+}
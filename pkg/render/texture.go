@@ -0,0 +1,137 @@
+package render
+
+import "github.com/go-gl/gl/v4.1-core/gl"
+
+// Texture2D is able to store and configure a texture in OpenGL.
+// It also hosts utility functions for easy management.
+type Texture2D struct {
+	// Holds the ID of the texture object, used for all
+	// texture operations to reference to this particlar texture
+	ID uint32
+	// Texture image dimensions
+	width, height int32 // Width and height of loaded image in pixels
+	// Texture Format
+	internalFormat int32  // Format of texture object
+	imageFormat    uint32 // Format of loaded image
+	// Texture configuration
+	wrapS     int32 // Wrapping mode on S axis
+	wrapT     int32 // Wrapping mode on T axis
+	filterMin int32 // Filtering mode if texture pixels < screen pixels
+	filterMax int32 // Filtering mode if texture pixels > screen pixels
+	mipmaps   bool  // Generate mipmaps when generating the texture
+	filterSet bool  // The minifying filter was chosen with SetFilter, the mipmaps leave it alone
+}
+
+// NewTexture2D creates an empty texture object
+func NewTexture2D() *Texture2D {
+	texture := Texture2D{
+		internalFormat: gl.RGB,
+		imageFormat:    gl.RGB,
+		wrapS:          gl.REPEAT,
+		wrapT:          gl.REPEAT,
+		filterMin:      gl.LINEAR,
+		filterMax:      gl.LINEAR,
+	}
+	gl.GenTextures(1, &texture.ID)
+	Objects.Track(TextureObject, texture.ID)
+
+	return &texture
+}
+
+// Generate generates texture from image data
+func (t *Texture2D) Generate(width, height int32, data []byte) {
+	t.width = width
+	t.height = height
+	// Create Texture
+	gl.BindTexture(gl.TEXTURE_2D, t.ID)
+	// Rows of RGB images are not necessarily 4 bytes aligned
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	if data != nil {
+		gl.TexImage2D(gl.TEXTURE_2D, 0, t.internalFormat, width, height, 0, t.imageFormat, gl.UNSIGNED_BYTE, gl.Ptr(&data[0]))
+	} else {
+		gl.TexImage2D(gl.TEXTURE_2D, 0, t.internalFormat, width, height, 0, t.imageFormat, gl.UNSIGNED_BYTE, nil)
+	}
+	if t.mipmaps {
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+	}
+	// Set Texture wrap and filter modes
+	t.setParameters()
+	// Unbind texture
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	CheckError("Texture2D.Generate")
+}
+
+// SetWrap sets the wrapping modes on the S and T axes
+func (t *Texture2D) SetWrap(wrapS, wrapT int32) {
+	t.wrapS = wrapS
+	t.wrapT = wrapT
+	t.updateParameters()
+}
+
+// SetFilter sets the filtering modes used when minifying and magnifying the texture
+func (t *Texture2D) SetFilter(filterMin, filterMax int32) {
+	t.filterMin = filterMin
+	t.filterMax = filterMax
+	t.filterSet = true
+	t.updateParameters()
+}
+
+// SetMipmaps enables mipmaps generation, when trilinear is true the minifying
+// filter also blends between the two closest mipmaps, unless set with SetFilter
+func (t *Texture2D) SetMipmaps(enabled, trilinear bool) {
+	generate := enabled && !t.mipmaps
+	t.mipmaps = enabled
+	if !t.filterSet {
+		switch {
+		case enabled && trilinear:
+			t.filterMin = gl.LINEAR_MIPMAP_LINEAR
+		case enabled:
+			t.filterMin = gl.LINEAR_MIPMAP_NEAREST
+		default:
+			t.filterMin = gl.LINEAR
+		}
+	}
+	if generate && t.width != 0 && t.height != 0 {
+		// Already generated without them
+		gl.BindTexture(gl.TEXTURE_2D, t.ID)
+		gl.GenerateMipmap(gl.TEXTURE_2D)
+		gl.BindTexture(gl.TEXTURE_2D, 0)
+	}
+	t.updateParameters()
+}
+
+// SetFormat sets the format of the texture object and of the image data it is generated from
+func (t *Texture2D) SetFormat(internalFormat int32, imageFormat uint32) {
+	t.internalFormat = internalFormat
+	t.imageFormat = imageFormat
+}
+
+// Bind binds the texture as the current active GL_TEXTURE_2D texture object
+func (t *Texture2D) Bind() {
+	gl.BindTexture(gl.TEXTURE_2D, t.ID)
+}
+
+// Delete releases the texture object
+func (t *Texture2D) Delete() {
+	gl.DeleteTextures(1, &t.ID)
+	Objects.Untrack(TextureObject, t.ID)
+	t.ID = 0
+}
+
+// updateParameters applies the wrap and filter modes to an already generated texture, its
+// mipmaps are only generated along with the image
+func (t *Texture2D) updateParameters() {
+	if t.width == 0 || t.height == 0 {
+		return
+	}
+	gl.BindTexture(gl.TEXTURE_2D, t.ID)
+	t.setParameters()
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+}
+
+func (t *Texture2D) setParameters() {
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, t.wrapS)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, t.wrapT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, t.filterMin)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, t.filterMax)
+}
@@ -0,0 +1,48 @@
+package render
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Viewport is the area of the framebuffer where the game virtual resolution is displayed
+type Viewport struct {
+	X, Y, Width, Height int32
+}
+
+// NewViewport fits the virtual resolution into the framebuffer keeping
+// its aspect ratio, leaving bars on the sides when they don't match
+func NewViewport(framebufferWidth, framebufferHeight, virtualWidth, virtualHeight int) Viewport {
+	scaleX := float32(framebufferWidth) / float32(virtualWidth)
+	scaleY := float32(framebufferHeight) / float32(virtualHeight)
+	scale := scaleX
+	if scaleY < scale {
+		scale = scaleY
+	}
+	width := int32(float32(virtualWidth) * scale)
+	height := int32(float32(virtualHeight) * scale)
+
+	return Viewport{
+		X:      (int32(framebufferWidth) - width) / 2,
+		Y:      (int32(framebufferHeight) - height) / 2,
+		Width:  width,
+		Height: height,
+	}
+}
+
+// Apply sets the viewport as the current OpenGL viewport
+func (v Viewport) Apply() {
+	gl.Viewport(v.X, v.Y, v.Width, v.Height)
+}
+
+// ToVirtual converts a position in framebuffer pixels, from the top left corner, to the virtual resolution
+func (v Viewport) ToVirtual(x, y float32, virtualWidth, virtualHeight int) mgl.Vec2 {
+	if v.Width == 0 || v.Height == 0 {
+		return mgl.Vec2{}
+	}
+	// The viewport is centered, so its offset is the same from the top and the bottom
+	return mgl.Vec2{
+		(x - float32(v.X)) * float32(virtualWidth) / float32(v.Width),
+		(y - float32(v.Y)) * float32(virtualHeight) / float32(v.Height),
+	}
+}
@@ -0,0 +1,341 @@
+// Package resources loads and caches the shaders, textures, fonts and levels used by a game
+package resources
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg" // Register JPEG decoder
+	_ "image/png"  // Register PNG decoder
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/lucatironi/go-pong/pkg/level"
+	"github.com/lucatironi/go-pong/pkg/render"
+	"github.com/lucatironi/go-pong/pkg/text"
+)
+
+// ResourceManager hosts several functions to load Textures, Fonts and Shaders.
+// Textures and fonts are reference counted: every Load call acquires a reference
+// that has to be given back with the matching Release call, when the last
+// reference is released the resource is unloaded.
+type ResourceManager struct {
+	shaders      map[string]render.Shader
+	textures     map[string]*render.Texture2D
+	textureFiles map[string]*watchedFile
+	textureRefs  map[string]int
+	fonts        map[string]*text.Font
+	fontRefs     map[string]int
+	levels       map[string]*level.Level
+	HotReload    bool    // Re-upload textures when their files change
+	reloadTimer  float64 // Time left until the next check of the watched files
+	LeakCheck    bool    // Log the resources still referenced when reporting leaks
+	KeepErrors   bool    // Collect shader errors instead of panicking
+	errors       []string
+}
+
+// watchedFile holds the last known modification time of a resource file
+type watchedFile struct {
+	path    string
+	modTime time.Time
+}
+
+// hotReloadInterval is how often the files are checked for changes, in seconds
+const hotReloadInterval = 0.5
+
+// NewResourceManager returns an empty resource manager
+func NewResourceManager() *ResourceManager {
+	return &ResourceManager{
+		shaders:      make(map[string]render.Shader),
+		textures:     make(map[string]*render.Texture2D),
+		textureFiles: make(map[string]*watchedFile),
+		textureRefs:  make(map[string]int),
+		fonts:        make(map[string]*text.Font),
+		fontRefs:     make(map[string]int),
+		levels:       make(map[string]*level.Level),
+	}
+}
+
+// LoadShader loads (and generates) a shader program from file loading vertex, fragment (and geometry) shader's source code. If geometryShaderFile is not empty, it also loads a geometry shader
+func (r *ResourceManager) LoadShader(vertexShaderFile, fragmentShaderFile, geometryShaderFile, name string) render.Shader {
+	shader, err := r.loadShaderFromFile(vertexShaderFile, fragmentShaderFile, geometryShaderFile)
+	if err != nil {
+		if !r.KeepErrors {
+			panic(fmt.Errorf("shader %v: %v", name, err))
+		}
+		// Keep going with an empty program, the error is displayed on screen
+		log.Printf("ERROR::SHADER: %v: %v", name, err)
+		r.errors = append(r.errors, fmt.Sprintf("shader %v: %v", name, err))
+	}
+	r.shaders[name] = shader
+	return r.shaders[name]
+}
+
+// Errors returns the errors collected while loading resources
+func (r *ResourceManager) Errors() []string {
+	return r.errors
+}
+
+// GetShader retrieves a stored shader
+func (r *ResourceManager) GetShader(name string) *render.Shader {
+	shader := r.shaders[name]
+	return &shader
+}
+
+// LoadTexture loads (and generates) a texture from a PNG or JPEG file and acquires a reference to it,
+// textures are cached so loading the same name twice returns the already loaded texture. A file that
+// can't be decoded acquires nothing
+func (r *ResourceManager) LoadTexture(file, name string) (*render.Texture2D, error) {
+	if texture, ok := r.textures[name]; ok {
+		r.textureRefs[name]++
+		return texture, nil
+	}
+	texture, err := r.loadTextureFromFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("texture %v: %v", name, err)
+	}
+	r.textureRefs[name]++
+	r.textures[name] = texture
+	r.textureFiles[name] = &watchedFile{path: file, modTime: fileModTime(file)}
+	return texture, nil
+}
+
+// ReleaseTexture gives back a reference to a texture, unloading it when it's no longer used
+func (r *ResourceManager) ReleaseTexture(name string) {
+	if r.textureRefs[name] <= 0 {
+		log.Printf("ERROR::RESOURCEMANAGER: released texture %v more times than loaded", name)
+		return
+	}
+	r.textureRefs[name]--
+	if r.textureRefs[name] > 0 {
+		return
+	}
+	r.textures[name].Delete()
+	delete(r.textures, name)
+	delete(r.textureFiles, name)
+	delete(r.textureRefs, name)
+}
+
+// GetTexture retrieves a stored texture
+func (r *ResourceManager) GetTexture(name string) *render.Texture2D {
+	return r.textures[name]
+}
+
+// LoadFont loads (and pre-compiles the glyphs of) a font from a TrueType file at the given size and acquires
+// a reference to it, fonts are cached so loading the same name twice returns the already loaded font
+func (r *ResourceManager) LoadFont(name, file string, size float64) *text.Font {
+	r.fontRefs[name]++
+	if font, ok := r.fonts[name]; ok {
+		return font
+	}
+	r.fonts[name] = text.NewFont(file, size)
+	return r.fonts[name]
+}
+
+// ReleaseFont gives back a reference to a font, unloading it when it's no longer used
+func (r *ResourceManager) ReleaseFont(name string) {
+	if r.fontRefs[name] <= 0 {
+		log.Printf("ERROR::RESOURCEMANAGER: released font %v more times than loaded", name)
+		return
+	}
+	r.fontRefs[name]--
+	if r.fontRefs[name] > 0 {
+		return
+	}
+	r.fonts[name].Delete()
+	delete(r.fonts, name)
+	delete(r.fontRefs, name)
+}
+
+// GetFont retrieves a stored font
+func (r *ResourceManager) GetFont(name string) *text.Font {
+	return r.fonts[name]
+}
+
+// LoadLevel loads a level from a JSON file, levels are cached so loading the same name twice
+// returns the already loaded level. They hold no OpenGL resources and are never released
+func (r *ResourceManager) LoadLevel(file, name string) (*level.Level, error) {
+	if l, ok := r.levels[name]; ok {
+		return l, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	l, err := level.Parse(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("level %v: %v", name, err)
+	}
+	r.levels[name] = l
+	return l, nil
+}
+
+// ReleaseLevel forgets a level, the next load reads its file again
+func (r *ResourceManager) ReleaseLevel(name string) {
+	delete(r.levels, name)
+}
+
+// GetLevel retrieves a stored level
+func (r *ResourceManager) GetLevel(name string) *level.Level {
+	return r.levels[name]
+}
+
+// ReloadTextures checks the loaded textures' files for changes, when hot reload is enabled,
+// and uploads the new images into the existing textures, so they are updated everywhere they are used
+func (r *ResourceManager) ReloadTextures(deltaTime float64) {
+	if !r.HotReload {
+		return
+	}
+	r.reloadTimer -= deltaTime
+	if r.reloadTimer > 0.0 {
+		return
+	}
+	r.reloadTimer = hotReloadInterval
+
+	for name, file := range r.textureFiles {
+		modTime := fileModTime(file.path)
+		if modTime.IsZero() || !modTime.After(file.modTime) {
+			continue
+		}
+		// The file could be still being written, retry on the next check in case of errors
+		width, height, data, opaque, err := readImageFile(file.path)
+		if err != nil {
+			log.Printf("ERROR::RESOURCEMANAGER: failed to reload texture %v: %v", name, err)
+			continue
+		}
+		file.modTime = modTime
+		texture := r.textures[name]
+		setTextureFormat(texture, opaque)
+		texture.Generate(width, height, data)
+		log.Printf("RESOURCEMANAGER: reloaded texture %v from %v", name, file.path)
+	}
+}
+
+// ReportLeaks logs the textures and fonts that are still referenced, when leak checking is enabled.
+// It is meant to be called at shutdown, after every user released its resources
+func (r *ResourceManager) ReportLeaks() {
+	if !r.LeakCheck {
+		return
+	}
+	for name, refs := range r.textureRefs {
+		log.Printf("RESOURCEMANAGER: leaked texture %v (%v references)", name, refs)
+	}
+	for name, refs := range r.fontRefs {
+		log.Printf("RESOURCEMANAGER: leaked font %v (%v references)", name, refs)
+	}
+}
+
+// Clear (Properly) delete all shaders, textures and fonts
+func (r *ResourceManager) Clear() {
+	for _, shader := range r.shaders {
+		shader.Delete()
+	}
+	for _, texture := range r.textures {
+		texture.Delete()
+	}
+	for _, font := range r.fonts {
+		font.Delete()
+	}
+	r.shaders = make(map[string]render.Shader)
+	r.textures = make(map[string]*render.Texture2D)
+	r.textureFiles = make(map[string]*watchedFile)
+	r.textureRefs = make(map[string]int)
+	r.fonts = make(map[string]*text.Font)
+	r.fontRefs = make(map[string]int)
+	r.levels = make(map[string]*level.Level)
+}
+
+func (r *ResourceManager) loadShaderFromFile(vertexShaderFile, fragmentShaderFile, geometryShaderFile string) (render.Shader, error) {
+	geometrySource := ""
+	if geometryShaderFile != "" {
+		geometrySource = readShaderFile(geometryShaderFile)
+	}
+	shader := render.Shader{}
+	err := shader.Compile(readShaderFile(vertexShaderFile), readShaderFile(fragmentShaderFile), geometrySource)
+	return shader, err
+}
+
+func (r *ResourceManager) loadTextureFromFile(file string) (*render.Texture2D, error) {
+	width, height, data, opaque, err := readImageFile(file)
+	if err != nil {
+		return nil, err
+	}
+	texture := render.NewTexture2D()
+	// Sprites are scaled to the virtual resolution, use trilinear filtering
+	texture.SetMipmaps(true, true)
+	setTextureFormat(texture, opaque)
+	texture.Generate(width, height, data)
+	return texture, nil
+}
+
+func setTextureFormat(texture *render.Texture2D, opaque bool) {
+	if opaque {
+		texture.SetFormat(gl.RGB, gl.RGB)
+	} else {
+		texture.SetFormat(gl.RGBA, gl.RGBA)
+	}
+}
+
+// readImageFile decodes an image file returning its pixels as tightly packed
+// RGB (when the image is opaque) or RGBA rows. Rows are kept top to bottom:
+// the projection has the y axis pointing down, so the first row of the image
+// ends up at the top of the sprite without flipping it.
+func readImageFile(filePath string) (int32, int32, []byte, bool, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0, nil, false, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return 0, 0, nil, false, err
+	}
+	// Convert any decoded color model into non premultiplied RGBA
+	bounds := img.Bounds()
+	rgba := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	if !rgba.Opaque() {
+		return int32(bounds.Dx()), int32(bounds.Dy()), rgba.Pix, false, nil
+	}
+	// Drop the alpha channel
+	rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for i := 0; i < len(rgba.Pix); i += 4 {
+		rgb = append(rgb, rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2])
+	}
+	return int32(bounds.Dx()), int32(bounds.Dy()), rgb, true, nil
+}
+
+// fileModTime returns the modification time of a file, or the zero time if it can't be read
+func fileModTime(filePath string) time.Time {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+func readShaderFile(filePath string) string {
+	src := ""
+	f, err := os.Open(filePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		src += "\n" + scanner.Text()
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	src += "\x00"
+
+	return src
+}
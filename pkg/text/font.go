@@ -0,0 +1,153 @@
+// Package text loads TrueType fonts into glyph textures and renders text with them
+package text
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"io/ioutil"
+	"os"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/golang/freetype"
+	"github.com/golang/freetype/truetype"
+	"github.com/lucatironi/go-pong/pkg/render"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// Character holds all state information relevant to a character as loaded using FreeType
+type Character struct {
+	textureID uint32 // ID handle of the glyph texture
+	width     int    // glyph width
+	height    int    // glyph height
+	advance   int    // glyph advance
+	bearingH  int    // glyph bearing horizontal
+	bearingV  int    // glyph bearing vertical
+}
+
+// Font holds the list of pre-compiled Characters of a font face at a given size
+type Font struct {
+	chars []*Character // Holds a list of pre-compiled Characters
+	size  float64      // Size the font has been loaded with
+}
+
+// NewFont pre-compiles a list of characters from the given font file
+func NewFont(fontFile string, fontSize float64) *Font {
+	f := Font{
+		size:  fontSize,
+		chars: make([]*Character, 0, 96),
+	}
+
+	fd, err := os.Open(fontFile)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("ERROR::FONT: %v", err))
+	}
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(fd)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("ERROR::FONT: %v", err))
+	}
+
+	// Read the truetype font.
+	ttf, err := truetype.Parse(data)
+	if err != nil {
+		fmt.Println(fmt.Sprintf("ERROR::FONT: %v", err))
+	}
+
+	// Make each gylph
+	for ch := rune(32); ch <= rune(127); ch++ {
+		char := new(Character)
+
+		// Create new face to measure glyph dimensions
+		ttfFace := truetype.NewFace(ttf, &truetype.Options{
+			Size:    fontSize,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+
+		gBnd, gAdv, ok := ttfFace.GlyphBounds(ch)
+		if ok != true {
+			fmt.Println(fmt.Sprintf("ERROR::FONT: ttf face glyphBounds error"))
+		}
+
+		gh := int32((gBnd.Max.Y - gBnd.Min.Y) >> 6)
+		gw := int32((gBnd.Max.X - gBnd.Min.X) >> 6)
+
+		// If gylph has no dimensions set to a max value
+		if gw == 0 || gh == 0 {
+			gBnd = ttf.Bounds(fixed.Int26_6(fontSize))
+			gw = int32((gBnd.Max.X - gBnd.Min.X) >> 6)
+			gh = int32((gBnd.Max.Y - gBnd.Min.Y) >> 6)
+		}
+
+		// The glyph's ascent and descent equal -bounds.Min.Y and +bounds.Max.Y.
+		gAscent := int(-gBnd.Min.Y) >> 6
+		gdescent := int(gBnd.Max.Y) >> 6
+
+		// Set w,h and adv, bearing V and bearing H in char
+		char.width = int(gw)
+		char.height = int(gh)
+		char.advance = int(gAdv)
+		char.bearingV = gdescent
+		char.bearingH = (int(gBnd.Min.X) >> 6)
+
+		// Create image to draw glyph
+		fg, bg := image.White, image.Black
+		rect := image.Rect(0, 0, int(gw), int(gh))
+		rgba := image.NewRGBA(rect)
+		draw.Draw(rgba, rgba.Bounds(), bg, image.ZP, draw.Src)
+
+		// Create a freetype context for drawing
+		c := freetype.NewContext()
+		c.SetDPI(72)
+		c.SetFont(ttf)
+		c.SetFontSize(fontSize)
+		c.SetClip(rgba.Bounds())
+		c.SetDst(rgba)
+		c.SetSrc(fg)
+		c.SetHinting(font.HintingFull)
+
+		// Set the glyph dot
+		px := 0 - (int(gBnd.Min.X) >> 6)
+		py := (gAscent)
+		pt := freetype.Pt(px, py)
+
+		// Draw the text from mask to image
+		_, err = c.DrawString(string(ch), pt)
+		if err != nil {
+			fmt.Println(fmt.Sprintf("ERROR::FONT: %v", err))
+		}
+
+		// Generate texture
+		var texture uint32
+		gl.GenTextures(1, &texture)
+		render.Objects.Track(render.TextureObject, texture)
+		gl.BindTexture(gl.TEXTURE_2D, texture)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+		gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+		gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, int32(rgba.Rect.Dx()), int32(rgba.Rect.Dy()), 0,
+			gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(rgba.Pix))
+
+		char.textureID = texture
+
+		// Add char to chars list
+		f.chars = append(f.chars, char)
+	}
+
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	return &f
+}
+
+// Delete releases the glyph textures of the font
+func (f *Font) Delete() {
+	for _, char := range f.chars {
+		gl.DeleteTextures(1, &char.textureID)
+		render.Objects.Untrack(render.TextureObject, char.textureID)
+	}
+	f.chars = nil
+}
@@ -0,0 +1,136 @@
+package text
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// TextRenderer renders text displayed by a font loaded using the FreeType library.
+// A single font is loaded, processed into a list of Character items for later rendering.
+type TextRenderer struct {
+	font   *Font          // Font holding the list of pre-compiled Characters
+	shader *render.Shader // Shader used for text rendering
+	vao    uint32         // Render state
+	vbo    uint32         // Render state
+	Alpha  float32        // Opacity of the text, from 0 to 1
+}
+
+// NewTextRenderer returns a renderer drawing text in the given font with the shader
+func NewTextRenderer(shader *render.Shader, font *Font) *TextRenderer {
+	renderer := TextRenderer{
+		shader: shader,
+		font:   font,
+		Alpha:  1,
+	}
+	renderer.shader.SetInteger("text", 0, true)
+	renderer.initRenderData()
+
+	return &renderer
+}
+
+// Delete releases the render buffers, the font is owned by the ResourceManager
+func (t *TextRenderer) Delete() {
+	gl.DeleteVertexArrays(1, &t.vao)
+	render.Objects.Untrack(render.VertexArrayObject, t.vao)
+	gl.DeleteBuffers(1, &t.vbo)
+	render.Objects.Untrack(render.BufferObject, t.vbo)
+}
+
+// SetFont changes the font used to render text
+func (t *TextRenderer) SetFont(font *Font) {
+	t.font = font
+}
+
+func (t *TextRenderer) initRenderData() {
+	// Configure VAO/VBO
+	gl.GenVertexArrays(1, &t.vao)
+	render.Objects.Track(render.VertexArrayObject, t.vao)
+	gl.GenBuffers(1, &t.vbo)
+	render.Objects.Track(render.BufferObject, t.vbo)
+	gl.BindVertexArray(t.vao)
+	// Fill mesh buffer
+	gl.BindBuffer(gl.ARRAY_BUFFER, t.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 6*4*4, nil, gl.DYNAMIC_DRAW)
+	// Set mesh attributes
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 4, gl.FLOAT, false, 4*4, gl.PtrOffset(0))
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+	gl.BindVertexArray(0)
+}
+
+// MeasureText returns the width and the height of a string of text as RenderText would draw it
+func (t *TextRenderer) MeasureText(scale float32, text string, argv ...interface{}) (float32, float32) {
+	lowChar := rune(32)
+	var width, height float32
+	for _, char := range fmt.Sprintf(text, argv...) {
+		if char < lowChar || int(char-lowChar) >= len(t.font.chars) {
+			continue
+		}
+		charRune := t.font.chars[char-lowChar]
+		width += float32((charRune.advance >> 6)) * scale
+		if h := float32(charRune.height) * scale; h > height {
+			height = h
+		}
+	}
+	return width, height
+}
+
+// RenderText renders a string of text using the precompiled list of characters
+func (t *TextRenderer) RenderText(x, y, scale float32, color mgl.Vec3, text string, argv ...interface{}) {
+	t.shader.Use()
+	t.shader.SetVector3v("textColor", color, false)
+	t.shader.SetFloat("textAlpha", t.Alpha, false)
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindVertexArray(t.vao)
+
+	lowChar := rune(32)
+	indices := []rune(fmt.Sprintf(text, argv...))
+
+	for i := range indices {
+		char := indices[i]
+		// Skip runes without a glyph
+		if char < lowChar || int(char-lowChar) >= len(t.font.chars) {
+			continue
+		}
+		// Find rune in chars list
+		charRune := t.font.chars[char-lowChar]
+
+		// Calculate position and size for current rune
+		xPos := x + float32(charRune.bearingH)*scale
+		yPos := y - float32(charRune.height-charRune.bearingV)*scale
+		w := float32(charRune.width) * scale
+		h := float32(charRune.height) * scale
+
+		// Update VBO for each character
+		var vertices = []float32{
+			// X, Y, U, V
+			xPos, yPos, 0.0, 0.0,
+			xPos + w, yPos, 1.0, 0.0,
+			xPos, yPos + h, 0.0, 1.0,
+			xPos, yPos + h, 0.0, 1.0,
+			xPos + w, yPos, 1.0, 0.0,
+			xPos + w, yPos + h, 1.0, 1.0}
+
+		// Render glyph texture over quad
+		gl.BindTexture(gl.TEXTURE_2D, charRune.textureID)
+		// Update content of VBO memory
+		gl.BindBuffer(gl.ARRAY_BUFFER, t.vbo)
+		// Be sure to use glBufferSubData and not glBufferData
+		gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(vertices)*4, gl.Ptr(vertices))
+
+		gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+		// Render quad
+		gl.DrawArrays(gl.TRIANGLES, 0, 6)
+
+		// Now advance cursors for next glyph (note that advance is number of 1/64 pixels)
+		x += float32((charRune.advance >> 6)) * scale // Bitshift by 6 to get value in pixels (2^6 = 64 (divide amount of 1/64th pixels by 64 to get amount of pixels))
+	}
+	// clear opengl textures and programs
+	gl.BindVertexArray(0)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	render.CheckError("TextRenderer.RenderText")
+}
@@ -0,0 +1,48 @@
+package tween
+
+import "math"
+
+// Easing maps the linear progress of a tween, from 0 to 1, to the progress applied to the value
+type Easing func(t float64) float64
+
+// Linear applies the progress as it is
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad starts slowly and speeds up to the end
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad starts fast and slows down to the end
+func OutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// InOutQuad starts and ends slowly
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// OutCubic starts fast and slows down to the end, more than OutQuad
+func OutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// OutBack overshoots the end a little before settling on it
+func OutBack(t float64) float64 {
+	const overshoot = 1.70158
+	return 1 + (overshoot+1)*math.Pow(t-1, 3) + overshoot*math.Pow(t-1, 2)
+}
+
+// OutElastic springs past the end a few times before settling on it
+func OutElastic(t float64) float64 {
+	if t <= 0 || t >= 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*2*math.Pi/3) + 1
+}
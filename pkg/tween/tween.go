@@ -0,0 +1,111 @@
+// Package tween animates values over time along easing curves,
+// it has no OpenGL nor GLFW dependencies so it can be used and tested without a window
+package tween
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+// Tween moves a value from a start to an end in the given time, calling back when it's done
+type Tween struct {
+	duration float64 // Seconds
+	elapsed  float64
+	easing   Easing
+	apply    func(progress float32)
+	onDone   func()
+	done     bool
+}
+
+// newTween returns a tween applying the eased progress through the function, starting from the
+// beginning right away
+func newTween(duration float64, easing Easing, apply func(progress float32)) *Tween {
+	if easing == nil {
+		easing = Linear
+	}
+	t := &Tween{duration: duration, easing: easing, apply: apply}
+	t.apply(0)
+	return t
+}
+
+// NewFloat returns a tween moving the target from a value to another
+func NewFloat(target *float32, from, to float32, duration float64, easing Easing) *Tween {
+	return newTween(duration, easing, func(progress float32) {
+		*target = from + (to-from)*progress
+	})
+}
+
+// NewVec2 returns a tween moving the target from a position to another
+func NewVec2(target *mgl.Vec2, from, to mgl.Vec2, duration float64, easing Easing) *Tween {
+	return newTween(duration, easing, func(progress float32) {
+		*target = from.Add(to.Sub(from).Mul(progress))
+	})
+}
+
+// NewColor returns a tween blending the target from a color to another
+func NewColor(target *mgl.Vec3, from, to mgl.Vec3, duration float64, easing Easing) *Tween {
+	return newTween(duration, easing, func(progress float32) {
+		*target = from.Add(to.Sub(from).Mul(progress))
+	})
+}
+
+// OnDone sets the function called once the value reaches the end
+func (t *Tween) OnDone(onDone func()) *Tween {
+	t.onDone = onDone
+	return t
+}
+
+// Update advances the tween, applying the value reached
+func (t *Tween) Update(deltaTime float64) {
+	if t.done {
+		return
+	}
+	t.elapsed += deltaTime
+	if t.elapsed < t.duration {
+		t.apply(float32(t.easing(t.elapsed / t.duration)))
+		return
+	}
+	t.apply(1)
+	t.done = true
+	if t.onDone != nil {
+		t.onDone()
+	}
+}
+
+// Done tells if the value reached the end
+func (t *Tween) Done() bool {
+	return t.done
+}
+
+// Group updates tweens together, dropping them once done
+type Group struct {
+	tweens []*Tween
+}
+
+// Add starts updating the tween with the group and returns it
+func (g *Group) Add(t *Tween) *Tween {
+	g.tweens = append(g.tweens, t)
+	return t
+}
+
+// Update advances the tweens of the group, the ones added by the callbacks start with the next update
+func (g *Group) Update(deltaTime float64) {
+	count := len(g.tweens)
+	for i := 0; i < count; i++ {
+		g.tweens[i].Update(deltaTime)
+	}
+	running := g.tweens[:0]
+	for _, t := range g.tweens {
+		if !t.done {
+			running = append(running, t)
+		}
+	}
+	g.tweens = running
+}
+
+// Clear stops all the tweens, leaving the values where they are
+func (g *Group) Clear() {
+	g.tweens = nil
+}
+
+// Len returns the tweens running
+func (g *Group) Len() int {
+	return len(g.tweens)
+}
@@ -0,0 +1,23 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+// Pointer is the state of the mouse over the screens, in virtual resolution coordinates
+type Pointer struct {
+	Position mgl.Vec2
+	Moved    bool // The pointer moved since the previous frame, the item under it is highlighted
+	Click    bool // The left button was pressed
+	Down     bool // The left button is held, dragging
+	Erase    bool // The right button was pressed
+}
+
+// Over tells if the pointer is inside the rectangle
+func (p Pointer) Over(position, size mgl.Vec2) bool {
+	return p.Position.X() >= position.X() && p.Position.X() < position.X()+size.X() &&
+		p.Position.Y() >= position.Y() && p.Position.Y() < position.Y()+size.Y()
+}
+
+// ToVirtual converts a position in framebuffer pixels, from the top left corner, to the virtual resolution
+func (g *Game) ToVirtual(x, y float64) mgl.Vec2 {
+	return g.viewport.ToVirtual(float32(x), float32(y), g.width, g.height)
+}
@@ -0,0 +1,302 @@
+package pong
+
+import (
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+// PowerUpKind is the effect a power-up grants to the player whose ball picks it up
+type PowerUpKind int
+
+// The power-ups
+const (
+	PowerUpEnlarge   PowerUpKind = iota // The paddles of the player grow for a while
+	PowerUpShrink                       // The paddles of the opponent shrink for a while
+	PowerUpSpeedUp                      // The ball speeds up at once
+	PowerUpMultiBall                    // A second ball joins the rally
+	PowerUpSticky                       // The paddles of the player hold the ball for a moment before sending it back, for a while
+	powerUpKinds
+)
+
+var powerUpNames = []string{"enlarge", "shrink", "speed up", "multi-ball", "sticky"}
+
+var powerUpColors = [powerUpKinds]mgl.Vec3{
+	{0.2, 0.9, 0.3},
+	{0.9, 0.2, 0.3},
+	{1.0, 0.6, 0.1},
+	{0.3, 0.7, 1.0},
+	{0.9, 0.3, 0.9},
+}
+
+var (
+	powerUpInterval     = 8.0 // Seconds between the power-ups appearing
+	powerUpLife         = 6.0 // Seconds a power-up waits on the court for the ball
+	powerUpLimit        = 2   // Power-ups waiting on the court at once
+	powerUpSize         = float32(48)
+	powerUpDuration     = 8.0 // Seconds the effects on the paddles last
+	powerUpEnlargeScale = float32(1.5)
+	powerUpShrinkScale  = float32(0.6)
+	powerUpSpeedScale   = float32(1.4)
+	powerUpBallLimit    = 2   // Extra balls on the court at once
+	stickyHold          = 0.5 // Seconds a sticky paddle holds the ball
+)
+
+// String returns the name of the power-up
+func (k PowerUpKind) String() string {
+	if k < 0 || k >= powerUpKinds {
+		return "unknown"
+	}
+	return powerUpNames[k]
+}
+
+// PowerUp is a collectible modifier waiting on the court for the ball to pick it up
+type PowerUp struct {
+	GameObject
+	kind PowerUpKind
+	life float64 // Seconds left before it vanishes
+}
+
+// powerUps are the power-ups on the court and the effects they granted, with the power-ups mutator
+type powerUps struct {
+	items   []PowerUp
+	next    float64                  // Seconds to the next power-up
+	effects [2][powerUpKinds]float64 // Seconds left of the effects on each player, by power-up
+	balls   []BallObject             // Extra balls of the multi-ball, gone once they leave the court
+	held    float64                  // Seconds the ball stays on the sticky paddle holding it
+	holder  int                      // Paddle holding the ball, by its index in the paddles of the game
+	offset  mgl.Vec2                 // Position of the held ball from the paddle
+}
+
+// clone returns a copy of the power-ups sharing nothing with them
+func (p powerUps) clone() powerUps {
+	p.items = append([]PowerUp(nil), p.items...)
+	p.balls = append([]BallObject(nil), p.balls...)
+	return p
+}
+
+// resetPowerUps clears the court of the power-ups and their effects
+func (g *Game) resetPowerUps() {
+	g.powerUps = powerUps{next: powerUpInterval}
+	g.sizePaddles()
+}
+
+// updatePowerUps counts down the effects and the power-ups on the court, spawning a new one now and then
+func (g *Game) updatePowerUps(deltaTime float64) {
+	if !g.rules.powerUps {
+		return
+	}
+	for player := range g.powerUps.effects {
+		for kind, left := range g.powerUps.effects[player] {
+			g.powerUps.effects[player][kind] = math.Max(left-deltaTime, 0)
+		}
+	}
+	g.sizePaddles()
+	items := g.powerUps.items[:0]
+	for _, item := range g.powerUps.items {
+		item.life -= deltaTime
+		if item.life > 0 {
+			items = append(items, item)
+		}
+	}
+	g.powerUps.items = items
+	g.powerUps.next -= deltaTime
+	if g.powerUps.next > 0 {
+		return
+	}
+	g.powerUps.next = powerUpInterval
+	if len(g.powerUps.items) >= powerUpLimit {
+		return
+	}
+	// In the middle third of the court, away from the paddles
+	width, height := float32(g.width), float32(g.height)
+	position := mgl.Vec2{
+		width/3 + g.random.Float32()*(width/3-powerUpSize),
+		powerUpSize + g.random.Float32()*(height-powerUpSize*3),
+	}
+	item := PowerUp{
+		GameObject: *newGameObject(position, mgl.Vec2{powerUpSize, powerUpSize}),
+		kind:       PowerUpKind(g.random.Intn(int(powerUpKinds))),
+		life:       powerUpLife,
+	}
+	item.color = powerUpColors[item.kind]
+	g.powerUps.items = append(g.powerUps.items, item)
+}
+
+// sizePaddles scales the paddles of each player by the effects on them, around their centers
+func (g *Game) sizePaddles() {
+	paddles, teams := g.paddles()
+	for i, paddle := range paddles {
+		effects := g.powerUps.effects[teams[i]-1]
+		height := g.rules.paddleSize.Y()
+		if effects[PowerUpEnlarge] > 0 {
+			height *= powerUpEnlargeScale
+		}
+		if effects[PowerUpShrink] > 0 {
+			height *= powerUpShrinkScale
+		}
+		if paddle.size.Y() != height {
+			paddle.position[1] += (paddle.size.Y() - height) / 2
+			paddle.size[1] = height
+		}
+	}
+}
+
+// collectPowerUps grants the power-ups the ball touches to the player who last hit it, the ball
+// goes through them after a serve
+func (g *Game) collectPowerUps(ball *BallObject, player int) {
+	if player == 0 {
+		return
+	}
+	items := g.powerUps.items[:0]
+	for _, item := range g.powerUps.items {
+		if _, ok := physics.CircleAABB(ball.Circle(), item.AABB()); ok {
+			g.grantPowerUp(item.kind, player, ball)
+			continue
+		}
+		items = append(items, item)
+	}
+	g.powerUps.items = items
+}
+
+// grantPowerUp applies the effect of a power-up picked up by the ball of the player
+func (g *Game) grantPowerUp(kind PowerUpKind, player int, ball *BallObject) {
+	switch kind {
+	case PowerUpEnlarge, PowerUpSticky:
+		g.powerUps.effects[player-1][kind] = powerUpDuration
+	case PowerUpShrink:
+		g.powerUps.effects[2-player][kind] = powerUpDuration
+	case PowerUpSpeedUp:
+		// Not past the fastest the rules allow, the ball would go through the paddles
+		if speed := ball.velocity.Len(); speed > 0 {
+			faster := float32(math.Min(float64(speed*powerUpSpeedScale), float64(g.rules.ballMaxSpeed)))
+			ball.velocity = ball.velocity.Mul(faster / speed)
+		}
+	case PowerUpMultiBall:
+		if len(g.powerUps.balls) >= powerUpBallLimit {
+			return
+		}
+		// The same way, mirrored up and down
+		extra := *ball
+		extra.velocity[1] = -extra.velocity.Y()
+		if extra.velocity.Y() == 0 {
+			extra.velocity[1] = extra.velocity.X() / 2
+		}
+		g.powerUps.balls = append(g.powerUps.balls, extra)
+	}
+}
+
+// stickBall makes the paddle that hit the ball hold it, when the player has sticky paddles
+func (g *Game) stickBall(player int, paddle *GameObject) {
+	if g.powerUps.effects[player-1][PowerUpSticky] <= 0 {
+		return
+	}
+	paddles, _ := g.paddles()
+	for i, other := range paddles {
+		if other == paddle {
+			g.powerUps.holder = i
+		}
+	}
+	g.powerUps.held = stickyHold
+	g.powerUps.offset = g.ball.position.Sub(paddle.position)
+}
+
+// holdBall keeps the ball on the sticky paddle holding it, following the paddle, it tells if it did
+func (g *Game) holdBall(deltaTime float64) bool {
+	if g.powerUps.held <= 0 {
+		return false
+	}
+	g.powerUps.held -= deltaTime
+	paddles, _ := g.paddles()
+	g.ball.position = paddles[g.powerUps.holder].position.Add(g.powerUps.offset)
+	return true
+}
+
+// moveExtraBalls moves the balls of the multi-ball, bouncing them like the main one: a ball leaving
+// the court scores like it and is gone
+func (g *Game) moveExtraBalls(deltaTime float64, events *simulationEvents) {
+	balls := g.powerUps.balls[:0]
+	for _, ball := range g.powerUps.balls {
+		ball.previousPosition = ball.position
+		ball.Move(deltaTime, g.width, g.height)
+		g.bounceLevel(&ball)
+		g.bounceOffPaddles(&ball)
+		g.collectPowerUps(&ball, g.lastHit)
+		g.bounceEnds(&ball)
+		if scored := g.scoreGoal(&ball, events); scored != 0 {
+			if events.scored == 0 {
+				events.scored = scored
+			}
+			continue
+		}
+		balls = append(balls, ball)
+	}
+	g.powerUps.balls = balls
+}
+
+// drawPowerUps renders the power-ups on the court, blinking before they vanish
+func (g *Game) drawPowerUps(alpha float32) {
+	for _, item := range g.powerUps.items {
+		if item.life < 1.5 && int(item.life*8)%2 == 0 {
+			continue
+		}
+		g.renderer.Alpha = 0.3
+		g.renderer.Draw(item.position, item.size, 0, item.color)
+		g.renderer.Alpha = 1
+		g.renderer.DrawRectOutline(item.position, item.size, 3, item.color)
+		g.drawPowerUpIcon(item.kind, item.position.Add(item.size.Mul(0.5)), item.color)
+	}
+}
+
+// drawExtraBalls renders the balls of the multi-ball power-up
+func (g *Game) drawExtraBalls(alpha float32) {
+	for i := range g.powerUps.balls {
+		g.powerUps.balls[i].Draw(g.renderer, alpha)
+	}
+}
+
+// drawPowerUpIcon renders the sign of the power-up around the center: a tall or a short paddle,
+// a double arrow, three balls or a ball stuck to a paddle
+func (g *Game) drawPowerUpIcon(kind PowerUpKind, center mgl.Vec2, color mgl.Vec3) {
+	switch kind {
+	case PowerUpEnlarge:
+		g.renderer.Draw(center.Sub(mgl.Vec2{4, 16}), mgl.Vec2{8, 32}, 0, color)
+	case PowerUpShrink:
+		g.renderer.Draw(center.Sub(mgl.Vec2{4, 6}), mgl.Vec2{8, 12}, 0, color)
+	case PowerUpSpeedUp:
+		for _, x := range []float32{-10, 2} {
+			tip := center.Add(mgl.Vec2{x + 8, 0})
+			g.renderer.DrawLine(center.Add(mgl.Vec2{x, -10}), tip, 3, color)
+			g.renderer.DrawLine(tip, center.Add(mgl.Vec2{x, 10}), 3, color)
+		}
+	case PowerUpMultiBall:
+		for _, offset := range []mgl.Vec2{{-11, 2}, {1, 2}, {-5, -10}} {
+			g.renderer.Draw(center.Add(offset), mgl.Vec2{10, 10}, 0, color)
+		}
+	case PowerUpSticky:
+		g.renderer.Draw(center.Sub(mgl.Vec2{10, 14}), mgl.Vec2{8, 28}, 0, color)
+		g.renderer.Draw(center.Sub(mgl.Vec2{2, 5}), mgl.Vec2{10, 10}, 0, color)
+	}
+}
+
+// drawPowerUpEffects lists the effects on the players and the seconds they have left, under their scores
+func (g *Game) drawPowerUpEffects() {
+	if !g.rules.powerUps || (g.state != GameActive && g.state != GamePaused) {
+		return
+	}
+	for side, player := range g.screenPlayers() {
+		x := float32(g.width/2) - 300
+		if side == 1 {
+			x = float32(g.width/2) + 180
+		}
+		y := float32(300)
+		for kind, left := range g.powerUps.effects[player-1] {
+			if left <= 0 {
+				continue
+			}
+			g.text.RenderText(x, y, 0.25, powerUpColors[kind], "%v %.0fs", PowerUpKind(kind), math.Ceil(left))
+			y += 30
+		}
+	}
+}
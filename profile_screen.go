@@ -0,0 +1,270 @@
+package pong
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+var (
+	maxProfiles          = 10 // Profiles that fit the screen
+	maxProfileNameLength = 16
+)
+
+// Profile is a named player with the handicap and the career statistics
+type Profile struct {
+	Name     string
+	Handicap int     // Points the player starts the sets with
+	Rating   float64 // Elo rating, from the local matches
+	Stats    Stats
+}
+
+// ProfileControls are the commands of the profile screen for one frame
+type ProfileControls struct {
+	Navigation              // Activating a profile picks it for the left player, then for the right one
+	Player1, Player2 bool   // Pick the focused profile for the left or the right player
+	Left, Right      bool   // Change the handicap of the focused profile
+	Create           bool   // Start naming a new profile
+	Delete           bool   // Delete the focused profile, two are always kept
+	Text             string // Characters typed in the frame, naming a new profile
+	Erase            bool   // Erase the last character of the name
+	Close            bool   // Close the screen, or cancel naming a profile
+	Pointer          Pointer
+}
+
+// ProfileScreen lists the profiles with the career statistics of the focused one, the players pick
+// their profile before a match; it's up to the owner to save the changes
+type ProfileScreen struct {
+	game     *Game
+	profiles []Profile
+	players  [2]int // Profiles picked by the left and the right player
+	focus    focus
+	naming   bool // Typing the name of a new profile
+	name     string
+	open     bool
+}
+
+// NewProfileScreen returns a closed profile screen
+func NewProfileScreen(game *Game) *ProfileScreen {
+	s := &ProfileScreen{game: game}
+	if game.initialized {
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { s.draw() }))
+	}
+	return s
+}
+
+// Open shows the profiles, at least two, with the ones picked by the players
+func (s *ProfileScreen) Open(profiles []Profile, players [2]int) {
+	s.profiles = append([]Profile{}, profiles...)
+	s.players = players
+	s.naming = false
+	s.open = true
+	s.focus.reset(len(s.profiles))
+}
+
+// IsOpen tells if the screen is shown
+func (s *ProfileScreen) IsOpen() bool {
+	return s.open
+}
+
+// Profiles returns the current profiles
+func (s *ProfileScreen) Profiles() []Profile {
+	return append([]Profile{}, s.profiles...)
+}
+
+// Players returns the indices of the profiles picked by the left and the right player
+func (s *ProfileScreen) Players() [2]int {
+	return s.players
+}
+
+// CanDelete tells if the focused profile can be deleted, two profiles are always kept
+func (s *ProfileScreen) CanDelete() bool {
+	return s.open && !s.naming && len(s.profiles) > 2
+}
+
+// Focused returns the focused profile
+func (s *ProfileScreen) Focused() Profile {
+	return s.profiles[s.focus.index]
+}
+
+// Update applies the controls and reports whether the profiles or the players changed
+func (s *ProfileScreen) Update(controls ProfileControls) bool {
+	if !s.open || len(s.profiles) == 0 {
+		return false
+	}
+	if s.naming {
+		return s.updateName(controls)
+	}
+	focused := s.focus.index
+	switch {
+	case controls.Close:
+		s.open = false
+	case s.focus.update(controls.Navigation, controls.Pointer, s.row):
+		player := 0
+		if s.players[0] == s.focus.index {
+			player = 1
+		}
+		return s.pick(player)
+	case controls.Player1:
+		return s.pick(0)
+	case controls.Player2:
+		return s.pick(1)
+	case controls.Left && s.profiles[focused].Handicap > 0:
+		s.profiles[focused].Handicap--
+		return true
+	case controls.Right && s.profiles[focused].Handicap < maxHandicap:
+		s.profiles[focused].Handicap++
+		return true
+	case controls.Create && len(s.profiles) < maxProfiles:
+		s.naming = true
+		s.name = ""
+	case controls.Delete && s.CanDelete():
+		s.remove(focused)
+		return true
+	}
+	return false
+}
+
+// updateName edits the name of the new profile, creating it on activation
+func (s *ProfileScreen) updateName(controls ProfileControls) bool {
+	switch {
+	case controls.Close:
+		s.naming = false
+	case controls.Activate:
+		name := strings.TrimSpace(s.name)
+		if name == "" || s.exists(name) {
+			return false
+		}
+		s.naming = false
+		s.profiles = append(s.profiles, Profile{Name: name})
+		s.focus.reset(len(s.profiles))
+		s.focus.index = len(s.profiles) - 1
+		return true
+	case controls.Erase && s.name != "":
+		s.name = s.name[:len(s.name)-1]
+	}
+	// Names are also file names: letters, digits and spaces only
+	for _, r := range controls.Text {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == ' ') && len(s.name) < maxProfileNameLength {
+			s.name += string(r)
+		}
+	}
+	return false
+}
+
+// exists tells if a profile has the name, ignoring the case
+func (s *ProfileScreen) exists(name string) bool {
+	for _, profile := range s.profiles {
+		if strings.EqualFold(profile.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pick gives the focused profile to the player, swapping the profiles when the other player has it
+func (s *ProfileScreen) pick(player int) bool {
+	other := 1 - player
+	if s.players[player] == s.focus.index {
+		return false
+	}
+	if s.players[other] == s.focus.index {
+		s.players[other] = s.players[player]
+	}
+	s.players[player] = s.focus.index
+	return true
+}
+
+// remove deletes the profile at the index, a player who had it takes the first profile left free
+func (s *ProfileScreen) remove(index int) {
+	s.profiles = append(s.profiles[:index], s.profiles[index+1:]...)
+	for i := range s.players {
+		if s.players[i] > index {
+			s.players[i]--
+		} else if s.players[i] == index {
+			s.players[i] = -1
+		}
+	}
+	for i := range s.players {
+		for free := 0; s.players[i] == -1; free++ {
+			if free != s.players[1-i] {
+				s.players[i] = free
+			}
+		}
+	}
+	s.focus.reset(len(s.profiles))
+}
+
+// row returns the area of the profile at the index
+func (s *ProfileScreen) row(i int) (mgl.Vec2, mgl.Vec2) {
+	return mgl.Vec2{float32(s.game.width/2) - 480, 250 + float32(i)*60}, mgl.Vec2{500, 52}
+}
+
+// statLines returns the labels and the values of the rating and the statistics of the profile
+func statLines(profile Profile) ([]string, []string) {
+	stats := profile.Stats
+	played := int(stats.PlayTime)
+	return []string{"Rating", "Matches", "Wins", "Win rate", "Points scored", "Points conceded", "Rallies", "Play time"},
+		[]string{
+			fmt.Sprintf("%.0f", profile.Rating),
+			fmt.Sprint(stats.Matches),
+			fmt.Sprint(stats.Wins),
+			fmt.Sprintf("%.0f%%", stats.WinRate()*100),
+			fmt.Sprint(stats.PointsScored),
+			fmt.Sprint(stats.PointsConceded),
+			fmt.Sprint(stats.Rallies),
+			fmt.Sprintf("%d:%02d:%02d", played/3600, played/60%60, played%60),
+		}
+}
+
+// draw renders the list of the profiles, marking the ones picked by the players, next to the
+// handicap and the statistics of the focused one
+func (s *ProfileScreen) draw() {
+	if !s.open {
+		return
+	}
+	g := s.game
+	g.renderer.Draw(mgl.Vec2{0, 0}, mgl.Vec2{float32(g.width), float32(g.height)}, 0, mgl.Vec3{0.1, 0.1, 0.1})
+	x := float32(g.width/2) - 460
+	g.text.RenderText(x, 120, 0.6, mgl.Vec3{1.0, 1.0, 1.0}, "PROFILES")
+	for i, profile := range s.profiles {
+		position, size := s.row(i)
+		y := position.Y() + 10
+		color := mgl.Vec3{0.6, 0.6, 0.6}
+		if i == s.focus.index && !s.naming {
+			g.drawFocusRing(position, size)
+			color = mgl.Vec3{1.0, 0.8, 0.1}
+		}
+		g.text.RenderText(x, y, 0.35, color, "%v", profile.Name)
+		for player, picked := range s.players {
+			if picked == i {
+				g.text.RenderText(x+400, y, 0.35, g.playerColor(player+1), "P%v", player+1)
+			}
+		}
+	}
+	if s.naming {
+		position, size := s.row(len(s.profiles))
+		g.drawFocusRing(position, size)
+		g.text.RenderText(x, position.Y()+10, 0.35, mgl.Vec3{1.0, 0.8, 0.1}, "%v_", s.name)
+	}
+	if !s.naming {
+		profile := s.profiles[s.focus.index]
+		column := float32(g.width / 2)
+		g.text.RenderText(column, 260, 0.35, mgl.Vec3{0.6, 0.6, 0.6}, "Handicap")
+		g.text.RenderText(column+320, 260, 0.35, mgl.Vec3{1.0, 1.0, 1.0}, "< %v >", profile.Handicap)
+		labels, values := statLines(profile)
+		for j, label := range labels {
+			y := 340 + float32(j)*60
+			g.text.RenderText(column, y, 0.35, mgl.Vec3{0.6, 0.6, 0.6}, "%v", label)
+			g.text.RenderText(column+320, y, 0.35, mgl.Vec3{1.0, 1.0, 1.0}, "%v", values[j])
+		}
+	}
+	hint := "1/2 pick for a player - LEFT/RIGHT handicap - N new - DELETE delete - ESC back"
+	if s.naming {
+		hint = "Type the name - ENTER create - ESC cancel"
+	}
+	g.text.RenderText(x, float32(g.height)-80, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "%v", hint)
+}
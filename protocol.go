@@ -0,0 +1,430 @@
+package pong
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Version of the snapshot and input messages. They follow the schema of protocol.proto in the
+// protobuf wire format, so the clients read them with the code generated from it. Adding fields
+// doesn't need a new minimum version: the fields unknown to the reader are skipped and the missing
+// ones are left zero. Bump protocolMinVersion when a change breaks the older readers.
+const (
+	ProtocolVersion    = 2
+	protocolMinVersion = 2 // Oldest version able to read the messages written by this one, the first one was gob
+	protocolMagic      = "PONG"
+	maxMessageSize     = 1 << 16 // Bigger messages are corrupt
+)
+
+// Errors reading a stream of messages
+var (
+	ErrIncompatibleVersion = errors.New("incompatible protocol version")
+	ErrMalformedMessage    = errors.New("malformed message")
+)
+
+// Message is a snapshot of the game or the inputs of the players at a tick, only one of them is set
+type Message struct {
+	Tick   uint64
+	State  *State
+	Inputs []PlayerInput // Players without an input are idle
+}
+
+// PlayerInput is the input of a player at a tick
+type PlayerInput struct {
+	Player   int // From 1 to 4, the front paddles of doubles are 3 and 4
+	Up, Down bool
+	Well     bool // Places a gravity well, players 1 and 2 only
+	SkinNext bool // Cycles the skin in the menu, players 1 and 2 only
+	Start    bool
+	Pause    bool
+	Tutorial bool
+	Quit     bool
+	WarmUp   bool
+}
+
+// PlayerInputs splits the input of a tick by player, the commands of the menu and the match go
+// to the first player. The front paddles only have an input when they move
+func PlayerInputs(input Input) []PlayerInput {
+	inputs := []PlayerInput{
+		{Player: 1, Up: input.Paddle1Up, Down: input.Paddle1Down, Well: input.Well1, SkinNext: input.Skin1Next,
+			Start: input.Start, Pause: input.Pause, Tutorial: input.Tutorial, Quit: input.Quit, WarmUp: input.WarmUp},
+		{Player: 2, Up: input.Paddle2Up, Down: input.Paddle2Down, Well: input.Well2, SkinNext: input.Skin2Next},
+	}
+	if input.Paddle3Up || input.Paddle3Down {
+		inputs = append(inputs, PlayerInput{Player: 3, Up: input.Paddle3Up, Down: input.Paddle3Down})
+	}
+	if input.Paddle4Up || input.Paddle4Down {
+		inputs = append(inputs, PlayerInput{Player: 4, Up: input.Paddle4Up, Down: input.Paddle4Down})
+	}
+	return inputs
+}
+
+// MergeInputs returns the input of a tick from the ones of the players, the commands of any player count
+func MergeInputs(inputs []PlayerInput) Input {
+	var input Input
+	for _, p := range inputs {
+		switch p.Player {
+		case 1:
+			input.Paddle1Up, input.Paddle1Down = input.Paddle1Up || p.Up, input.Paddle1Down || p.Down
+			input.Well1, input.Skin1Next = input.Well1 || p.Well, input.Skin1Next || p.SkinNext
+		case 2:
+			input.Paddle2Up, input.Paddle2Down = input.Paddle2Up || p.Up, input.Paddle2Down || p.Down
+			input.Well2, input.Skin2Next = input.Well2 || p.Well, input.Skin2Next || p.SkinNext
+		case 3:
+			input.Paddle3Up, input.Paddle3Down = input.Paddle3Up || p.Up, input.Paddle3Down || p.Down
+		case 4:
+			input.Paddle4Up, input.Paddle4Down = input.Paddle4Up || p.Up, input.Paddle4Down || p.Down
+		}
+		input.Start = input.Start || p.Start
+		input.Pause = input.Pause || p.Pause
+		input.Tutorial = input.Tutorial || p.Tutorial
+		input.Quit = input.Quit || p.Quit
+		input.WarmUp = input.WarmUp || p.WarmUp
+	}
+	return input
+}
+
+// Encoder writes a stream of messages
+type Encoder struct {
+	w *bufio.Writer
+}
+
+// NewEncoder writes the protocol header and returns an encoder of messages to the writer
+func NewEncoder(w io.Writer) (*Encoder, error) {
+	e := &Encoder{w: bufio.NewWriter(w)}
+	var header wireWriter
+	header.string(1, protocolMagic)
+	header.varint(2, ProtocolVersion)
+	header.varint(3, protocolMinVersion)
+	if err := e.write(header); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// WriteState writes a snapshot of the game at the tick
+func (e *Encoder) WriteState(tick uint64, state State) error {
+	var message wireWriter
+	message.varint(1, tick)
+	message.message(2, encodeState(state))
+	return e.write(message)
+}
+
+// WriteInputs writes the inputs of the players at the tick
+func (e *Encoder) WriteInputs(tick uint64, inputs []PlayerInput) error {
+	var body wireWriter
+	for _, input := range inputs {
+		body.message(1, encodePlayerInput(input))
+	}
+	var message wireWriter
+	message.varint(1, tick)
+	message.message(3, body)
+	return e.write(message)
+}
+
+// write writes a message prefixed by its size and flushes it
+func (e *Encoder) write(message wireWriter) error {
+	var size [binary.MaxVarintLen64]byte
+	if _, err := e.w.Write(size[:binary.PutUvarint(size[:], uint64(len(message)))]); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(message); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+func encodeState(state State) wireWriter {
+	var w wireWriter
+	w.varint(1, uint64(state.Phase))
+	w.message(2, encodeVec2(state.Ball))
+	w.message(3, encodeVec2(state.BallVelocity))
+	for _, paddle := range []mgl.Vec2{state.Paddle1, state.Paddle2, state.Paddle3, state.Paddle4} {
+		w.message(4, encodeVec2(paddle))
+	}
+	for _, size := range state.PaddleSizes {
+		w.message(5, encodeVec2(size))
+	}
+	w.float(6, state.BallRadius)
+	w.varint(7, uint64(state.Score1))
+	w.varint(8, uint64(state.Score2))
+	w.varint(9, uint64(state.Sets1))
+	w.varint(10, uint64(state.Sets2))
+	return w
+}
+
+func encodeVec2(v mgl.Vec2) wireWriter {
+	var w wireWriter
+	w.float(1, v.X())
+	w.float(2, v.Y())
+	return w
+}
+
+func encodePlayerInput(input PlayerInput) wireWriter {
+	var w wireWriter
+	w.varint(1, uint64(input.Player))
+	for i, flag := range []bool{input.Up, input.Down, input.Well, input.SkinNext, input.Start, input.Pause, input.Tutorial, input.Quit, input.WarmUp} {
+		w.boolean(i+2, flag)
+	}
+	return w
+}
+
+// Decoder reads a stream of messages
+type Decoder struct {
+	r       *bufio.Reader
+	Version int // Protocol version the stream was written with
+}
+
+// NewDecoder reads the protocol header from the reader, failing with ErrIncompatibleVersion
+// when the stream can't be read by this version
+func NewDecoder(r io.Reader) (*Decoder, error) {
+	d := &Decoder{r: bufio.NewReader(r)}
+	data, err := d.frame()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the protocol header: %v", err)
+	}
+	var magic string
+	var version, minVersion uint64
+	err = readFields(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			magic = string(f.data)
+		case 2:
+			version = f.value
+		case 3:
+			minVersion = f.value
+		}
+		return nil
+	})
+	if err != nil || magic != protocolMagic {
+		return nil, fmt.Errorf("not a pong stream")
+	}
+	if minVersion > ProtocolVersion || version < protocolMinVersion {
+		return nil, fmt.Errorf("%w: stream version %v, supported %v to %v", ErrIncompatibleVersion, version, protocolMinVersion, ProtocolVersion)
+	}
+	d.Version = int(version)
+	return d, nil
+}
+
+// Read reads the next message, returning io.EOF at the end of the stream
+func (d *Decoder) Read() (Message, error) {
+	var message Message
+	data, err := d.frame()
+	if err != nil {
+		return message, err
+	}
+	err = readFields(data, func(f wireField) error {
+		switch f.number {
+		case 1:
+			message.Tick = f.value
+		case 2:
+			state, err := decodeState(f.data)
+			message.State = &state
+			return err
+		case 3:
+			return readFields(f.data, func(f wireField) error {
+				if f.number != 1 {
+					return nil
+				}
+				input, err := decodePlayerInput(f.data)
+				message.Inputs = append(message.Inputs, input)
+				return err
+			})
+		}
+		return nil
+	})
+	return message, err
+}
+
+// frame reads the next message, io.EOF when the stream ends between two of them
+func (d *Decoder) frame() ([]byte, error) {
+	size, err := binary.ReadUvarint(d.r)
+	if err == io.EOF {
+		return nil, err
+	} else if err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("%w: %v bytes", ErrMalformedMessage, size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+func decodeState(data []byte) (State, error) {
+	var state State
+	var paddles, sizes []mgl.Vec2
+	err := readFields(data, func(f wireField) error {
+		var err error
+		switch f.number {
+		case 1:
+			state.Phase = GameState(f.value)
+		case 2:
+			state.Ball, err = decodeVec2(f.data)
+		case 3:
+			state.BallVelocity, err = decodeVec2(f.data)
+		case 4:
+			var paddle mgl.Vec2
+			paddle, err = decodeVec2(f.data)
+			paddles = append(paddles, paddle)
+		case 5:
+			var size mgl.Vec2
+			size, err = decodeVec2(f.data)
+			sizes = append(sizes, size)
+		case 6:
+			state.BallRadius = f.float()
+		case 7:
+			state.Score1 = int(f.value)
+		case 8:
+			state.Score2 = int(f.value)
+		case 9:
+			state.Sets1 = int(f.value)
+		case 10:
+			state.Sets2 = int(f.value)
+		}
+		return err
+	})
+	for i, paddle := range []*mgl.Vec2{&state.Paddle1, &state.Paddle2, &state.Paddle3, &state.Paddle4} {
+		if i < len(paddles) {
+			*paddle = paddles[i]
+		}
+	}
+	copy(state.PaddleSizes[:], sizes)
+	return state, err
+}
+
+func decodeVec2(data []byte) (mgl.Vec2, error) {
+	var v mgl.Vec2
+	err := readFields(data, func(f wireField) error {
+		if f.number == 1 || f.number == 2 {
+			v[f.number-1] = f.float()
+		}
+		return nil
+	})
+	return v, err
+}
+
+func decodePlayerInput(data []byte) (PlayerInput, error) {
+	var input PlayerInput
+	flags := []*bool{&input.Up, &input.Down, &input.Well, &input.SkinNext, &input.Start, &input.Pause, &input.Tutorial, &input.Quit, &input.WarmUp}
+	err := readFields(data, func(f wireField) error {
+		if f.number == 1 {
+			input.Player = int(f.value)
+		} else if f.number >= 2 && f.number-2 < len(flags) {
+			*flags[f.number-2] = f.value != 0
+		}
+		return nil
+	})
+	return input, err
+}
+
+// Wire types of the protobuf encoding
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// wireWriter appends the fields of a message in the protobuf wire format, leaving out the zero
+// values of the scalars like protobuf 3 does
+type wireWriter []byte
+
+func (w *wireWriter) key(number, wireType int) {
+	*w = binary.AppendUvarint(*w, uint64(number)<<3|uint64(wireType))
+}
+
+func (w *wireWriter) varint(number int, value uint64) {
+	if value == 0 {
+		return
+	}
+	w.key(number, wireVarint)
+	*w = binary.AppendUvarint(*w, value)
+}
+
+func (w *wireWriter) boolean(number int, value bool) {
+	if value {
+		w.varint(number, 1)
+	}
+}
+
+func (w *wireWriter) float(number int, value float32) {
+	if value == 0 {
+		return
+	}
+	w.key(number, wireFixed32)
+	*w = binary.LittleEndian.AppendUint32(*w, math.Float32bits(value))
+}
+
+func (w *wireWriter) string(number int, value string) {
+	w.key(number, wireBytes)
+	*w = binary.AppendUvarint(*w, uint64(len(value)))
+	*w = append(*w, value...)
+}
+
+// message appends an embedded message, even an empty one as it may be an element of a list
+func (w *wireWriter) message(number int, message wireWriter) {
+	w.string(number, string(message))
+}
+
+// wireField is a field read from a message: the number and, by wire type, the value or the bytes
+type wireField struct {
+	number int
+	value  uint64 // Varints and fixed size values
+	data   []byte // Strings and embedded messages
+}
+
+// float returns the value of a fixed32 field as a float
+func (f wireField) float() float32 {
+	return math.Float32frombits(uint32(f.value))
+}
+
+// readFields calls read with each field of the message, in order
+func readFields(data []byte, read func(wireField) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrMalformedMessage
+		}
+		data = data[n:]
+		f := wireField{number: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			if f.value, n = binary.Uvarint(data); n <= 0 {
+				return ErrMalformedMessage
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return ErrMalformedMessage
+			}
+			f.value, data = binary.LittleEndian.Uint64(data), data[8:]
+		case wireBytes:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < size {
+				return ErrMalformedMessage
+			}
+			f.data, data = data[n:n+int(size)], data[n+int(size):]
+		case wireFixed32:
+			if len(data) < 4 {
+				return ErrMalformedMessage
+			}
+			f.value, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		default:
+			return ErrMalformedMessage
+		}
+		if err := read(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
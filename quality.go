@@ -0,0 +1,67 @@
+package pong
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Quality is a preset of the graphics effects, from Low for potato hardware to Ultra
+type Quality int
+
+// Quality presets
+const (
+	QualityLow Quality = iota
+	QualityMedium
+	QualityHigh
+	QualityUltra
+)
+
+var qualityNames = []string{"low", "medium", "high", "ultra"}
+
+// QualitySettings holds the effects settings controlled by a quality preset
+type QualitySettings struct {
+	particleScale float32 // Multiplier of the amount of particles of every generator, zero disables them
+	trailRate     float64 // Particles spawned every second by the ball trail, independent of the tick rate
+	bloom         bool    // Glow around the bright parts of the screen
+	samples       int32   // MSAA samples of the postprocessing render target, zero disables multisampling
+}
+
+var qualityPresets = map[Quality]QualitySettings{
+	QualityLow:    {particleScale: 0, trailRate: 0, bloom: false, samples: 0},
+	QualityMedium: {particleScale: 0.5, trailRate: 120, bloom: false, samples: 4},
+	QualityHigh:   {particleScale: 1, trailRate: 120, bloom: false, samples: 8},
+	QualityUltra:  {particleScale: 2, trailRate: 240, bloom: true, samples: 8},
+}
+
+// ParseQuality returns the preset with the given name
+func ParseQuality(name string) (Quality, error) {
+	for i, qualityName := range qualityNames {
+		if strings.EqualFold(name, qualityName) {
+			return Quality(i), nil
+		}
+	}
+	return QualityHigh, fmt.Errorf("unknown quality preset %q, expected one of %v", name, strings.Join(qualityNames, ", "))
+}
+
+func (q Quality) String() string {
+	if q < QualityLow || q > QualityUltra {
+		return "unknown"
+	}
+	return qualityNames[q]
+}
+
+// Settings returns the effects settings of the preset
+func (q Quality) Settings() QualitySettings {
+	return qualityPresets[q]
+}
+
+// WithSamples returns the settings with the MSAA samples changed, zero disables multisampling
+func (s QualitySettings) WithSamples(samples int32) QualitySettings {
+	s.samples = samples
+	return s
+}
+
+// particleAmount scales the amount of particles of a generator
+func (s QualitySettings) particleAmount(amount int) int {
+	return int(float32(amount) * s.particleScale)
+}
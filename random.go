@@ -0,0 +1,35 @@
+package pong
+
+import "math/rand"
+
+// randomSource is a seeded source of random numbers whose state is a single number, so the
+// snapshots can save it and the simulation draws the same numbers after a rewind or a seek
+type randomSource struct {
+	state uint64
+}
+
+// newRandom returns random numbers drawn from a source seeded with the seed, and the source
+func newRandom(seed int64) (*rand.Rand, *randomSource) {
+	source := &randomSource{}
+	source.Seed(seed)
+	return rand.New(source), source
+}
+
+// Seed restarts the source from the seed
+func (s *randomSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+// Uint64 returns the next number, following SplitMix64
+func (s *randomSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// Int63 returns the next number, without the sign bit
+func (s *randomSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
@@ -0,0 +1,30 @@
+package pong
+
+import "math"
+
+var (
+	// InitialRating is the rating of the new profiles
+	InitialRating = 1000.0
+	ratingK       = 32.0  // Most points a match can move the ratings by
+	ratingScale   = 400.0 // Rating difference making the stronger player ten times as likely to win
+)
+
+// ExpectedScore returns the chance of the first player to beat the second one, as predicted by the ratings
+func ExpectedScore(rating1, rating2 float64) float64 {
+	return 1 / (1 + math.Pow(10, (rating2-rating1)/ratingScale))
+}
+
+// UpdateRatings returns the ratings of the players after the match, the points won by one are lost by the other
+func UpdateRatings(rating1, rating2 float64, result MatchResult) (float64, float64) {
+	score := 0.0
+	if result.Winner == 1 {
+		score = 1
+	}
+	change := ratingK * (score - ExpectedScore(rating1, rating2))
+	return rating1 + change, rating2 - change
+}
+
+// SetRatings shows the ratings of the left and the right player in the menu
+func (g *Game) SetRatings(rating1, rating2 float64) {
+	g.ratings = [2]float64{rating1, rating2}
+}
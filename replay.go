@@ -0,0 +1,382 @@
+package pong
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+
+	"github.com/lucatironi/go-pong/pkg/level"
+)
+
+// Replay files start with a fixed header followed by the inputs of the players, delta encoded:
+// a record is written only on the ticks the input changes, as the number of ticks since the
+// previous record (uvarint) followed by the input bits (uvarint).
+// The last record has the end bit set and points at the tick after the last one, so its delta
+// gives the length of the replay.
+//
+//	magic       [4]byte "PRPL"
+//	version     uint16  version of the writer
+//	minVersion  uint16  oldest version able to read the file
+//	headerSize  uint16  size of the rest of the header, newer fields are skipped by older readers
+//	configHash  uint64  hash of the settings the simulation depends on
+//	seed        int64   seed of the simulation random numbers
+//	tickRate    float64 fixed updates per second
+//	sets        uint16  sets of the match, best of
+//	handicaps   [2]byte points each player starts the sets with
+//	mutators    uint16  modifiers of the rules of the match
+//	doubles     bool    two players per side
+//	levelSize   uint16  size of the level JSON, zero for the classic court
+//	level       []byte  JSON of the level the match is played on
+//	intro       float64 seconds of the match intro
+//	victory     float64 seconds of the victory sequence
+const (
+	ReplayVersion    = 1
+	replayMinVersion = 1 // Oldest version able to read the files written by this one
+	replayMagic      = "PRPL"
+	replayHeaderSize = 8 + 8 + 8 + 2 + 2 + 2 + 1 + 2 + 8 + 8 // Header fields after the size, without the level
+	maxReplayTicks   = 24 * 60 * 60 * 240                    // A day at the highest tick rate, longer replays are corrupt
+)
+
+// Bits of the input records
+const (
+	replayPaddle1Up uint16 = 1 << iota
+	replayPaddle1Down
+	replayPaddle2Up
+	replayPaddle2Down
+	replayStart
+	replayPause
+	replayTutorial
+	replayQuit
+	replayWell1
+	replayWell2
+	replayPaddle3Up
+	replayPaddle3Down
+	replayPaddle4Up
+	replayPaddle4Down
+	replayWarmUp
+	replayEnd uint16 = 1 << 15
+)
+
+// Errors reading replay files
+var (
+	ErrNotReplay       = errors.New("not a replay file")
+	ErrReplayVersion   = errors.New("incompatible replay version")
+	ErrReplayConfig    = errors.New("replay recorded with different simulation settings")
+	ErrReplayTruncated = errors.New("truncated replay")
+)
+
+// ReplayHeader describes a replay
+type ReplayHeader struct {
+	Version    uint16
+	MinVersion uint16
+	ConfigHash uint64
+	Seed       int64
+	TickRate   float64
+	Sets       uint16 // Best of
+	Handicaps  [2]uint8
+	Mutators   Mutators
+	Doubles    bool
+	Level      *level.Level // Nil for the classic court
+	Intro      float64      // Seconds of the match intro
+	Victory    float64      // Seconds of the victory sequence
+}
+
+// Options returns the options of the game the replay was recorded with
+func (h ReplayHeader) Options() Options {
+	return Options{
+		Sets:      int(h.Sets),
+		Handicaps: [2]int{int(h.Handicaps[0]), int(h.Handicaps[1])},
+		Mutators:  h.Mutators,
+		Doubles:   h.Doubles,
+		Level:     h.Level,
+		Seed:      h.Seed,
+		Intro:     h.Intro,
+		Victory:   h.Victory,
+	}
+}
+
+// ConfigHash hashes the tick rate and the tuning the simulation reads: replays only play back
+// the same when they match. The tuning of the presentation is left out, the lengths of the intro
+// and of the victory are recorded in the header instead
+func ConfigHash(tickRate float64) uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h, tickRate, maxScore, paddleSize, paddleMargin, paddleVelocity, paddleSpin,
+		ballRadius, initialBallVelocity, ballMaxAngle, ballMaxSpeed, hitStopTicks, hitStopCounter,
+		bigBallScale, tinyPaddleScale, doubleSpeedScale, wellDuration, wellCooldown, wellRadius, wellPull,
+		doublesFront, levelEndWidth, warmUpReadyTime, warmUpCountdown, tutorialMoveDistance, tutorialServeSpeed,
+		powerUpInterval, powerUpLife, powerUpLimit, powerUpSize, powerUpDuration, powerUpEnlargeScale,
+		powerUpShrinkScale, powerUpSpeedScale, powerUpBallLimit, stickyHold, VirtualWidth, VirtualHeight)
+	return h.Sum64()
+}
+
+// ReplayWriter records the inputs of a match, one per tick
+type ReplayWriter struct {
+	w        *bufio.Writer
+	tick     uint64 // Ticks recorded so far
+	lastTick uint64 // Tick of the last record
+	last     uint16 // Input bits of the last record
+}
+
+// NewReplayWriter writes the header of a replay recorded at the tick rate, of a game created with the options
+func NewReplayWriter(w io.Writer, tickRate float64, options Options) (*ReplayWriter, error) {
+	rw := &ReplayWriter{w: bufio.NewWriter(w)}
+	header := ReplayHeader{
+		Version:    ReplayVersion,
+		MinVersion: replayMinVersion,
+		ConfigHash: ConfigHash(tickRate),
+		Seed:       options.Seed,
+		TickRate:   tickRate,
+		Sets:       uint16(options.Sets),
+		Handicaps:  [2]uint8{uint8(options.Handicaps[0]), uint8(options.Handicaps[1])},
+		Mutators:   options.Mutators,
+		Doubles:    options.Doubles,
+		Level:      options.Level,
+		Intro:      options.Intro,
+		Victory:    options.Victory,
+	}
+	if header.Intro == 0 {
+		header.Intro = introTime
+	}
+	if header.Victory == 0 {
+		header.Victory = victoryTime
+	}
+	var levelData []byte
+	if header.Level != nil {
+		levelData = header.Level.Encode()
+	}
+	rw.w.WriteString(replayMagic)
+	for _, v := range []interface{}{header.Version, header.MinVersion, uint16(replayHeaderSize + len(levelData)), header.ConfigHash, header.Seed, header.TickRate, header.Sets, header.Handicaps, header.Mutators, header.Doubles, uint16(len(levelData)), levelData, header.Intro, header.Victory} {
+		if err := binary.Write(rw.w, binary.LittleEndian, v); err != nil {
+			return nil, err
+		}
+	}
+	return rw, nil
+}
+
+// Record records the input of the next tick
+func (rw *ReplayWriter) Record(input Input) error {
+	bits := inputBits(input)
+	var err error
+	if rw.tick == 0 || bits != rw.last {
+		err = rw.writeRecord(bits)
+	}
+	rw.tick++
+	return err
+}
+
+// Close writes the end record and flushes the replay, it doesn't close the underlying writer
+func (rw *ReplayWriter) Close() error {
+	if err := rw.writeRecord(replayEnd); err != nil {
+		return err
+	}
+	return rw.w.Flush()
+}
+
+func (rw *ReplayWriter) writeRecord(bits uint16) error {
+	var buf [2 * binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], rw.tick-rw.lastTick)
+	n += binary.PutUvarint(buf[n:], uint64(bits))
+	rw.lastTick = rw.tick
+	rw.last = bits
+	_, err := rw.w.Write(buf[:n])
+	return err
+}
+
+// Replay is a recorded match
+type Replay struct {
+	Header  ReplayHeader
+	Ticks   uint64   // Length of the replay in ticks
+	Records int      // Number of input changes
+	inputs  []uint16 // Input bits of every tick
+}
+
+// ReadReplay reads a replay, failing when it can't be read by this version or is truncated
+func ReadReplay(r io.Reader) (*Replay, error) {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(replayMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != replayMagic {
+		return nil, ErrNotReplay
+	}
+	var replay Replay
+	var headerSize uint16
+	for _, v := range []interface{}{&replay.Header.Version, &replay.Header.MinVersion, &headerSize} {
+		if err := binary.Read(br, binary.LittleEndian, v); err != nil {
+			return nil, ErrReplayTruncated
+		}
+	}
+	if replay.Header.MinVersion > ReplayVersion || replay.Header.Version < replayMinVersion {
+		return nil, fmt.Errorf("%w: file version %v, supported %v to %v", ErrReplayVersion, replay.Header.Version, replayMinVersion, ReplayVersion)
+	}
+	if headerSize < replayHeaderSize {
+		return nil, fmt.Errorf("%w: header of %v bytes", ErrNotReplay, headerSize)
+	}
+	var levelSize uint16
+	for _, v := range []interface{}{&replay.Header.ConfigHash, &replay.Header.Seed, &replay.Header.TickRate, &replay.Header.Sets, &replay.Header.Handicaps, &replay.Header.Mutators, &replay.Header.Doubles, &levelSize} {
+		if err := binary.Read(br, binary.LittleEndian, v); err != nil {
+			return nil, ErrReplayTruncated
+		}
+	}
+	if replayHeaderSize+int(levelSize) > int(headerSize) {
+		return nil, fmt.Errorf("%w: level of %v bytes", ErrNotReplay, levelSize)
+	}
+	if levelSize > 0 {
+		data := make([]byte, levelSize)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return nil, ErrReplayTruncated
+		}
+		l, err := level.Parse("", data)
+		if err == nil {
+			err = l.Validate(VirtualWidth, VirtualHeight)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: level: %v", ErrNotReplay, err)
+		}
+		replay.Header.Level = l
+	}
+	for _, v := range []interface{}{&replay.Header.Intro, &replay.Header.Victory} {
+		if err := binary.Read(br, binary.LittleEndian, v); err != nil {
+			return nil, ErrReplayTruncated
+		}
+	}
+	// Skip the header fields added by newer versions
+	if _, err := br.Discard(int(headerSize) - replayHeaderSize - int(levelSize)); err != nil {
+		return nil, ErrReplayTruncated
+	}
+
+	var bits uint16
+	for {
+		delta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, ErrReplayTruncated
+		}
+		next, err := readBits(br)
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(replay.inputs))+delta > maxReplayTicks {
+			return nil, fmt.Errorf("%w: longer than %v ticks", ErrNotReplay, maxReplayTicks)
+		}
+		// The previous input holds until this record
+		for i := uint64(0); i < delta; i++ {
+			replay.inputs = append(replay.inputs, bits)
+		}
+		if next&replayEnd != 0 {
+			break
+		}
+		bits = next
+		replay.Records++
+	}
+	replay.Ticks = uint64(len(replay.inputs))
+	return &replay, nil
+}
+
+// readBits reads the input bits of a record
+func readBits(br *bufio.Reader) (uint16, error) {
+	bits, err := binary.ReadUvarint(br)
+	if err != nil {
+		return 0, ErrReplayTruncated
+	}
+	if bits > 0xFFFF {
+		return 0, fmt.Errorf("%w: input bits %x", ErrNotReplay, bits)
+	}
+	return uint16(bits), nil
+}
+
+// Validate checks that the replay was recorded with the simulation settings of this build
+func (r *Replay) Validate() error {
+	if r.Header.ConfigHash != ConfigHash(r.Header.TickRate) {
+		return ErrReplayConfig
+	}
+	return nil
+}
+
+// Input returns the input of the players at the tick
+func (r *Replay) Input(tick uint64) Input {
+	if tick >= r.Ticks {
+		return Input{}
+	}
+	return bitsInput(r.inputs[tick])
+}
+
+// Play runs the whole replay on a new headless game and returns it
+func (r *Replay) Play() *Game {
+	game := New(r.Header.Options())
+	step := 1.0 / r.Header.TickRate
+	for tick := uint64(0); tick < r.Ticks; tick++ {
+		game.Step(r.Input(tick), step)
+	}
+	return game
+}
+
+func inputBits(input Input) uint16 {
+	var bits uint16
+	if input.Paddle1Up {
+		bits |= replayPaddle1Up
+	}
+	if input.Paddle1Down {
+		bits |= replayPaddle1Down
+	}
+	if input.Paddle2Up {
+		bits |= replayPaddle2Up
+	}
+	if input.Paddle2Down {
+		bits |= replayPaddle2Down
+	}
+	if input.Start {
+		bits |= replayStart
+	}
+	if input.Pause {
+		bits |= replayPause
+	}
+	if input.Tutorial {
+		bits |= replayTutorial
+	}
+	if input.Quit {
+		bits |= replayQuit
+	}
+	if input.Well1 {
+		bits |= replayWell1
+	}
+	if input.Well2 {
+		bits |= replayWell2
+	}
+	if input.Paddle3Up {
+		bits |= replayPaddle3Up
+	}
+	if input.Paddle3Down {
+		bits |= replayPaddle3Down
+	}
+	if input.Paddle4Up {
+		bits |= replayPaddle4Up
+	}
+	if input.Paddle4Down {
+		bits |= replayPaddle4Down
+	}
+	if input.WarmUp {
+		bits |= replayWarmUp
+	}
+	return bits
+}
+
+func bitsInput(bits uint16) Input {
+	return Input{
+		Paddle1Up:   bits&replayPaddle1Up != 0,
+		Paddle1Down: bits&replayPaddle1Down != 0,
+		Paddle2Up:   bits&replayPaddle2Up != 0,
+		Paddle2Down: bits&replayPaddle2Down != 0,
+		Start:       bits&replayStart != 0,
+		Pause:       bits&replayPause != 0,
+		Tutorial:    bits&replayTutorial != 0,
+		Quit:        bits&replayQuit != 0,
+		Well1:       bits&replayWell1 != 0,
+		Well2:       bits&replayWell2 != 0,
+		Paddle3Up:   bits&replayPaddle3Up != 0,
+		Paddle3Down: bits&replayPaddle3Down != 0,
+		Paddle4Up:   bits&replayPaddle4Up != 0,
+		Paddle4Down: bits&replayPaddle4Down != 0,
+		WarmUp:      bits&replayWarmUp != 0,
+	}
+}
@@ -0,0 +1,102 @@
+package pong
+
+import (
+	"bytes"
+	"testing"
+)
+
+const testTickRate = 120.0
+
+// recordMatch records the computers playing a match against each other on a headless game
+func recordMatch(t *testing.T, seed int64, ticks int) *Replay {
+	t.Helper()
+	options := Options{Seed: seed}
+	var buf bytes.Buffer
+	w, err := NewReplayWriter(&buf, testTickRate, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := New(options)
+	opponents := []*Opponent{
+		NewDifficultyOpponent(DifficultyEasy, 1, 0.15, game.Random()),
+		NewDifficultyOpponent(DifficultyHard, 2, 0.15, game.Random()),
+	}
+	for tick := 0; tick < ticks; tick++ {
+		input := Input{Start: tick == 0}
+		for _, opponent := range opponents {
+			opponent.Control(game.State(), &input, 1/testTickRate)
+		}
+		if err := w.Record(input); err != nil {
+			t.Fatal(err)
+		}
+		game.Step(input, 1/testTickRate)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := ReadReplay(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := replay.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return replay
+}
+
+// playTo plays the replay on a new game up to the tick, returning the game and the ticks of the goals
+func playTo(replay *Replay, tick uint64) (*Game, []uint64) {
+	game := New(replay.Header.Options())
+	var goals []uint64
+	for t := uint64(0); t < tick; t++ {
+		before := game.paddle1Score + game.paddle2Score
+		game.Step(replay.Input(t), 1/replay.Header.TickRate)
+		if game.paddle1Score+game.paddle2Score > before {
+			goals = append(goals, t)
+		}
+	}
+	return game, goals
+}
+
+func TestReplayViewerMatchesPlay(t *testing.T) {
+	replay := recordMatch(t, 7, 90*testTickRate)
+	played, goals := playTo(replay, replay.Ticks)
+	if len(goals) == 0 {
+		t.Fatal("no goals scored in the recorded match")
+	}
+	if state := replay.Play().State(); state != played.State() {
+		t.Fatalf("Play() state = %+v, want %+v", state, played.State())
+	}
+
+	viewer := NewReplayViewer(New(Options{}), replay)
+	if len(viewer.goals) != len(goals) {
+		t.Fatalf("viewer goals = %v, want %v", viewer.goals, goals)
+	}
+	for i := range goals {
+		if viewer.goals[i] != goals[i] {
+			t.Fatalf("viewer goals = %v, want %v", viewer.goals, goals)
+		}
+	}
+
+	// Backwards too, so the keyframes get restored
+	for _, tick := range []uint64{500, 3000, 6000, 9000, 2950, 10, replay.Ticks} {
+		viewer.Seek(tick)
+		want, _ := playTo(replay, tick)
+		if got := viewer.game.State(); got != want.State() {
+			t.Errorf("Seek(%v) state = %+v, want %+v", tick, got, want.State())
+		}
+	}
+}
+
+func TestReplayViewerPlaybackMatchesPlay(t *testing.T) {
+	replay := recordMatch(t, 3, 30*testTickRate)
+	viewer := NewReplayViewer(New(Options{}), replay)
+	viewer.Seek(1000)
+	// Play the rest at normal speed, a second at a time
+	for viewer.tick < replay.Ticks {
+		viewer.Update(ReplayControls{}, 1)
+	}
+	if got, want := viewer.game.State(), replay.Play().State(); got != want {
+		t.Errorf("playback state = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// replayKeyframeInterval is how many ticks apart the snapshots used for seeking are taken
+const replayKeyframeInterval = 120
+
+// replaySeekTime is how far the viewer jumps when seeking while playing, in seconds
+const replaySeekTime = 5.0
+
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4}
+
+// ReplayControls are the commands of the replay viewer for one frame
+type ReplayControls struct {
+	TogglePause        bool
+	Forward, Back      bool // Step one tick when paused, seek otherwise
+	Faster, Slower     bool
+	NextGoal, PrevGoal bool
+}
+
+// ReplayViewer plays a replay back on a game, with pause, stepping, speed control and seeking
+type ReplayViewer struct {
+	game        *Game
+	replay      *Replay
+	step        float64
+	tick        uint64               // Next tick to simulate
+	keyframes   []simulationSnapshot // Simulation before every replayKeyframeInterval ticks
+	goals       []uint64             // Ticks a goal was scored at
+	speed       int                  // Index of the playback speed
+	paused      bool
+	accumulator float64
+}
+
+// NewReplayViewer simulates the whole replay once to find the goals and take the snapshots
+// needed to seek, then starts playing it back from the beginning on the game
+func NewReplayViewer(game *Game, replay *Replay) *ReplayViewer {
+	v := &ReplayViewer{
+		game:   game,
+		replay: replay,
+		step:   1.0 / replay.Header.TickRate,
+		speed:  2,
+	}
+	// Play the recorded matches whatever the game was created with
+	options := replay.Header.Options()
+	game.match = newMatch(options.Sets)
+	game.SetHandicaps(options.Handicaps[0], options.Handicaps[1])
+	game.SetMutators(options.Mutators)
+	game.SetDoubles(options.Doubles)
+	game.SetLevel(options.Level)
+	game.seed = options.Seed
+	game.introLength, game.victoryLength = options.Intro, options.Victory
+	game.randomSource.Seed(options.Seed)
+	game.presentation.Seed(options.Seed)
+	// The playback steps the game through the same simulation step, only presenting it on top
+	preview := New(options)
+	for tick := uint64(0); tick < replay.Ticks; tick++ {
+		if tick%replayKeyframeInterval == 0 {
+			v.keyframes = append(v.keyframes, preview.snapshot())
+		}
+		if events, _ := preview.stepSimulation(replay.Input(tick), v.step); events.scored != 0 {
+			v.goals = append(v.goals, tick)
+		}
+	}
+	if len(v.keyframes) == 0 {
+		v.keyframes = append(v.keyframes, preview.snapshot())
+	}
+	game.restore(v.keyframes[0])
+	if game.initialized {
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { v.draw() }))
+	}
+	return v
+}
+
+// Update plays the replay for the given real time, applying the controls
+func (v *ReplayViewer) Update(controls ReplayControls, deltaTime float64) {
+	if controls.TogglePause {
+		v.paused = !v.paused
+	}
+	if controls.Faster && v.speed < len(replaySpeeds)-1 {
+		v.speed++
+	}
+	if controls.Slower && v.speed > 0 {
+		v.speed--
+	}
+	seek := uint64(replaySeekTime * v.replay.Header.TickRate)
+	if v.paused {
+		seek = 1
+	}
+	switch {
+	case controls.Forward:
+		v.Seek(v.tick + seek)
+	case controls.Back && v.tick > seek:
+		v.Seek(v.tick - seek)
+	case controls.Back:
+		v.Seek(0)
+	case controls.NextGoal:
+		for _, goal := range v.goals {
+			if v.goalStart(goal) > v.tick {
+				v.Seek(v.goalStart(goal))
+				break
+			}
+		}
+	case controls.PrevGoal:
+		target := uint64(0)
+		for _, goal := range v.goals {
+			if v.goalStart(goal) < v.tick {
+				target = v.goalStart(goal)
+			}
+		}
+		v.Seek(target)
+	}
+	if v.paused {
+		return
+	}
+	v.accumulator += deltaTime * replaySpeeds[v.speed]
+	for v.accumulator >= v.step {
+		if v.tick >= v.replay.Ticks {
+			v.paused = true
+			v.accumulator = 0
+			break
+		}
+		v.game.Step(v.replay.Input(v.tick), v.step)
+		v.tick++
+		v.accumulator -= v.step
+	}
+}
+
+// goalStart is where jumping to a goal lands, a second before it to see it coming
+func (v *ReplayViewer) goalStart(goal uint64) uint64 {
+	lead := uint64(v.replay.Header.TickRate)
+	if goal < lead {
+		return 0
+	}
+	return goal - lead
+}
+
+// Seek moves the playback to the tick, restoring the closest snapshot before it
+// and simulating the ticks in between
+func (v *ReplayViewer) Seek(tick uint64) {
+	if tick > v.replay.Ticks {
+		tick = v.replay.Ticks
+	}
+	keyframe := int(tick / replayKeyframeInterval)
+	if keyframe >= len(v.keyframes) {
+		keyframe = len(v.keyframes) - 1
+	}
+	// Stepping forward a few ticks doesn't need a snapshot
+	if tick < v.tick || tick-v.tick > replayKeyframeInterval {
+		v.game.restore(v.keyframes[keyframe])
+		v.tick = uint64(keyframe) * replayKeyframeInterval
+	}
+	for ; v.tick < tick; v.tick++ {
+		v.game.stepSimulation(v.replay.Input(v.tick), v.step)
+	}
+	// Don't interpolate the jump
+	v.game.storePositions()
+	v.accumulator = 0
+}
+
+// Alpha returns how far the playback is between the previous and the current tick
+func (v *ReplayViewer) Alpha() float32 {
+	return float32(v.accumulator / v.step)
+}
+
+// draw renders the timeline with the goal markers and the playback status
+func (v *ReplayViewer) draw() {
+	g := v.game
+	position := mgl.Vec2{100, float32(g.height) - 60}
+	size := mgl.Vec2{float32(g.width) - 200, 12}
+	g.renderer.Draw(position, size, 0, mgl.Vec3{0.3, 0.3, 0.3})
+	progress := float32(0)
+	if v.replay.Ticks > 0 {
+		progress = float32(v.tick) / float32(v.replay.Ticks)
+	}
+	g.renderer.Draw(position, mgl.Vec2{size.X() * progress, size.Y()}, 0, mgl.Vec3{0.9, 0.9, 0.9})
+	for _, goal := range v.goals {
+		x := position.X() + size.X()*float32(goal)/float32(v.replay.Ticks)
+		g.renderer.Draw(mgl.Vec2{x - 3, position.Y() - 8}, mgl.Vec2{6, size.Y() + 16}, 0, mgl.Vec3{1.0, 0.8, 0.1})
+	}
+	status := "PLAYING"
+	if v.paused {
+		status = "PAUSED"
+	}
+	seconds := float64(v.tick) / v.replay.Header.TickRate
+	total := float64(v.replay.Ticks) / v.replay.Header.TickRate
+	g.text.RenderText(position.X(), position.Y()-50, 0.3, mgl.Vec3{1.0, 1.0, 1.0}, "REPLAY %v %vx  %02d:%02d / %02d:%02d",
+		status, replaySpeeds[v.speed], int(seconds)/60, int(seconds)%60, int(total)/60, int(total)%60)
+}
@@ -0,0 +1,87 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+var rewindTime = 10.0 // Seconds of the match kept to rewind through in development mode
+
+// rewindBuffer is a ring buffer of the snapshots of the last updates of a match, the oldest ones
+// are overwritten
+type rewindBuffer struct {
+	snapshots []simulationSnapshot
+	newest    int     // Index of the newest snapshot
+	count     int     // Snapshots recorded, up to the capacity
+	cursor    int     // Snapshots rewound from the newest one
+	step      float64 // Seconds between the snapshots
+}
+
+// record adds the snapshot of an update of the given duration, the buffer is sized on the first one
+func (b *rewindBuffer) record(s simulationSnapshot, deltaTime float64) {
+	if b.snapshots == nil {
+		b.snapshots = make([]simulationSnapshot, int(rewindTime/deltaTime))
+		b.newest = len(b.snapshots) - 1
+		b.step = deltaTime
+	}
+	b.newest = (b.newest + 1) % len(b.snapshots)
+	b.snapshots[b.newest] = s
+	if b.count < len(b.snapshots) {
+		b.count++
+	}
+}
+
+// at returns the snapshot the given number of updates before the newest one
+func (b *rewindBuffer) at(back int) simulationSnapshot {
+	return b.snapshots[(b.newest-back+len(b.snapshots))%len(b.snapshots)]
+}
+
+// resume drops the snapshots after the cursor, the match goes on from there
+func (b *rewindBuffer) resume() {
+	if b.cursor == 0 {
+		return
+	}
+	b.newest = (b.newest - b.cursor + len(b.snapshots)) % len(b.snapshots)
+	b.count -= b.cursor
+	b.cursor = 0
+}
+
+// clear drops all the snapshots
+func (b *rewindBuffer) clear() {
+	b.count = 0
+	b.cursor = 0
+}
+
+// Rewind steps the paused match back through the last updates, or forward with a negative number of
+// updates, it only works in development mode and reports whether the match moved. Resuming the match
+// goes on from the update rewound to.
+func (g *Game) Rewind(updates int) bool {
+	b := &g.rewind
+	if !g.devMode || g.state != GamePaused || b.count == 0 {
+		return false
+	}
+	cursor := b.cursor + updates
+	if cursor < 0 {
+		cursor = 0
+	} else if cursor > b.count-1 {
+		cursor = b.count - 1
+	}
+	if cursor == b.cursor {
+		return false
+	}
+	b.cursor = cursor
+	g.restore(b.at(cursor))
+	g.state = GamePaused
+	// Don't interpolate the jump
+	g.storePositions()
+	return true
+}
+
+// drawRewind renders how far the paused match was rewound
+func (g *Game) drawRewind() {
+	if !g.devMode || g.state != GamePaused || g.rewind.count == 0 {
+		return
+	}
+	b := g.rewind
+	g.text.RenderText(float32(g.width/2)-260, float32(g.height/2)+40, 0.35, mgl.Vec3{1.0, 0.8, 0.1},
+		"LEFT/RIGHT rewind: -%.3fs of %.1fs", float64(b.cursor)*b.step, float64(b.count-1)*b.step)
+}
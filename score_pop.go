@@ -0,0 +1,60 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/tween"
+)
+
+var (
+	scorePopScale   = float32(0.6) // Extra scale of the number just scored, it shrinks back from it
+	scorePopTime    = 0.6
+	scoreFlashColor = mgl.Vec3{1.0, 0.8, 0.1}
+	scoreBigScale   = float32(4)    // Scale of the score flashed mid-court
+	scoreBigAlpha   = float32(0.35) // Opacity of the score flashed mid-court as it appears
+	scoreBigTime    = 1.0
+)
+
+// scorePop animates the scoreboard after a point
+type scorePop struct {
+	scale [2]float32 // Extra scale of the numbers on the left and on the right side of the screen
+	flash [2]float32 // Share of the flash color of the numbers
+	big   float32    // Opacity of the score flashed mid-court
+}
+
+// popScore pops and flashes the numbers of the players who scored and flashes the score mid-court
+func (g *Game) popScore(goals [2]int) {
+	for side, player := range g.screenPlayers() {
+		if goals[player-1] == 0 {
+			continue
+		}
+		g.tweens.Add(tween.NewFloat(&g.scorePop.scale[side], scorePopScale, 0, scorePopTime, tween.OutCubic))
+		g.tweens.Add(tween.NewFloat(&g.scorePop.flash[side], 1, 0, scorePopTime, tween.InQuad))
+	}
+	g.tweens.Add(tween.NewFloat(&g.scorePop.big, scoreBigAlpha, 0, scoreBigTime, tween.InQuad))
+}
+
+// drawScore renders the scores around the colon in the middle of the top of the screen, each number
+// scaled around its center while it pops, then the score flashed mid-court
+func (g *Game) drawScore() {
+	left, right := g.screenScores()
+	colon, height := g.text.MeasureText(1, " : ")
+	center := float32(g.width / 2)
+	g.text.RenderText(center-colon/2, 100, 1, mgl.Vec3{1.0, 1.0, 1.0}, " : ")
+	for side, score := range []int{left, right} {
+		scale := 1 + g.scorePop.scale[side]
+		width, _ := g.text.MeasureText(scale, "%v", score)
+		x := center + colon/2
+		if side == 0 {
+			x = center - colon/2 - width
+		}
+		color := mgl.Vec3{1.0, 1.0, 1.0}
+		color = color.Add(scoreFlashColor.Sub(color).Mul(g.scorePop.flash[side]))
+		g.text.RenderText(x, 100-(scale-1)*height/2, scale, color, "%v", score)
+	}
+	if g.scorePop.big > 0 {
+		g.text.Alpha = g.scorePop.big
+		width, height := g.text.MeasureText(scoreBigScale, "%v : %v", left, right)
+		g.text.RenderText(center-width/2, float32(g.height)/2-height/2, scoreBigScale, mgl.Vec3{1.0, 1.0, 1.0}, "%v : %v", left, right)
+		g.text.Alpha = 1
+	}
+}
@@ -0,0 +1,138 @@
+package pong
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	selfPlayMaxTime  = 10 * 60.0 // Seconds a self-play match can last before it's called a draw
+	selfPlayMutation = float32(0.25)
+)
+
+// selfPlayRecord counts the matches of a parameter set
+type selfPlayRecord struct {
+	personality Personality
+	matches     int
+	wins        int
+	draws       int
+}
+
+// RunSelfPlay plays the personalities and the given number of mutations of each against all the
+// personalities, the given matches per pairing on parallel goroutines, then reports the win rate of
+// every parameter set. The seed makes the mutations and the matches the same on every run.
+func RunSelfPlay(personalities []Personality, mutations, matches int, updateRate float64, seed int64) {
+	if len(personalities) == 0 {
+		fmt.Println("No personalities to play")
+		return
+	}
+	random := rand.New(rand.NewSource(seed))
+	candidates := append([]Personality{}, personalities...)
+	for _, personality := range personalities {
+		for i := 1; i <= mutations; i++ {
+			candidates = append(candidates, mutatePersonality(personality, fmt.Sprintf("%v~%v", personality.Name, i), random))
+		}
+	}
+	records := make([]selfPlayRecord, len(candidates))
+	for i, candidate := range candidates {
+		records[i].personality = candidate
+	}
+
+	type job struct{ candidate, opponent, match, index int }
+	jobs := make(chan job)
+	var mutex sync.Mutex
+	var wait sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for j := range jobs {
+				// Alternate the sides, they don't play quite the same
+				side := 1 + j.match%2
+				// Every match has its own seed, whichever goroutine plays it
+				winner := playSelfPlayMatch(candidates[j.candidate], personalities[j.opponent], side, updateRate, seed+int64(j.index))
+				mutex.Lock()
+				record := &records[j.candidate]
+				record.matches++
+				if winner == side {
+					record.wins++
+				} else if winner == 0 {
+					record.draws++
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	index := 0
+	for c := range candidates {
+		for o := range personalities {
+			for m := 0; m < matches; m++ {
+				index++
+				jobs <- job{c, o, m, index}
+			}
+		}
+	}
+	close(jobs)
+	wait.Wait()
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].wins*records[j].matches > records[j].wins*records[i].matches
+	})
+	total := len(candidates) * len(personalities) * matches
+	fmt.Printf("Played %v matches in %v on %v goroutines\n", total, time.Since(start), runtime.NumCPU())
+	fmt.Printf("%-16v %9v %9v %6v %6v %9v %9v %6v\n", "personality", "aim_error", "reaction", "speed", "edge", "recenter", "win rate", "draws")
+	for _, r := range records {
+		p := r.personality
+		fmt.Printf("%-16v %9.1f %9.3f %6.2f %6.2f %9v %8.1f%% %6v\n", p.Name, p.AimError, p.Reaction, p.Speed, p.Edge, p.Recenter,
+			float64(r.wins)/float64(r.matches)*100, r.draws)
+	}
+}
+
+// playSelfPlayMatch plays a headless match of the personality on the side against the opponent,
+// seeded as given, it returns the winning side or zero when the match ran out of time
+func playSelfPlayMatch(personality, opponent Personality, side int, updateRate float64, seed int64) int {
+	game := New(Options{Seed: seed})
+	player := NewOpponent(personality, side, game.Random())
+	other := NewOpponent(opponent, 3-side, game.Random())
+	step := 1.0 / updateRate
+	game.Step(Input{Start: true}, step)
+	for tick := 0; tick < int(selfPlayMaxTime*updateRate); tick++ {
+		var input Input
+		state := game.State()
+		player.Control(state, &input, step)
+		other.Control(state, &input, step)
+		game.Step(input, step)
+		if result, won := game.Result(); won {
+			return result.Winner
+		}
+	}
+	return 0
+}
+
+// mutatePersonality returns a copy of the personality with every parameter moved at random by up
+// to selfPlayMutation of its value
+func mutatePersonality(p Personality, name string, random *rand.Rand) Personality {
+	scale := func(v float32) float32 {
+		return v * (1 + (random.Float32()*2-1)*selfPlayMutation)
+	}
+	p.Name = name
+	p.AimError = scale(p.AimError)
+	p.Reaction = float64(scale(float32(p.Reaction)))
+	p.Speed = scale(p.Speed)
+	if p.Speed > 1 {
+		p.Speed = 1
+	}
+	p.Edge = scale(p.Edge)
+	if p.Edge > 1 {
+		p.Edge = 1
+	}
+	if random.Float32() < selfPlayMutation {
+		p.Recenter = !p.Recenter
+	}
+	return p
+}
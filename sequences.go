@@ -0,0 +1,143 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/tween"
+)
+
+var (
+	introTime       = 2.4          // Seconds of the match intro before the first serve, unless set by the options
+	introZoom       = float32(1.6) // Zoom of the camera on the paddles during the intro
+	victoryTime     = 4.0          // Seconds of the spotlight on the winner, unless set by the options
+	victoryDrop     = float32(300) // Distance the winner text drops in from
+	spotlightRadius = float32(0.3) // Reach of the spotlight on the winner, as a fraction of the court height
+	spotlightDark   = float32(0.2) // Share of the light left out of the spotlight
+)
+
+// sequences are the scripted presentations of the start and the end of a match: their timers are
+// part of the simulation, so any input skips them the same way in the replays
+type sequences struct {
+	intro   float64 // Seconds left of the intro, the match waits for it
+	victory float64 // Seconds left of the victory sequence
+	slide   float32 // Distance of the texts from their place
+	alpha   float32 // Opacity of the texts
+}
+
+// startIntro shows the court from paddle to paddle and slides the player names in before the first serve
+func (g *Game) startIntro() {
+	g.state = GameIntro
+	g.sequences.intro = g.introLength
+	if !g.initialized {
+		return
+	}
+	g.tweens.Add(tween.NewFloat(&g.sequences.slide, menuSlide, 0, menuSlideTime, tween.OutBack))
+	g.tweens.Add(tween.NewFloat(&g.sequences.alpha, 0, 1, menuFadeTime, tween.InOutQuad))
+}
+
+// processIntro skips the intro on any input
+func (g *Game) processIntro(input Input) {
+	if input == (Input{}) {
+		return
+	}
+	g.sequences.intro = 0
+	g.state = GameActive
+	if g.initialized {
+		g.camera.LookAt(mgl.Vec2{float32(g.width / 2), float32(g.height / 2)})
+		g.camera.SetZoom(1)
+	}
+}
+
+// simulateIntro counts down the intro, then the match starts
+func (g *Game) simulateIntro(deltaTime float64) {
+	g.sequences.intro -= deltaTime
+	if g.sequences.intro <= 0 {
+		g.sequences.intro = 0
+		g.state = GameActive
+	}
+}
+
+// directIntro moves the camera from the left paddle to the right one, then back to the whole court
+func (g *Game) directIntro() {
+	elapsed := g.introLength - g.sequences.intro
+	paddles := [2]*GameObject{g.paddle1, g.paddle2}
+	if g.mirrored {
+		paddles[0], paddles[1] = paddles[1], paddles[0]
+	}
+	switch {
+	case elapsed < g.introLength/3:
+		g.camera.LookAt(paddles[0].position.Add(paddles[0].size.Mul(0.5)))
+		g.camera.SetZoom(introZoom)
+	case elapsed < g.introLength*2/3:
+		g.camera.LookAt(paddles[1].position.Add(paddles[1].size.Mul(0.5)))
+		g.camera.SetZoom(introZoom)
+	default:
+		g.camera.LookAt(mgl.Vec2{float32(g.width / 2), float32(g.height / 2)})
+		g.camera.SetZoom(1)
+	}
+}
+
+// startVictory drops the name of the winner in and puts the spotlight on them
+func (g *Game) startVictory() {
+	g.sequences.victory = g.victoryLength
+	if !g.initialized {
+		return
+	}
+	g.tweens.Add(tween.NewFloat(&g.sequences.slide, victoryDrop, 0, menuSlideTime, tween.OutBack))
+	g.tweens.Add(tween.NewFloat(&g.sequences.alpha, 0, 1, menuFadeTime, tween.InOutQuad))
+}
+
+// processVictory runs the victory sequence, it tells if the input was used: any input skips it
+func (g *Game) processVictory(input Input, deltaTime float64) bool {
+	if g.sequences.victory <= 0 {
+		return false
+	}
+	g.sequences.victory -= deltaTime
+	if input != (Input{}) {
+		g.sequences.victory = 0
+		g.sequences.slide, g.sequences.alpha = 0, 1
+	}
+	return true
+}
+
+// winner returns the team who won the match, or the last set
+func (g *Game) winner() int {
+	if g.paddle2Score > g.paddle1Score {
+		return 2
+	}
+	return 1
+}
+
+// spotlight places the light on the winner as drawn, through the camera, while the victory
+// sequence lasts, it tells if it did
+func (g *Game) spotlight() bool {
+	if g.state != GameWin || g.sequences.victory <= 0 {
+		return false
+	}
+	winner := g.paddle1
+	if g.winner() == 2 {
+		winner = g.paddle2
+	}
+	center := winner.position.Add(winner.size.Mul(0.5))
+	position := g.camera.View().Mul4x1(mgl.Vec4{center.X(), center.Y(), 0, 1})
+	g.effects.Light.Enabled = true
+	g.effects.Light.Position = mgl.Vec2{position.X() / float32(g.width), 1 - position.Y()/float32(g.height)}
+	g.effects.Light.Radius = spotlightRadius
+	g.effects.Light.Ambient = spotlightDark
+	return true
+}
+
+// drawIntro renders the names of the players sliding in from their sides
+func (g *Game) drawIntro() {
+	if g.state != GameIntro {
+		return
+	}
+	g.text.Alpha = g.sequences.alpha
+	names := g.screenPlayers()
+	left, right := g.teamName(names[0]), g.teamName(names[1])
+	width, _ := g.text.MeasureText(0.6, "%v", right)
+	y := float32(g.height/2) + 120
+	g.text.RenderText(80-g.sequences.slide, y, 0.6, mgl.Vec3{1.0, 1.0, 1.0}, "%v", left)
+	g.text.RenderText(float32(g.width)-80-width+g.sequences.slide, y, 0.6, mgl.Vec3{1.0, 1.0, 1.0}, "%v", right)
+	g.drawCentered(y, 0.6, mgl.Vec3{1.0, 0.8, 0.1}, "VS")
+	g.text.Alpha = 1
+}
@@ -0,0 +1,149 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	maxHandicap         = 5 // Most points a player can start the sets with
+	scoreboardRowHeight = float32(50)
+	historyPointSize    = float32(14) // Side of the square of a point in the round history
+	historyPointGap     = float32(4)
+)
+
+// setScore is the final score of a set
+type setScore struct {
+	score1, score2 int
+}
+
+// match keeps the sets of a best of match, a set is played to maxScore points
+type match struct {
+	bestOf   int
+	sets     []setScore // Scores of the finished sets
+	points   [][]int    // Player who scored each point, one list per set played
+	rallies  [2]int     // Balls returned by each player
+	duration float64    // Seconds of play
+	heat     heatMap
+}
+
+// newMatch returns a match won by the first player taking more than half of the sets
+func newMatch(bestOf int) match {
+	if bestOf < 1 {
+		bestOf = 1
+	}
+	m := match{bestOf: bestOf}
+	m.reset()
+	return m
+}
+
+// reset clears the sets played, starting the first one
+func (m *match) reset() {
+	m.sets = nil
+	m.points = [][]int{nil}
+	m.rallies = [2]int{}
+	m.duration = 0
+	m.heat = newHeatMap()
+}
+
+// point records the player who scored in the current set
+func (m *match) point(player int) {
+	current := len(m.points) - 1
+	m.points[current] = append(m.points[current], player)
+}
+
+// finishSet records the score of the current set, it reports whether the match is over
+func (m *match) finishSet(score1, score2 int) bool {
+	m.sets = append(m.sets, setScore{score1, score2})
+	sets1, sets2 := m.setsWon()
+	return sets1 > m.bestOf/2 || sets2 > m.bestOf/2
+}
+
+// nextSet starts recording the points of a new set
+func (m *match) nextSet() {
+	m.points = append(m.points, nil)
+}
+
+// setsWon returns the sets won by each player
+func (m *match) setsWon() (int, int) {
+	var sets1, sets2 int
+	for _, set := range m.sets {
+		if set.score1 > set.score2 {
+			sets1++
+		} else {
+			sets2++
+		}
+	}
+	return sets1, sets2
+}
+
+// clone returns a copy of the match not sharing the history
+func (m match) clone() match {
+	m.sets = append([]setScore(nil), m.sets...)
+	points := make([][]int, len(m.points))
+	for i, set := range m.points {
+		points[i] = append([]int(nil), set...)
+	}
+	m.points = points
+	m.heat = m.heat.clone()
+	return m
+}
+
+// SetHandicaps changes the points the players start the sets with from the next match
+func (g *Game) SetHandicaps(handicap1, handicap2 int) {
+	for i, handicap := range []int{handicap1, handicap2} {
+		if handicap < 0 {
+			handicap = 0
+		} else if handicap > maxHandicap {
+			handicap = maxHandicap
+		}
+		g.handicaps[i] = handicap
+	}
+}
+
+// startNextSet clears the court for the next set of the match
+func (g *Game) startNextSet() {
+	g.match.nextSet()
+	g.resetObjects()
+	g.lastHit = 0
+	g.state = GameActive
+	if !g.initialized {
+		return
+	}
+	g.applySkins()
+	g.camera.Reset()
+	g.particles.Reset()
+}
+
+// playerColor returns the color of the skin picked by the player
+func (g *Game) playerColor(player int) mgl.Vec3 {
+	if len(g.skins) == 0 {
+		return mgl.Vec3{1.0, 1.0, 1.0}
+	}
+	if player == 1 {
+		return g.skins[g.paddle1Skin].Color
+	}
+	return g.skins[g.paddle2Skin].Color
+}
+
+// drawScoreboard renders the scores of the sets played, each with the history of who scored its points:
+// the points of the left player on the top of the row and the ones of the right player on the bottom
+func (g *Game) drawScoreboard() {
+	rows := float32(len(g.match.points))
+	width := float32(maxScore*2-1)*(historyPointSize+historyPointGap) + 300
+	position := mgl.Vec2{(float32(g.width) - width) / 2, float32(g.height/2) + 60}
+	g.renderer.Draw(position, mgl.Vec2{width, rows*scoreboardRowHeight + 20}, 0, mgl.Vec3{0.0, 0.0, 0.0})
+	for i, points := range g.match.points {
+		y := position.Y() + 10 + float32(i)*scoreboardRowHeight
+		g.text.RenderText(position.X()+20, y+10, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "Set %v", i+1)
+		if i < len(g.match.sets) {
+			set := g.match.sets[i]
+			g.text.RenderText(position.X()+130, y+10, 0.3, mgl.Vec3{1.0, 1.0, 1.0}, "%v : %v", set.score1, set.score2)
+		}
+		for j, player := range points {
+			x := position.X() + 280 + float32(j)*(historyPointSize+historyPointGap)
+			top := y + scoreboardRowHeight/2 - historyPointSize - historyPointGap/2
+			if player == 2 {
+				top = y + scoreboardRowHeight/2 + historyPointGap/2
+			}
+			g.renderer.Draw(mgl.Vec2{x, top}, mgl.Vec2{historyPointSize, historyPointSize}, 0, g.playerColor(player))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/render"
+)
+
+// Setting is a named choice of a settings screen, its value is cycled with left and right
+type Setting struct {
+	Name    string
+	Values  []string
+	Current int // Index of the current value
+}
+
+// Value returns the current value of the setting
+func (s *Setting) Value() string {
+	return s.Values[s.Current]
+}
+
+// SettingsControls are the commands of a settings screen for one frame
+type SettingsControls struct {
+	Navigation       // Activating a setting moves to its next value
+	Left, Right bool // Change the value of the focused setting
+	Close       bool
+	Pointer     Pointer // Hovering focuses a setting, clicking its arrows changes it
+}
+
+// SettingsScreen is a list of settings drawn over the game, it's up to the owner to apply the changes
+type SettingsScreen struct {
+	game     *Game
+	title    string
+	settings []*Setting
+	hint     string // Keys listed at the bottom
+	focus    focus
+	open     bool
+}
+
+// NewSettingsScreen returns a closed screen listing the settings
+func NewSettingsScreen(game *Game, title string, settings []*Setting) *SettingsScreen {
+	s := &SettingsScreen{
+		game:     game,
+		title:    title,
+		settings: settings,
+		hint:     "UP/DOWN/TAB select - LEFT/RIGHT change - ESC back",
+	}
+	if game.initialized {
+		game.layers.Register(layerUI, render.DrawFunc(func(float32) { s.draw() }))
+	}
+	return s
+}
+
+// SetHint changes the keys listed at the bottom of the screen, for the owners handling more of them
+func (s *SettingsScreen) SetHint(hint string) {
+	s.hint = hint
+}
+
+// Open shows the screen with the first setting focused
+func (s *SettingsScreen) Open() {
+	s.open = true
+	s.focus.reset(len(s.settings))
+}
+
+// IsOpen tells if the screen is shown
+func (s *SettingsScreen) IsOpen() bool {
+	return s.open
+}
+
+// Update applies the controls and returns the setting whose value changed, nil if none did
+func (s *SettingsScreen) Update(controls SettingsControls) *Setting {
+	if !s.open || len(s.settings) == 0 {
+		return nil
+	}
+	if controls.Close {
+		s.open = false
+		return nil
+	}
+	if s.focus.update(controls.Navigation, controls.Pointer, s.row) {
+		// The previous value clicking the left arrow, the next anywhere else
+		controls.Left = controls.Pointer.Click && controls.Pointer.Position.X() < s.valueX()+60
+		controls.Right = !controls.Left
+	}
+	if !controls.Left && !controls.Right {
+		return nil
+	}
+	setting := s.settings[s.focus.index]
+	if controls.Left {
+		setting.Current = (setting.Current + len(setting.Values) - 1) % len(setting.Values)
+	} else {
+		setting.Current = (setting.Current + 1) % len(setting.Values)
+	}
+	return setting
+}
+
+// row returns the area of the setting at the index
+func (s *SettingsScreen) row(i int) (mgl.Vec2, mgl.Vec2) {
+	return mgl.Vec2{float32(s.game.width/2) - 480, 268 + float32(i)*70}, mgl.Vec2{960, 60}
+}
+
+// valueX returns where the values are drawn
+func (s *SettingsScreen) valueX() float32 {
+	return float32(s.game.width/2) + 20
+}
+
+// draw renders the settings over the whole screen, the selected one highlighted
+func (s *SettingsScreen) draw() {
+	if !s.open {
+		return
+	}
+	g := s.game
+	g.renderer.Draw(mgl.Vec2{0, 0}, mgl.Vec2{float32(g.width), float32(g.height)}, 0, mgl.Vec3{0.1, 0.1, 0.1})
+	x := float32(g.width/2) - 460
+	g.text.RenderText(x, 160, 0.6, mgl.Vec3{1.0, 1.0, 1.0}, "%v", s.title)
+	for i, setting := range s.settings {
+		position, size := s.row(i)
+		y := position.Y() + 12
+		color := mgl.Vec3{0.6, 0.6, 0.6}
+		if i == s.focus.index {
+			g.drawFocusRing(position, size)
+			color = mgl.Vec3{1.0, 0.8, 0.1}
+		}
+		g.text.RenderText(x, y, 0.4, color, "%v", setting.Name)
+		g.text.RenderText(s.valueX(), y, 0.4, color, "< %v >", setting.Value())
+	}
+	g.text.RenderText(x, float32(g.height)-120, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "%v", s.hint)
+}
@@ -1,15 +1,23 @@
 #version 330 core
 layout (location = 0) in vec2 vertex; // <vec2 position>
+// Per particle attributes
+layout (location = 1) in vec2 offset;
+layout (location = 2) in vec2 size;
+layout (location = 3) in float rotation;
+layout (location = 4) in vec4 color;
 
 out vec4 ParticleColor;
 
 uniform mat4 projection;
-uniform vec2 offset;
-uniform vec4 color;
+uniform mat4 view;
 
 void main()
 {
-    float scale = 10.0f;
+    // Scale and rotate the quad around its center
+    vec2 local = (vertex.xy - 0.5) * size;
+    float c = cos(rotation);
+    float s = sin(rotation);
+    vec2 rotated = vec2(local.x * c - local.y * s, local.x * s + local.y * c);
     ParticleColor = color;
-    gl_Position = projection * vec4((vertex.xy * scale) + offset, 1.0, 1.0);
-}
\ No newline at end of file
+    gl_Position = projection * view * vec4(rotated + offset + size * 0.5, 1.0, 1.0);
+}
@@ -10,15 +10,43 @@ uniform float     blur_kernel[9];
 uniform bool chaos;
 uniform bool confuse;
 uniform bool shake;
+uniform bool bloom;
+uniform bool dim;
+uniform int transition_effect;
+uniform float transition_progress;
+uniform bool lighting;
+uniform vec2 light_position;
+uniform float light_radius;
+uniform float light_ambient;
+uniform bool fog;
+uniform float fog_split;
+uniform float fog_side;
+uniform float fog_density;
+uniform vec2 twist_position[2];
+uniform float twist_radius[2];
+uniform float twist_angle[2];
+uniform float aspect;
 
 void main()
 {
     color = vec4(0.0f);
+    // swirl the scene around the twists, the most at their centers
+    vec2 uv = TexCoords;
+    for(int i = 0; i < 2; i++)
+    {
+        if(twist_angle[i] == 0.0f)
+            continue;
+        vec2 offset = (uv - twist_position[i]) * vec2(aspect, 1.0f);
+        float reach = clamp(1.0f - length(offset) / twist_radius[i], 0.0f, 1.0f);
+        float angle = twist_angle[i] * reach * reach;
+        offset = mat2(cos(angle), sin(angle), -sin(angle), cos(angle)) * offset;
+        uv = twist_position[i] + offset / vec2(aspect, 1.0f);
+    }
     vec3 sample[9];
     // sample from texture offsets if using convolution matrix
     if(chaos || shake)
         for(int i = 0; i < 9; i++)
-            sample[i] = vec3(texture(scene, TexCoords.st + offsets[i]));
+            sample[i] = vec3(texture(scene, uv + offsets[i]));
 
     // process effects
     if(chaos)
@@ -29,7 +57,7 @@ void main()
     }
     else if(confuse)
     {
-        color = vec4(1.0 - texture(scene, TexCoords).rgb, 1.0);
+        color = vec4(1.0 - texture(scene, uv).rgb, 1.0);
     }
     else if(shake)
     {
@@ -39,6 +67,47 @@ void main()
     }
     else
     {
-        color =  texture(scene, TexCoords);
+        color =  texture(scene, uv);
+    }
+    if(bloom)
+    {
+        // add a blurred copy of the bright parts of the scene around them
+        vec3 glow = vec3(0.0f);
+        for(int i = 0; i < 9; i++)
+        {
+            vec3 bright = vec3(texture(scene, uv + offsets[i] * 3.0));
+            glow += max(bright - vec3(0.6f), vec3(0.0f)) * blur_kernel[i];
+        }
+        color.rgb += glow * 2.0f;
+    }
+    if(lighting)
+    {
+        // light the scene around the point light, fading out with the distance
+        float reach = length((TexCoords - light_position) * vec2(aspect, 1.0f));
+        float falloff = clamp(1.0f - reach / light_radius, 0.0f, 1.0f);
+        color.rgb *= mix(light_ambient, 1.0f, falloff * falloff);
+    }
+    if(fog)
+    {
+        // blur and darken the side of the split the fog is on, with a soft edge
+        float amount = smoothstep(-0.05f, 0.05f, (TexCoords.x - fog_split) * -fog_side) * fog_density;
+        if(amount > 0.0f)
+        {
+            vec3 blurred = vec3(0.0f);
+            for(int i = 0; i < 9; i++)
+                blurred += vec3(texture(scene, uv + offsets[i] * 4.0)) * blur_kernel[i];
+            color.rgb = mix(color.rgb, blurred * 0.2f, amount);
+        }
+    }
+    if(dim)
+    {
+        // darken and desaturate the scene behind a dialog
+        float gray = dot(color.rgb, vec3(0.299f, 0.587f, 0.114f));
+        color.rgb = mix(color.rgb, vec3(gray), 0.6f) * 0.35f;
     }
+    // reveal the scene just switched to
+    if(transition_effect == 1 || transition_effect == 3)
+        color.rgb *= transition_progress;
+    else if(transition_effect == 2 && TexCoords.x > transition_progress)
+        color.rgb = vec3(0.0f);
 }
@@ -7,6 +7,8 @@ uniform bool  chaos;
 uniform bool  confuse;
 uniform bool  shake;
 uniform float time;
+uniform int   transition_effect;
+uniform float transition_progress;
 
 void main()
 {
@@ -26,6 +28,11 @@ void main()
     {
         TexCoords = texture;
     }
+    if(transition_effect == 3)
+    {
+        // zoom out from twice the size as the transition goes
+        TexCoords = vec2(0.5) + (TexCoords - vec2(0.5)) / mix(2.0, 1.0, transition_progress);
+    }
     if (shake)
     {
         float strength = 0.01;
@@ -1,9 +1,15 @@
 #version 330 core
+in vec2 TexCoords;
 out vec4 color;
 
+uniform sampler2D image;
+uniform bool useImage;
 uniform vec3 spriteColor;
+uniform float spriteAlpha;
 
 void main()
 {
-    color = vec4(spriteColor, 1.0);
+    color = vec4(spriteColor, spriteAlpha);
+    if (useImage)
+        color *= texture(image, TexCoords);
 }
\ No newline at end of file
@@ -1,10 +1,14 @@
 #version 330 core
 layout (location = 0) in vec2 vertex; // <vec2 position>
 
+out vec2 TexCoords;
+
 uniform mat4 model;
+uniform mat4 view;
 uniform mat4 projection;
 
 void main()
 {
-    gl_Position = projection * model * vec4(vertex.xy, 1.0, 1.0);
+    TexCoords = vertex.xy;
+    gl_Position = projection * view * model * vec4(vertex.xy, 1.0, 1.0);
 }
\ No newline at end of file
@@ -4,9 +4,10 @@ out vec4 color;
 
 uniform sampler2D text;
 uniform vec3 textColor;
+uniform float textAlpha;
 
 void main()
 {    
     vec4 sampled = vec4(1.0, 1.0, 1.0, texture(text, TexCoords).r);
-    color = vec4(textColor, 1.0) * sampled;
+    color = vec4(textColor, textAlpha) * sampled;
 }  
\ No newline at end of file
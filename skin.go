@@ -0,0 +1,173 @@
+package pong
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/particles"
+	"github.com/lucatironi/go-pong/pkg/render"
+	"github.com/lucatironi/go-pong/pkg/resources"
+)
+
+// skinsDir is where the skins are loaded from: every <name>.png and <name>.json pair is a skin,
+// either file can be missing
+const skinsDir = "./skins"
+
+// Skin is the look of a paddle, and of the ball after the paddle hits it
+type Skin struct {
+	Name    string
+	Texture *render.Texture2D // Sprite tinted by the color, plain color when nil
+	Color   mgl.Vec3
+	Trail   *particles.ParticlePreset // Particles left behind by the ball, the grey trail when nil
+}
+
+// skinFile is the JSON description of a skin
+type skinFile struct {
+	Color [3]float32     `json:"color"`
+	Trail *particlesFile `json:"trail"`
+}
+
+// defaultSkinTrail holds the life and fade of the trails that don't set them
+var defaultSkinTrail = particles.ParticlePreset{Life: 1, Fade: 2.5}
+
+// defaultSkin is the classic look, always available as the first skin
+func defaultSkin() Skin {
+	return Skin{
+		Name:  "classic",
+		Color: mgl.Vec3{1, 1, 1},
+	}
+}
+
+// loadSkins returns the default skin followed by the skins found in the directories, sorted by name:
+// a skin named like one of a previous directory is skipped
+func loadSkins(dirs []string, resourceManager *resources.ResourceManager) []Skin {
+	skins := []Skin{defaultSkin()}
+	seen := map[string]bool{skins[0].Name: true}
+	for _, dir := range dirs {
+		names, err := skinNames(dir)
+		if err != nil {
+			fmt.Println("ERROR::SKINS:", err)
+			continue
+		}
+		for _, name := range names {
+			if seen[name] {
+				fmt.Printf("ERROR::SKINS: skin %v of %v already exists\n", name, dir)
+				continue
+			}
+			seen[name] = true
+			skin, err := loadSkin(dir, name, resourceManager)
+			if err != nil {
+				fmt.Println("ERROR::SKINS:", err)
+				continue
+			}
+			skins = append(skins, skin)
+		}
+	}
+	sort.Slice(skins[1:], func(i, j int) bool { return skins[i+1].Name < skins[j+1].Name })
+	return skins
+}
+
+// skinNames returns the names of the skins found in the directory, none when it's missing
+func skinNames(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var names []string
+	seen := make(map[string]bool)
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		if file.IsDir() || (ext != ".png" && ext != ".json") {
+			continue
+		}
+		name := strings.TrimSuffix(file.Name(), ext)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// loadSkin reads the description and the texture of a skin
+func loadSkin(dir, name string, resourceManager *resources.ResourceManager) (Skin, error) {
+	skin := defaultSkin()
+	skin.Name = name
+	data, err := ioutil.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil && !os.IsNotExist(err) {
+		return skin, err
+	} else if err == nil {
+		// Read the description over the default look, so it only needs the fields it changes
+		file := skinFile{Color: skin.Color}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return skin, fmt.Errorf("failed to parse skin %v: %v", name, err)
+		}
+		if !validColor(file.Color[:]) {
+			return skin, fmt.Errorf("skin %v: color not from 0 to 1", name)
+		}
+		skin.Color = mgl.Vec3(file.Color)
+		if file.Trail != nil {
+			trail, err := file.Trail.preset()
+			if err != nil {
+				return skin, fmt.Errorf("skin %v: trail %v", name, err)
+			}
+			skin.Trail = &trail
+		}
+	}
+	texture := filepath.Join(dir, name+".png")
+	if _, err := os.Stat(texture); err == nil {
+		// A malformed image skips the skin, the others are loaded anyway
+		if skin.Texture, err = resourceManager.LoadTexture(texture, skinTextureName(name)); err != nil {
+			return skin, fmt.Errorf("skin %v: %v", name, err)
+		}
+	}
+	return skin, nil
+}
+
+// releaseSkins gives back the textures of the skins
+func releaseSkins(skins []Skin, resourceManager *resources.ResourceManager) {
+	for _, skin := range skins {
+		if skin.Texture != nil {
+			resourceManager.ReleaseTexture(skinTextureName(skin.Name))
+		}
+	}
+}
+
+func skinTextureName(name string) string {
+	return "skin_" + name
+}
+
+// SkinName returns the name of the skin picked by the player, empty until the skins are loaded
+func (g *Game) SkinName(player int) string {
+	if len(g.skins) == 0 {
+		return ""
+	}
+	if player == 1 {
+		return g.skins[g.paddle1Skin].Name
+	}
+	return g.skins[g.paddle2Skin].Name
+}
+
+// PickSkin gives the player the named skin, the unknown names are ignored
+func (g *Game) PickSkin(player int, name string) {
+	for i, skin := range g.skins {
+		if skin.Name != name {
+			continue
+		}
+		if player == 1 {
+			g.paddle1Skin = i
+		} else {
+			g.paddle2Skin = i
+		}
+		g.applySkins()
+		return
+	}
+}
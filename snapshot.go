@@ -0,0 +1,115 @@
+package pong
+
+// simulationSnapshot holds everything the simulation depends on, so it can be restored later.
+// Left out on purpose: the settings of the match (mutators, doubles, level, handicaps and the
+// lengths of the sequences) set before it starts, the demo of the menu, never recorded, and the
+// presentation (camera, effects, particles, tweens and the court flip, which doesn't flip the input)
+type simulationSnapshot struct {
+	state        GameState
+	paddle1      GameObject
+	paddle2      GameObject
+	paddle3      GameObject
+	paddle4      GameObject
+	ball         BallObject
+	paddle1Score int
+	paddle2Score int
+	lastHit      int
+	tutorial     tutorial
+	match        match
+	rules        rules
+	wells        [2]gravityWell
+	powerUps     powerUps
+	random       randomSource
+	hitStop      int
+	warmUp       warmUp
+	sequences    sequences
+}
+
+// snapshot copies the state of the simulation
+func (g *Game) snapshot() simulationSnapshot {
+	return simulationSnapshot{
+		state:        g.state,
+		paddle1:      *g.paddle1,
+		paddle2:      *g.paddle2,
+		paddle3:      *g.paddle3,
+		paddle4:      *g.paddle4,
+		ball:         *g.ball,
+		paddle1Score: g.paddle1Score,
+		paddle2Score: g.paddle2Score,
+		lastHit:      g.lastHit,
+		tutorial:     g.tutorial,
+		match:        g.match.clone(),
+		rules:        g.rules,
+		wells:        g.wells,
+		powerUps:     g.powerUps.clone(),
+		random:       *g.randomSource,
+		hitStop:      g.hitStop,
+		warmUp:       g.warmUp,
+		sequences:    g.sequences,
+	}
+}
+
+// restore brings the simulation back to the snapshot, the effects are cleared
+func (g *Game) restore(s simulationSnapshot) {
+	g.state = s.state
+	*g.paddle1 = s.paddle1
+	*g.paddle2 = s.paddle2
+	*g.paddle3 = s.paddle3
+	*g.paddle4 = s.paddle4
+	*g.ball = s.ball
+	g.paddle1Score = s.paddle1Score
+	g.paddle2Score = s.paddle2Score
+	g.lastHit = s.lastHit
+	g.tutorial = s.tutorial
+	g.match = s.match.clone()
+	g.rules = s.rules
+	g.wells = s.wells
+	g.powerUps = s.powerUps.clone()
+	*g.randomSource = s.random
+	g.hitStop = s.hitStop
+	g.warmUp = s.warmUp
+	g.sequences = s.sequences
+	if !g.initialized {
+		return
+	}
+	g.applySkins()
+	g.particles.Reset()
+	if g.state != GameWin {
+		g.camera.Reset()
+		g.fireworks.Stop()
+		g.confetti.Stop()
+	}
+}
+
+// stepSimulation advances only the simulation by one fixed update, frozen during a hit stop, it
+// returns what happened and the time simulated for the presentation to follow
+func (g *Game) stepSimulation(input Input, deltaTime float64) (simulationEvents, float64) {
+	g.storePositions()
+	deltaTime = g.stepTime(deltaTime)
+	g.ProcessInput(input, deltaTime)
+	events := simulationEvents{phase: g.state}
+	switch g.state {
+	case GameActive:
+		events = g.simulate(deltaTime)
+	case GameTutorial:
+		events = g.simulateTutorial(deltaTime)
+	case GameWarmUp:
+		events = g.simulateWarmUp(deltaTime)
+	case GameIntro:
+		g.simulateIntro(deltaTime)
+		return events, deltaTime
+	case GameMenu:
+		if !g.Attracting() {
+			return events, deltaTime
+		}
+		events = g.simulateAttract(deltaTime)
+	default:
+		return events, deltaTime
+	}
+	if events.paddleHit {
+		g.lastHit = events.hitBy
+	} else if events.scored != 0 {
+		g.lastHit = 0
+	}
+	return events, deltaTime
+}
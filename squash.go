@@ -0,0 +1,27 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/tween"
+)
+
+var (
+	squashTime   = 0.3                 // Seconds the objects take to spring back to their shape
+	paddleSquash = mgl.Vec2{0.7, 1.08} // Scale of the paddle as it hits the ball
+	ballSquash   = mgl.Vec2{0.6, 1.35} // Scale of the ball against a paddle, flattened along the hit
+	wallSquash   = mgl.Vec2{1.35, 0.6} // Scale of the ball against the top or the bottom of the court
+	noSquash     = mgl.Vec2{1.0, 1.0}
+)
+
+// squash deforms the paddle that hit the ball and the ball against what it bounced on, then springs
+// them back: only the look changes, the collisions still use the sizes
+func (g *Game) squash(events simulationEvents) {
+	switch {
+	case events.paddleHit:
+		paddle := events.hitPaddle
+		g.tweens.Add(tween.NewVec2(&paddle.scale, paddleSquash, noSquash, squashTime, tween.OutElastic))
+		g.tweens.Add(tween.NewVec2(&g.ball.scale, ballSquash, noSquash, squashTime, tween.OutElastic))
+	case events.wallHit:
+		g.tweens.Add(tween.NewVec2(&g.ball.scale, wallSquash, noSquash, squashTime, tween.OutElastic))
+	}
+}
@@ -0,0 +1,61 @@
+package pong
+
+// Stats are the career statistics of a player, summed over the matches played to the end
+type Stats struct {
+	Matches        int     `json:"matches"`
+	Wins           int     `json:"wins"`
+	PointsScored   int     `json:"points_scored"`
+	PointsConceded int     `json:"points_conceded"`
+	Rallies        int     `json:"rallies"`   // Balls returned with the paddle
+	PlayTime       float64 `json:"play_time"` // Seconds of play, the pauses and the breaks between sets excluded
+}
+
+// MatchResult sums up a match played to the end, by player
+type MatchResult struct {
+	Winner   int
+	Points   [2]int
+	Rallies  [2]int
+	Duration float64 // Seconds of play
+}
+
+// Add counts the match in the statistics of the player
+func (s *Stats) Add(result MatchResult, player int) {
+	own, other := player-1, 2-player
+	s.Matches++
+	if result.Winner == player {
+		s.Wins++
+	}
+	s.PointsScored += result.Points[own]
+	s.PointsConceded += result.Points[other]
+	s.Rallies += result.Rallies[own]
+	s.PlayTime += result.Duration
+}
+
+// WinRate returns the fraction of the matches won
+func (s Stats) WinRate() float64 {
+	if s.Matches == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Matches)
+}
+
+// Result returns the result of the match just won, false until a match is won
+func (g *Game) Result() (MatchResult, bool) {
+	if g.state != GameWin {
+		return MatchResult{}, false
+	}
+	result := MatchResult{
+		Winner:   1,
+		Rallies:  g.match.rallies,
+		Duration: g.match.duration,
+	}
+	if g.paddle2Score > g.paddle1Score {
+		result.Winner = 2
+	}
+	for _, points := range g.match.points {
+		for _, player := range points {
+			result.Points[player-1]++
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,120 @@
+package pong
+
+import (
+	"fmt"
+	"math"
+	"runtime/metrics"
+)
+
+var telemetryFrames = 30 // Frames the allocations are averaged over and the longest GC pause is taken from
+
+// Runtime metrics read by the telemetry
+const (
+	metricAllocBytes   = "/gc/heap/allocs:bytes"
+	metricAllocObjects = "/gc/heap/allocs:objects"
+	metricPauses       = "/gc/pauses:seconds"
+	metricHeap         = "/memory/classes/heap/objects:bytes"
+	metricCycles       = "/gc/cycles/total:gc-cycles"
+)
+
+// telemetry follows the allocations and the garbage collections of the frames from the runtime
+// metrics, for the debug overlay
+type telemetry struct {
+	samples  []metrics.Sample
+	started  bool    // The previous sample is a baseline to measure from
+	frames   int     // Frames measured in the current window
+	bytes    uint64  // Bytes allocated in the current window
+	objects  uint64  // Objects allocated in the current window
+	pause    float64 // Longest GC pause of the current window, upper bound of its bucket
+	previous struct {
+		bytes, objects uint64
+		pauses         []uint64 // Counts of the buckets of the pause histogram
+	}
+	values []debugValue // Shown, refreshed at the end of every window
+}
+
+// newTelemetry returns a telemetry reading the runtime metrics it needs
+func newTelemetry() *telemetry {
+	return &telemetry{samples: []metrics.Sample{
+		{Name: metricAllocBytes},
+		{Name: metricAllocObjects},
+		{Name: metricPauses},
+		{Name: metricHeap},
+		{Name: metricCycles},
+	}}
+}
+
+// reset starts measuring again from the next frame, when the overlay shows up after frames it didn't see
+func (t *telemetry) reset() {
+	t.started = false
+	t.frames, t.bytes, t.objects, t.pause = 0, 0, 0, 0
+}
+
+// frame reads the metrics at the end of a frame, adding the allocations and the GC pauses since the
+// previous one to the window
+func (t *telemetry) frame() {
+	metrics.Read(t.samples)
+	bytes, objects := t.uint64(metricAllocBytes), t.uint64(metricAllocObjects)
+	cycles := t.uint64(metricCycles)
+	var pauses *metrics.Float64Histogram
+	if sample := t.sample(metricPauses); sample.Value.Kind() == metrics.KindFloat64Histogram {
+		pauses = sample.Value.Float64Histogram()
+	}
+	if t.started {
+		t.frames++
+		t.bytes += bytes - t.previous.bytes
+		t.objects += objects - t.previous.objects
+		if pauses != nil && len(pauses.Counts) == len(t.previous.pauses) {
+			for i, count := range pauses.Counts {
+				// The longest pause is only known to be within its bucket, the open last one by its start
+				bound := pauses.Buckets[i+1]
+				if math.IsInf(bound, 1) {
+					bound = pauses.Buckets[i]
+				}
+				if count > t.previous.pauses[i] && bound > t.pause {
+					t.pause = bound
+				}
+			}
+		}
+	}
+	t.started = true
+	t.previous.bytes, t.previous.objects = bytes, objects
+	if pauses != nil {
+		t.previous.pauses = append(t.previous.pauses[:0], pauses.Counts...)
+	}
+	if t.frames < telemetryFrames && t.values != nil {
+		return
+	}
+	frames := uint64(t.frames)
+	if frames == 0 {
+		frames = 1
+	}
+	pause := "none"
+	if t.pause > 0 {
+		pause = fmt.Sprintf("%.3fms", t.pause*1000)
+	}
+	t.values = []debugValue{
+		{"allocs/frame", fmt.Sprintf("%v B %v objects", t.bytes/frames, t.objects/frames)},
+		{"gc pause", fmt.Sprintf("%v, %v cycles", pause, cycles)},
+		{"heap", fmt.Sprintf("%.1f MB", float64(t.uint64(metricHeap))/(1<<20))},
+	}
+	t.frames, t.bytes, t.objects, t.pause = 0, 0, 0, 0
+}
+
+// sample returns the sample of the metric
+func (t *telemetry) sample(name string) metrics.Sample {
+	for _, sample := range t.samples {
+		if sample.Name == name {
+			return sample
+		}
+	}
+	return metrics.Sample{}
+}
+
+// uint64 returns the value of the metric, 0 when the runtime doesn't support it
+func (t *telemetry) uint64(name string) uint64 {
+	if sample := t.sample(name); sample.Value.Kind() == metrics.KindUint64 {
+		return sample.Value.Uint64()
+	}
+	return 0
+}
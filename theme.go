@@ -0,0 +1,47 @@
+package pong
+
+import (
+	"fmt"
+	"strings"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// Theme sets the colors of the court
+type Theme struct {
+	Name       string
+	Background mgl.Vec3
+	Markings   mgl.Vec3 // Color of the lines of the court, none when black
+	Lighting   bool     // The ball lights the court around it, the rest is left in the dark
+}
+
+var themes = []Theme{
+	{Name: "grey", Background: mgl.Vec3{0.2, 0.2, 0.2}},
+	{Name: "black", Background: mgl.Vec3{0.0, 0.0, 0.0}},
+	{Name: "navy", Background: mgl.Vec3{0.05, 0.08, 0.18}},
+	{Name: "night", Background: mgl.Vec3{0.12, 0.12, 0.16}, Markings: mgl.Vec3{0.6, 0.6, 0.7}, Lighting: true},
+}
+
+// ThemeNames returns the names of the themes of the game followed by the ones of the mods, the first is the default
+func (c ModContent) ThemeNames() []string {
+	var names []string
+	for _, theme := range append(themes[:len(themes):len(themes)], c.Themes...) {
+		names = append(names, theme.Name)
+	}
+	return names
+}
+
+// ParseTheme returns the theme of the game or of the mods with the given name
+func (c ModContent) ParseTheme(name string) (Theme, error) {
+	for _, theme := range append(themes[:len(themes):len(themes)], c.Themes...) {
+		if strings.EqualFold(name, theme.Name) {
+			return theme, nil
+		}
+	}
+	return themes[0], fmt.Errorf("unknown theme %q, expected one of %v", name, strings.Join(c.ThemeNames(), ", "))
+}
+
+// SetTheme changes the colors of the court
+func (g *Game) SetTheme(theme Theme) {
+	g.theme = theme
+}
@@ -0,0 +1,62 @@
+package pong
+
+import mgl "github.com/go-gl/mathgl/mgl32"
+
+var (
+	toastDuration = 3.0 // Seconds a notification stays on screen, fades included
+	toastFade     = 0.3 // Seconds of the fade in and of the fade out
+	maxToasts     = 3   // Notifications on screen at once, the others wait their turn
+)
+
+// toast is a notification on screen
+type toast struct {
+	text string
+	age  float64
+}
+
+// toasts shows the notifications in the top right corner, queueing them when the corner is full
+type toasts struct {
+	shown []toast
+	queue []string
+}
+
+// Notify shows a short notification in the corner of the screen
+func (g *Game) Notify(text string) {
+	g.toasts.queue = append(g.toasts.queue, text)
+}
+
+// update ages the notifications on screen and shows the queued ones as room is made
+func (t *toasts) update(deltaTime float64) {
+	shown := t.shown[:0]
+	for _, toast := range t.shown {
+		toast.age += deltaTime
+		if toast.age < toastDuration {
+			shown = append(shown, toast)
+		}
+	}
+	t.shown = shown
+	for len(t.shown) < maxToasts && len(t.queue) > 0 {
+		t.shown = append(t.shown, toast{text: t.queue[0]})
+		t.queue = t.queue[1:]
+	}
+}
+
+// alpha returns the opacity of the toast fading in and out
+func (t toast) alpha() float32 {
+	alpha := 1.0
+	if t.age < toastFade {
+		alpha = t.age / toastFade
+	} else if left := toastDuration - t.age; left < toastFade {
+		alpha = left / toastFade
+	}
+	return float32(alpha)
+}
+
+// drawToasts renders the notifications stacked from the top right corner
+func (g *Game) drawToasts() {
+	for i, toast := range g.toasts.shown {
+		g.text.Alpha = toast.alpha()
+		g.text.RenderText(float32(g.width)-640, 40+float32(i)*50, 0.3, mgl.Vec3{1.0, 0.8, 0.1}, "%v", toast.text)
+	}
+	g.text.Alpha = 1
+}
@@ -0,0 +1,59 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+	"github.com/lucatironi/go-pong/pkg/physics"
+)
+
+var (
+	trajectoryTime   = 2.0         // Seconds of the path of the ball predicted ahead
+	trajectoryStep   = 1.0 / 120.0 // Seconds between the predicted positions
+	trajectoryDash   = 6           // Predicted positions per dash, and per gap between the dashes
+	trajectoryWidth  = float32(8)
+	trajectoryOpaque = float32(0.8) // Opacity at the start of the path, it fades out along the way
+)
+
+// ToggleTrajectory shows or hides the aiming aid drawing the predicted path of the ball, only in the tutorial
+func (g *Game) ToggleTrajectory() {
+	g.trajectory = !g.trajectory
+}
+
+// predictTrajectory returns the centers of the ball along its predicted path, bouncing on the walls
+// like the tutorial does, until it reaches the line of the left paddle
+func (g *Game) predictTrajectory() []mgl.Vec2 {
+	box := g.ball.AABB()
+	velocity := g.ball.velocity
+	if velocity.Len() == 0 {
+		return nil
+	}
+	limit := g.paddle1.position.X() + g.paddle1.size.X()
+	var path []mgl.Vec2
+	for t := 0.0; t < trajectoryTime; t += trajectoryStep {
+		box.Position, velocity = physics.Move(box, velocity, trajectoryStep, float32(g.height))
+		if box.Position.X()+box.Size.X() >= float32(g.width) && velocity.X() > 0 {
+			velocity[0] = -velocity.X()
+		}
+		if box.Position.X() <= limit && velocity.X() < 0 {
+			break
+		}
+		path = append(path, box.Center())
+	}
+	return path
+}
+
+// drawTrajectory renders the predicted path of the ball as a dashed line fading along the way
+func (g *Game) drawTrajectory() {
+	if !g.trajectory || g.state != GameTutorial {
+		return
+	}
+	path := g.predictTrajectory()
+	size := mgl.Vec2{trajectoryWidth, trajectoryWidth}
+	for i, point := range path {
+		if i/trajectoryDash%2 == 1 {
+			continue
+		}
+		g.renderer.Alpha = trajectoryOpaque * (1 - float32(i)/float32(len(path)))
+		g.renderer.Draw(point.Sub(size.Mul(0.5)), size, 0, g.ball.color)
+	}
+	g.renderer.Alpha = 1
+}
@@ -0,0 +1,64 @@
+package pong
+
+import (
+	"github.com/lucatironi/go-pong/pkg/render"
+	"github.com/lucatironi/go-pong/pkg/tween"
+)
+
+// sceneChange is a switch of the game from a state to another
+type sceneChange struct {
+	from, to GameState
+}
+
+// sceneTransition is how a scene change is shown, the easing shapes the progress of the effect
+type sceneTransition struct {
+	effect   render.Transition
+	duration float64 // Seconds
+	easing   tween.Easing
+}
+
+// sceneTransitions are the effects of the scene changes, the ones not listed switch at once
+var sceneTransitions = map[sceneChange]sceneTransition{
+	{GameMenu, GameActive}:     {render.TransitionZoom, 0.5, tween.OutCubic},
+	{GameMenu, GameTutorial}:   {render.TransitionWipe, 0.4, tween.InOutQuad},
+	{GameMenu, GameWarmUp}:     {render.TransitionZoom, 0.5, tween.OutCubic},
+	{GameWarmUp, GameActive}:   {render.TransitionWipe, 0.4, tween.InOutQuad},
+	{GameMenu, GameIntro}:      {render.TransitionZoom, 0.5, tween.OutCubic},
+	{GameSetBreak, GameActive}: {render.TransitionWipe, 0.4, tween.InOutQuad},
+	{GameActive, GameMenu}:     {render.TransitionFade, 0.4, tween.Linear},
+	{GamePaused, GameMenu}:     {render.TransitionFade, 0.4, tween.Linear},
+	{GameSetBreak, GameMenu}:   {render.TransitionFade, 0.4, tween.Linear},
+	{GameTutorial, GameMenu}:   {render.TransitionFade, 0.4, tween.Linear},
+	{GameWarmUp, GameMenu}:     {render.TransitionFade, 0.4, tween.Linear},
+	{GameIntro, GameMenu}:      {render.TransitionFade, 0.4, tween.Linear},
+	{GameWin, GameMenu}:        {render.TransitionFade, 0.6, tween.InOutQuad},
+}
+
+// transition follows the scene shown and the effect revealing it
+type transition struct {
+	scene   GameState
+	current sceneTransition
+	time    float64 // Seconds since the scene changed
+}
+
+// updateTransition starts the transition of the scene change since the last update, then hands the
+// eased progress of the effect to the postprocessor
+func (g *Game) updateTransition(deltaTime float64) {
+	t := &g.transition
+	if g.state != t.scene {
+		t.current = sceneTransitions[sceneChange{t.scene, g.state}]
+		t.scene = g.state
+		t.time = 0
+		if g.state == GameMenu {
+			g.animateMenu()
+		}
+	} else {
+		t.time += deltaTime
+	}
+	if t.current.duration == 0 || t.time >= t.current.duration {
+		g.effects.Transition = render.TransitionNone
+		return
+	}
+	g.effects.Transition = t.current.effect
+	g.effects.TransitionProgress = float32(t.current.easing(t.time / t.current.duration))
+}
@@ -0,0 +1,121 @@
+package pong
+
+import (
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// tutorialStep is an action the player has to perform to go on with the tutorial
+type tutorialStep int
+
+const (
+	tutorialMoveUp tutorialStep = iota
+	tutorialMoveDown
+	tutorialReturn
+	tutorialAngle
+	tutorialDone
+)
+
+var (
+	tutorialMoveDistance = float32(300) // How far the paddle has to be moved up and down
+	tutorialServeSpeed   = float32(700) // Speed of the scripted serves
+	tutorialTexts        = []string{
+		"Move your paddle up with W",
+		"Now move it down with S",
+		"Here comes the serve: hit the ball back",
+		"Hit the ball while moving to send it at an angle",
+		"Well done! Press ENTER to play",
+	}
+	tutorialKeys = [][]string{{"W"}, {"S"}, {"W", "S"}, {"W", "S"}, {"ENTER"}}
+)
+
+// tutorial holds the progress of the tutorial
+type tutorial struct {
+	step  tutorialStep
+	moved float32 // Distance the paddle was moved in the direction asked
+}
+
+// startTutorial resets the game and starts the tutorial with the ball parked in the middle
+func (g *Game) startTutorial() {
+	// The tutorial teaches the plain rules
+	g.reset(Mutators(0).rules())
+	g.tutorial = tutorial{}
+	g.ball.Reset(mgl.Vec2{float32(g.width/2) - g.ball.radius, float32(g.height/2) - g.ball.radius}, mgl.Vec2{0, 0})
+	g.state = GameTutorial
+}
+
+// TutorialCompleted reports whether the tutorial was finished or skipped
+func (g *Game) TutorialCompleted() bool {
+	return g.tutorialCompleted
+}
+
+// simulateTutorial checks the actions asked by the tutorial and moves the scripted ball:
+// it's served towards the player, bounces on the right wall and is served again when missed
+func (g *Game) simulateTutorial(deltaTime float64) simulationEvents {
+	var events simulationEvents
+	if deltaTime == 0 {
+		return events
+	}
+	t := &g.tutorial
+	switch t.step {
+	case tutorialMoveUp, tutorialMoveDown:
+		direction := float32(-1)
+		if t.step == tutorialMoveDown {
+			direction = 1
+		}
+		if g.paddle1.velocity.Y()*direction > 0 {
+			t.moved += paddleVelocity * float32(deltaTime)
+		}
+		if t.moved >= tutorialMoveDistance {
+			t.step++
+			t.moved = 0
+			if t.step == tutorialReturn {
+				g.serveTutorialBall()
+			}
+		}
+		return events
+	case tutorialDone:
+		return events
+	}
+
+	incoming := g.ball.velocity
+	g.ball.Move(deltaTime, g.width, g.height)
+	events.wallHit = g.ball.velocity.Y() != incoming.Y()
+	events.hitBy, events.hitPaddle = g.DoCollisions()
+	events.paddleHit = events.hitBy != 0
+	if events.hitBy == 1 && (t.step == tutorialReturn || g.paddle1.velocity.Y() != 0) {
+		t.step++
+	}
+	if t.step == tutorialDone {
+		// Park the ball, the tutorial is over
+		g.ball.velocity = mgl.Vec2{0, 0}
+	} else if g.ball.position.X() <= 0 {
+		g.serveTutorialBall()
+	} else if g.ball.position.X()+g.ball.size.X() >= float32(g.width) && g.ball.velocity.X() > 0 {
+		g.ball.velocity[0] = -g.ball.velocity.X()
+	}
+	return events
+}
+
+// serveTutorialBall serves the ball from the middle of the court straight at the paddle of the player
+func (g *Game) serveTutorialBall() {
+	center := mgl.Vec2{float32(g.width / 2), float32(g.height / 2)}
+	target := g.paddle1.AABB().Center()
+	velocity := target.Sub(center).Normalize().Mul(tutorialServeSpeed)
+	g.ball.Reset(center.Sub(mgl.Vec2{g.ball.radius, g.ball.radius}), velocity)
+}
+
+// drawTutorial renders the instruction of the current step with the keys to press highlighted
+func (g *Game) drawTutorial() {
+	t := g.tutorial
+	g.text.RenderText(float32(g.width/2)-460, 220, 0.45, mgl.Vec3{1.0, 1.0, 1.0}, "%v", tutorialTexts[t.step])
+	x := float32(g.width/2) - 460
+	for _, key := range tutorialKeys[t.step] {
+		width := float32(60 + 30*(len(key)-1))
+		g.renderer.Draw(mgl.Vec2{x, 270}, mgl.Vec2{width, 60}, 0, mgl.Vec3{1.0, 0.8, 0.1})
+		g.text.RenderText(x+15, 282, 0.35, mgl.Vec3{0.1, 0.1, 0.1}, "%v", key)
+		x += width + 20
+	}
+	if t.step < tutorialDone {
+		g.text.RenderText(float32(g.width/2)-460, float32(g.height)-120, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "Step %v of %v - ENTER to skip - G aiming aid", int(t.step)+1, len(tutorialTexts)-1)
+	}
+}
@@ -0,0 +1,124 @@
+package pong
+
+import (
+	"fmt"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+var (
+	warmUpReadyTime  = 1.0 // Seconds a player holds up and down together to be ready
+	warmUpCountdown  = 3.0 // Seconds from both players ready to the first serve
+	warmUpReadyColor = mgl.Vec3{0.2, 1.0, 0.2}
+)
+
+// warmUp holds the readiness of the players while they warm up before a match
+type warmUp struct {
+	held      [4]float64 // Seconds the players have been holding up and down together, the front ones of doubles last
+	ready     [4]bool
+	countdown float64 // Seconds left before the first serve, once all the players are ready
+}
+
+// startWarmUp resets the game and lets the players move and hit the ball without scoring until both
+// are ready
+func (g *Game) startWarmUp() {
+	g.Reset()
+	g.warmUp = warmUp{}
+	g.state = GameWarmUp
+}
+
+// processWarmUp moves the paddles and makes ready the players holding up and down together long
+// enough, starting the countdown once all the players of the paddles in play are
+func (g *Game) processWarmUp(input Input, deltaTime float64) {
+	holding := [4]bool{
+		input.Paddle1Up && input.Paddle1Down, input.Paddle2Up && input.Paddle2Down,
+		input.Paddle3Up && input.Paddle3Down, input.Paddle4Up && input.Paddle4Down,
+	}
+	paddles, _ := g.paddles()
+	ready := true
+	for i := range paddles {
+		if !g.warmUp.ready[i] {
+			if holding[i] {
+				g.warmUp.held[i] += deltaTime
+			} else {
+				g.warmUp.held[i] = 0
+			}
+			g.warmUp.ready[i] = g.warmUp.held[i] >= warmUpReadyTime
+		}
+		ready = ready && g.warmUp.ready[i]
+	}
+	if ready && g.warmUp.countdown == 0 {
+		g.warmUp.countdown = warmUpCountdown
+	}
+	g.movePaddles(input, deltaTime)
+}
+
+// simulateWarmUp moves the ball bouncing off the paddles and all the ends of the court, nobody
+// scores, then starts the match once the countdown ends
+func (g *Game) simulateWarmUp(deltaTime float64) simulationEvents {
+	var events simulationEvents
+	if deltaTime == 0 {
+		return events
+	}
+	incoming := g.ball.velocity
+	g.ball.Move(deltaTime, g.width, g.height)
+	events.wallHit = g.ball.velocity.Y() != incoming.Y()
+	events.wallHit = g.bounceLevel(g.ball) || events.wallHit
+	events.hitBy, events.hitPaddle = g.DoCollisions()
+	events.paddleHit = events.hitBy != 0
+	if g.ball.position.X() <= 0 {
+		g.ball.position[0] = 0
+		g.ball.velocity[0] = abs(g.ball.velocity.X())
+		events.wallHit = true
+	} else if g.ball.position.X()+g.ball.size.X() >= float32(g.width) {
+		g.ball.position[0] = float32(g.width) - g.ball.size.X()
+		g.ball.velocity[0] = -abs(g.ball.velocity.X())
+		events.wallHit = true
+	}
+	if g.warmUp.countdown > 0 {
+		g.warmUp.countdown -= deltaTime
+		if g.warmUp.countdown <= 0 {
+			g.Reset()
+			g.state = GameActive
+		}
+	}
+	return events
+}
+
+// drawWarmUp renders the readiness of the players on their side of the screen and the countdown
+func (g *Game) drawWarmUp() {
+	if g.state != GameWarmUp {
+		return
+	}
+	if g.warmUp.countdown > 0 {
+		g.drawCentered(float32(g.height/2)-60, 1.5, mgl.Vec3{1.0, 1.0, 1.0}, fmt.Sprint(int(g.warmUp.countdown)+1))
+		return
+	}
+	g.drawCentered(220, 0.45, mgl.Vec3{1.0, 1.0, 1.0}, "WARM-UP")
+	for side, player := range g.screenPlayers() {
+		x := float32(60)
+		if side == 1 {
+			x = float32(g.width) - 460
+		}
+		// In doubles the front player of the team is shown above the back one
+		team := []int{player}
+		if g.rules.doubles {
+			team = append(team, player+2)
+		}
+		for row, player := range team {
+			y := float32(g.height) - 160 - float32(row)*90
+			label := ""
+			if g.rules.doubles {
+				label = fmt.Sprintf("P%v ", player)
+			}
+			if g.warmUp.ready[player-1] {
+				g.text.RenderText(x, y, 0.35, warmUpReadyColor, "%vREADY", label)
+				continue
+			}
+			g.text.RenderText(x, y, 0.3, mgl.Vec3{0.6, 0.6, 0.6}, "%vHold up and down when ready", label)
+			if held := float32(g.warmUp.held[player-1] / warmUpReadyTime); held > 0 {
+				g.renderer.Draw(mgl.Vec2{x, y + 50}, mgl.Vec2{400 * held, 10}, 0, warmUpReadyColor)
+			}
+		}
+	}
+}